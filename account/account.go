@@ -0,0 +1,116 @@
+// Package account finds the session files of the named accounts st can use on the judges.
+// The default account of a judge uses its session file in "~/.st", every other account
+// keeps its session files in a folder of Dir, e.g. "~/.st/accounts/team/codeforces_session".
+package account
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const Default = "default"
+
+// FileName is the file choosing the account in a folder and its subfolders.
+const FileName = ".st-account"
+
+const ErrorInvalidName = `the name of an account can contain only letters, digits, "-" and "_"`
+const ErrorUnknownAccount = `the account %v chosen with --account or .st-account doesn't exist on this judge, add it with "st account add"`
+
+// Dir is the folder with the named accounts.
+var Dir string
+
+// Override is the account chosen for this run (with --account or FileName).
+// The commands on a judge on which it doesn't exist fail, see Choose.
+var Override string
+
+var nameRegexp = regexp.MustCompile(`^[\w-]+$`)
+
+func ValidName(name string) error {
+	if !nameRegexp.MatchString(name) {
+		return errors.New(ErrorInvalidName)
+	}
+	return nil
+}
+
+// Path returns the session file of the account, sessionPath is the one of the default account.
+func Path(sessionPath, name string) string {
+	if name == "" || name == Default {
+		return sessionPath
+	}
+	return filepath.Join(Dir, name, filepath.Base(sessionPath))
+}
+
+// Name returns the account of a session file.
+func Name(path string) string {
+	dir := filepath.Dir(path)
+	if filepath.Dir(dir) == filepath.Clean(Dir) {
+		return filepath.Base(dir)
+	}
+	return Default
+}
+
+// Exists tells if the judge with the default session file sessionPath has the account.
+func Exists(sessionPath, name string) bool {
+	if name == "" || name == Default {
+		return true
+	}
+	if ValidName(name) != nil {
+		return false
+	}
+	_, err := os.Stat(Path(sessionPath, name))
+	return err == nil
+}
+
+// List returns the accounts of a judge, the default one first.
+func List(sessionPath string) []string {
+	names := []string{Default}
+	entries, _ := os.ReadDir(Dir)
+	var named []string
+	for _, entry := range entries {
+		if entry.IsDir() && Exists(sessionPath, entry.Name()) {
+			named = append(named, entry.Name())
+		}
+	}
+	sort.Strings(named)
+	return append(names, named...)
+}
+
+// Choose returns the account to use on a judge: Override when it is set, or else active,
+// the account chosen with "st account use". It returns the default account and an error
+// when the judge has no account Override, so that nothing is done as another user.
+func Choose(sessionPath, active string) (string, error) {
+	if Override != "" {
+		if !Exists(sessionPath, Override) {
+			return Default, fmt.Errorf(ErrorUnknownAccount, Override)
+		}
+		return Override, nil
+	}
+	if active != "" && Exists(sessionPath, active) {
+		return active, nil
+	}
+	return Default, nil
+}
+
+// FromFolder returns the account written in FileName in dir or the nearest of its parents.
+func FromFolder(dir string) string {
+	for {
+		if data, err := os.ReadFile(filepath.Join(dir, FileName)); err == nil {
+			return strings.TrimSpace(string(data))
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// Label describes the user of a session file, e.g. "tourist (account team)".
+func Label(path, username string) string {
+	return fmt.Sprintf("%v (account %v)", username, Name(path))
+}
@@ -0,0 +1,53 @@
+package account
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestChoose(t *testing.T) {
+	home := t.TempDir()
+	Dir = filepath.Join(home, "accounts")
+	defer func() { Dir, Override = "", "" }()
+	session := filepath.Join(home, "codeforces_session")
+	team := Path(session, "team")
+	if err := os.MkdirAll(filepath.Dir(team), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(team, []byte("{}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if Name(team) != "team" || Name(session) != Default {
+		t.Errorf("Unexpected names %v and %v.", Name(team), Name(session))
+	}
+	tests := []struct {
+		override, active, want string
+		fails                  bool
+	}{
+		{"", "", Default, false},
+		{"", "team", "team", false},
+		{"", "gone", Default, false},
+		{"team", "", "team", false},
+		{"gone", "team", Default, true},
+		{"../team", "", Default, true},
+	}
+	for _, test := range tests {
+		Override = test.override
+		if got, err := Choose(session, test.active); got != test.want || (err != nil) != test.fails {
+			t.Errorf("%+v: expect %v, but found %v (%v).", test, test.want, got, err)
+		}
+	}
+
+	contest := filepath.Join(home, "contest", "a")
+	if err := os.MkdirAll(contest, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, "contest", FileName), []byte("team\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := FromFolder(contest); got != "team" {
+		t.Errorf("Expect the account of the parent folder, but found %q.", got)
+	}
+}
@@ -0,0 +1,149 @@
+package atcoder_client
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Arapak/sio-tool/credentials"
+	"github.com/Arapak/sio-tool/replay/replaytest"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+// host is the site the fixtures are recorded from, ST_RECORD_FIXTURES=1 records them again
+// with the session of "st config". The expected values are copied from the recorded pages,
+// so they have to be updated with the pages.
+const host = "https://atcoder.jp"
+
+func init() {
+	replaytest.Session = func() (*http.Client, string, error) {
+		vault, _ := homedir.Expand("~/.st/vault")
+		credentials.Init(credentials.Auto, vault)
+		path, _ := homedir.Expand("~/.st/atcoder_session")
+		Init(path, host, "")
+		if err := Instance.CheckSession(); err != nil {
+			if err = Instance.Login(); err != nil {
+				return nil, "", err
+			}
+		}
+		return Instance.client, Instance.Username, nil
+	}
+}
+
+func TestFindStrings(t *testing.T) {
+	tasks := replaytest.Fixture(t, host+"/contests/abc350/tasks")
+	login := replaytest.AnonymousFixture(t, host+"/login")
+	submit := replaytest.Fixture(t, host+"/contests/abc350/submit")
+	replaytest.TestStrings(t, []replaytest.StringTest{
+		{Name: "username", Page: tasks, Find: findUsername, Want: "st_user"},
+		{Name: "not logged", Page: login, Find: findUsername},
+		{Name: "csrf", Page: login, Find: findCsrf, Want: "Wf1R3kqU0SpnvIRvzVcMsSv6tD9kBJcE4lqXxvG8l3g="},
+		{Name: "error", Page: submit, Find: findErrorMessage, Want: "You cannot submit the same code in a row."},
+	})
+}
+
+func TestFindSample(t *testing.T) {
+	input, output, err := findSample(replaytest.Fixture(t, host+"/contests/abc350/tasks/abc350_b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantInput := []string{"30 6\n2 9 18 27 18 9\n", "1 7\n1 1 1 1 1 1 1\n"}
+	wantOutput := []string{"28\n", "0\n"}
+	if len(input) != len(wantInput) || len(output) != len(wantOutput) {
+		t.Fatalf("Expect %v samples, but found %q and %q.", len(wantInput), input, output)
+	}
+	for i := range wantInput {
+		if string(input[i]) != wantInput[i] || string(output[i]) != wantOutput[i] {
+			t.Errorf("Sample %v: expect %q %q, but found %q %q.", i+1, wantInput[i], wantOutput[i], input[i], output[i])
+		}
+	}
+}
+
+func TestFindLanguages(t *testing.T) {
+	languages, err := findLanguages(replaytest.Fixture(t, host+"/contests/abc350/tasks/abc350_b"), "abc350_b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct{ value, name string }{
+		{"5001", "C++ 20 (gcc 12.2)"},
+		{"5055", "Python (CPython 3.11.4)"},
+		{"5054", "Rust (rustc 1.70.0)"},
+	}
+	if len(languages) != len(want) {
+		t.Fatalf("Expect %v languages, but found %+v.", len(want), languages)
+	}
+	for i, language := range languages {
+		if language.Value != want[i].value || language.Name != want[i].name {
+			t.Errorf("Expect %+v, but found %+v.", want[i], language)
+		}
+	}
+}
+
+func TestFindSubmissions(t *testing.T) {
+	submissions, err := findSubmissions(replaytest.Fixture(t, host+"/contests/abc350/submissions/me"), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		id                  uint64
+		task, status, score string
+		end                 bool
+	}{
+		{52544511, "abc350_c", "3/15 WA", "0", false},
+		{52540000, "abc350_b", "AC", "200", true},
+		{52535110, "abc350_a", "CE", "0", true},
+	}
+	if len(submissions) != len(want) {
+		t.Fatalf("Expect %v submissions, but found %+v.", len(want), submissions)
+	}
+	for i, s := range submissions {
+		w := want[i]
+		if s.id != w.id || s.task != w.task || s.status != w.status || s.score != w.score || s.end != w.end {
+			t.Errorf("Expect %+v, but found %+v.", w, s)
+		}
+	}
+}
+
+func TestFindStartTime(t *testing.T) {
+	start, err := findStartTime(replaytest.Fixture(t, host+"/contests/abc351"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := time.Date(2024, 4, 27, 21, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	if !start.Equal(want) {
+		t.Errorf("Expect %v, but found %v.", want, start)
+	}
+}
+
+func TestStatis(t *testing.T) {
+	c := &AtcoderClient{host: host, client: replaytest.FixtureClient(t)}
+	problems, _, err := c.Statis(Info{ContestID: "abc350"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct{ task, name, state string }{
+		{"abc350_a", "Past ABCs", "rejected"},
+		{"abc350_b", "Dentist Aoki", "accepted"},
+		{"abc350_c", "Sort", "rejected"},
+	}
+	if len(problems) != len(want) {
+		t.Fatalf("Expect %v problems, but found %+v.", len(want), problems)
+	}
+	for i, problem := range problems {
+		if problem.TaskScreenName != want[i].task || problem.Name != want[i].name || problem.State != want[i].state {
+			t.Errorf("Expect %+v, but found %+v.", want[i], problem)
+		}
+	}
+}
+
+func TestCheckSession(t *testing.T) {
+	c := &AtcoderClient{host: host, client: replaytest.FixtureClient(t)}
+	if err := c.CheckSession(); err != nil {
+		t.Errorf("Expect a valid session, but found %v.", err)
+	}
+	c.client = replaytest.AnonymousClient()
+	if err := c.CheckSession(); !c.Site().NotLogged(err) {
+		t.Errorf("Expect an expired session, but found %v.", err)
+	}
+}
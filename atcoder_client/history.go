@@ -0,0 +1,42 @@
+package atcoder_client
+
+import (
+	"database/sql"
+	"os"
+	"strings"
+
+	"github.com/Arapak/sio-tool/database_client"
+	"github.com/fatih/color"
+)
+
+const judgeName = "atcoder"
+
+// recordSubmission saves a submission made with st, together with the hash of its source, in the database.
+func (c *AtcoderClient) recordSubmission(db *sql.DB, info Info, s Submission, sourcePath string) {
+	if db == nil {
+		return
+	}
+	submission := database_client.Submission{
+		Judge:        judgeName,
+		SubmissionID: s.ParseID(),
+		ContestID:    info.ContestID,
+		ShortName:    strings.ToUpper(info.ProblemID),
+		When:         s.when,
+		Language:     s.lang,
+		Status:       s.status,
+		Points:       database_client.NoPoints,
+		FilePath:     sourcePath,
+	}
+	if s.Accepted() {
+		submission.Points = 100
+	} else if s.end {
+		submission.Points = 0
+	}
+	if source, err := os.ReadFile(sourcePath); err == nil {
+		submission.SourceHash = database_client.SourceHash(source)
+	}
+	submission.TaskID, _ = database_client.FindTaskID(db, database_client.Task{Source: "atcoder", ContestID: info.ContestID, ShortName: submission.ShortName})
+	if err := database_client.AddSubmission(db, submission); err != nil {
+		color.Red(err.Error())
+	}
+}
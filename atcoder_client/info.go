@@ -0,0 +1,119 @@
+package atcoder_client
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+type Info struct {
+	ContestID    string `json:"contest_id"`
+	ProblemID    string `json:"problem_id"`
+	SubmissionID string `json:"submission_id"`
+	RootPath     string
+}
+
+const ErrorNeedProblemID = "you have to specify the Problem ID"
+const ErrorNeedContestID = "you have to specify the Contest ID"
+const ErrorNeedSubmissionID = "you have to specify the Submission ID"
+
+func (info *Info) Hint() string {
+	text := "ATCODER"
+	if info.ContestID != "" {
+		text = text + " " + strings.ToUpper(info.ContestID)
+	}
+	if info.ProblemID != "" {
+		text = text + ", problem " + strings.ToUpper(info.ProblemID)
+	}
+	if info.SubmissionID != "" {
+		text = text + ", submission " + info.SubmissionID
+	}
+	return text
+}
+
+func (info *Info) Path() string {
+	path := info.RootPath
+	if info.ContestID != "" {
+		path = filepath.Join(path, info.ContestID)
+	}
+	if info.ProblemID != "" {
+		path = filepath.Join(path, strings.ToLower(info.ProblemID))
+	}
+	return path
+}
+
+// TaskScreenName returns the name AtCoder uses for the problem in its URLs and forms, e.g. abc350_a.
+func (info *Info) TaskScreenName() string {
+	return fmt.Sprintf("%v_%v", strings.ReplaceAll(info.ContestID, "-", "_"), strings.ToLower(info.ProblemID))
+}
+
+func (info *Info) ContestURL(host string) (string, error) {
+	if info.ContestID == "" {
+		return "", errors.New(ErrorNeedContestID)
+	}
+	return fmt.Sprintf(host+"/contests/%v", info.ContestID), nil
+}
+
+func (info *Info) TasksURL(host string) (string, error) {
+	URL, err := info.ContestURL(host)
+	if err != nil {
+		return "", err
+	}
+	return URL + "/tasks", nil
+}
+
+func (info *Info) ProblemURL(host string) (string, error) {
+	if info.ProblemID == "" {
+		return "", errors.New(ErrorNeedProblemID)
+	}
+	URL, err := info.TasksURL(host)
+	if err != nil {
+		return "", err
+	}
+	return URL + "/" + info.TaskScreenName(), nil
+}
+
+func (info *Info) SubmitURL(host string) (string, error) {
+	URL, err := info.ContestURL(host)
+	if err != nil {
+		return "", err
+	}
+	return URL + "/submit", nil
+}
+
+func (info *Info) MySubmissionURL(host string) (string, error) {
+	URL, err := info.ContestURL(host)
+	if err != nil {
+		return "", err
+	}
+	return URL + "/submissions/me", nil
+}
+
+func (info *Info) SubmissionURL(host string) (string, error) {
+	if info.SubmissionID == "" {
+		return "", errors.New(ErrorNeedSubmissionID)
+	}
+	URL, err := info.ContestURL(host)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(URL+"/submissions/%v", info.SubmissionID), nil
+}
+
+func (info *Info) StandingsURL(host string) (string, error) {
+	URL, err := info.ContestURL(host)
+	if err != nil {
+		return "", err
+	}
+	return URL + "/standings", nil
+}
+
+func (info *Info) OpenURL(host string) (string, error) {
+	if info.ContestID == "" {
+		return host + "/contests/", nil
+	} else if info.ProblemID == "" {
+		return info.TasksURL(host)
+	}
+	return info.ProblemURL(host)
+}
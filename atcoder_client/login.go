@@ -0,0 +1,114 @@
+package atcoder_client
+
+import (
+	"errors"
+	"html"
+	"net/url"
+	"regexp"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/Arapak/sio-tool/cookiejar"
+	"github.com/Arapak/sio-tool/credentials"
+	"github.com/Arapak/sio-tool/util"
+
+	"github.com/fatih/color"
+)
+
+const ErrorNotLogged = "not logged in"
+
+func findUsername(body []byte) (string, error) {
+	reg := regexp.MustCompile(`var userScreenName = "([^"]+?)"`)
+	tmp := reg.FindSubmatch(body)
+	if len(tmp) < 2 {
+		return "", errors.New(ErrorNotLogged)
+	}
+	return string(tmp[1]), nil
+}
+
+func findCsrf(body []byte) (string, error) {
+	reg := regexp.MustCompile(`name="csrf_token" value="(.+?)"`)
+	tmp := reg.FindSubmatch(body)
+	if len(tmp) < 2 {
+		return "", errors.New("cannot find csrf")
+	}
+	return html.UnescapeString(string(tmp[1])), nil
+}
+
+// CheckSession checks that the saved session is still logged in.
+func (c *AtcoderClient) CheckSession() (err error) {
+	body, err := util.GetBody(c.client, c.host)
+	if err != nil {
+		return
+	}
+	_, err = findUsername(body)
+	return
+}
+
+func (c *AtcoderClient) Login() (err error) {
+	color.Cyan("Login %v...\n", c.Username)
+
+	password, err := c.DecryptPassword()
+	if err != nil {
+		return
+	}
+
+	jar, _ := cookiejar.New(nil)
+
+	c.client.Jar = jar
+	body, err := util.GetBody(c.client, c.host+"/login")
+	if err != nil {
+		return
+	}
+	csrf, err := findCsrf(body)
+	if err != nil {
+		return
+	}
+
+	body, err = util.PostBody(c.client, c.host+"/login", url.Values{
+		"csrf_token": {csrf},
+		"username":   {c.Username},
+		"password":   {password},
+	})
+	if err != nil {
+		return
+	}
+
+	username, err := findUsername(body)
+	if err != nil {
+		return
+	}
+
+	c.Username = username
+	c.Jar = jar
+	color.Green("Succeed!!")
+	color.Green("Welcome %v~", username)
+	return c.save()
+}
+
+func (c *AtcoderClient) DecryptPassword() (string, error) {
+	if len(c.Username) == 0 {
+		return "", errors.New(credentials.ErrorNotFound)
+	}
+	return credentials.Get("atcoder", c.Username)
+}
+
+func (c *AtcoderClient) ConfigLogin() (err error) {
+	if c.Username != "" {
+		color.Green("Current user: %v", c.Username)
+	}
+	color.Cyan("Configure username and password")
+
+	username := ""
+	util.GetValue("username:", &username, true)
+
+	password := ""
+	if err = survey.AskOne(&survey.Password{Message: `password:`}, &password); err != nil {
+		return
+	}
+
+	c.Username = username
+	if err = credentials.Set("atcoder", username, password); err != nil {
+		return
+	}
+	return c.Login()
+}
@@ -0,0 +1,175 @@
+package atcoder_client
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Arapak/sio-tool/database_client"
+	"github.com/Arapak/sio-tool/util"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/fatih/color"
+	"github.com/k0kubun/go-ansi"
+)
+
+var sampleTitles = [][2]string{
+	{"Sample Input", "Sample Output"},
+	{"入力例", "出力例"},
+}
+
+func sampleText(s *goquery.Selection) []byte {
+	text := strings.ReplaceAll(s.Text(), "\r\n", "\n")
+	text = strings.TrimLeft(text, "\n")
+	return []byte(strings.TrimRight(text, "\n") + "\n")
+}
+
+func findSample(body []byte) (input [][]byte, output [][]byte, err error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	statement := doc.Find("#task-statement span.lang-en")
+	if statement.Length() == 0 {
+		statement = doc.Find("#task-statement")
+	}
+	for _, titles := range sampleTitles {
+		statement.Find("section").Each(func(_ int, s *goquery.Selection) {
+			title := strings.TrimSpace(s.Find("h3").First().Text())
+			if strings.HasPrefix(title, titles[0]) {
+				input = append(input, sampleText(s.Find("pre").First()))
+			} else if strings.HasPrefix(title, titles[1]) {
+				output = append(output, sampleText(s.Find("pre").First()))
+			}
+		})
+		if len(input) > 0 {
+			break
+		}
+	}
+	if len(input) != len(output) {
+		return nil, nil, errors.New("the numbers of sample inputs and outputs differ")
+	}
+	return
+}
+
+func (c *AtcoderClient) ParseProblem(URL, path string, mu *sync.Mutex) (samples int, perf util.Performance, err error) {
+	perf.StartFetching()
+
+	body, err := util.GetBody(c.client, URL)
+	if err != nil {
+		return
+	}
+
+	perf.StopFetching()
+	perf.StartParsing()
+
+	_, err = findUsername(body)
+	if err != nil {
+		return
+	}
+
+	input, output, err := findSample(body)
+	if err != nil {
+		return
+	}
+
+	perf.StopParsing()
+
+	for i := 0; i < len(input); i++ {
+		fileIn := filepath.Join(path, fmt.Sprintf("in%v.txt", i+1))
+		fileOut := filepath.Join(path, fmt.Sprintf("out%v.txt", i+1))
+		e := os.WriteFile(fileIn, input[i], 0644)
+		if e != nil {
+			mu.Lock()
+			color.Red(e.Error())
+			mu.Unlock()
+		}
+		e = os.WriteFile(fileOut, output[i], 0644)
+		if e != nil {
+			mu.Lock()
+			color.Red(e.Error())
+			mu.Unlock()
+		}
+	}
+	return len(input), perf, nil
+}
+
+func (c *AtcoderClient) Parse(info Info, db *sql.DB) (problems []string, paths []string, err error) {
+	color.Cyan("Parse " + info.Hint())
+
+	start := time.Now()
+
+	statis, err := c.problems(info)
+	if err != nil {
+		return
+	}
+	if info.ProblemID != "" {
+		prob, ok := findProblem(statis, info.ProblemID)
+		if !ok {
+			return nil, nil, fmt.Errorf("cannot find problem %v", info.ProblemID)
+		}
+		statis = []StatisInfo{prob}
+	}
+	info.ProblemID = ""
+	contestPath := info.Path()
+	_, _ = ansi.Printf(color.CyanString("The problem(s) will be saved to %v\n"), color.GreenString(contestPath))
+
+	var avgPerformance util.Performance
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(statis))
+	mu := sync.Mutex{}
+	problems = make([]string, len(statis))
+	paths = make([]string, len(statis))
+	for i, prob := range statis {
+		problems[i] = prob.ID
+		paths[i] = filepath.Join(contestPath, prob.ID)
+		go func(prob StatisInfo, path string) {
+			defer wg.Done()
+			mu.Lock()
+			fmt.Printf("Parsing %v\n", prob.ID)
+			mu.Unlock()
+
+			err := os.MkdirAll(path, os.ModePerm)
+			if err != nil {
+				return
+			}
+			URL := fmt.Sprintf("%v/contests/%v/tasks/%v", c.host, info.ContestID, prob.TaskScreenName)
+
+			samples, perf, err := c.ParseProblem(URL, path, &mu)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				color.Red("Failed %v. Error: %v", prob.ID, err.Error())
+				return
+			}
+			avgPerformance.Fetching += perf.Fetching
+			avgPerformance.Parsing += perf.Parsing
+			color.Green("Parsed %v. %v with %v samples.", prob.ID, prob.Name, samples)
+			task := database_client.Task{
+				Name:      prob.Name,
+				Source:    "atcoder",
+				Path:      path,
+				ShortName: strings.ToUpper(prob.ID),
+				Link:      URL,
+				ContestID: info.ContestID,
+			}
+			if err = database_client.AddTask(db, task); err != nil {
+				color.Red(err.Error())
+			}
+		}(prob, paths[i])
+	}
+	wg.Wait()
+	avgPerformance.Fetching = util.AverageTime(avgPerformance.Fetching, len(statis))
+	avgPerformance.Parsing = util.AverageTime(avgPerformance.Parsing, len(statis))
+	fmt.Printf("Average: (%v)\n", avgPerformance.Parse())
+	fmt.Printf("Total: %s\n", time.Since(start).Round(time.Millisecond))
+	return
+}
@@ -0,0 +1,17 @@
+package atcoder_client
+
+import (
+	"errors"
+
+	"github.com/Arapak/sio-tool/util"
+)
+
+const ErrorAtcoderIsUnavailable = "atcoder is unavailable (check your internet connection)"
+
+func (c *AtcoderClient) Ping() (err error) {
+	_, err = util.GetBody(c.client, c.host)
+	if err != nil {
+		return errors.New(ErrorAtcoderIsUnavailable)
+	}
+	return
+}
@@ -0,0 +1,55 @@
+package atcoder_client
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Arapak/sio-tool/util"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/fatih/color"
+)
+
+// findStartTime returns the start of the contest, the first time shown on the contest page.
+func findStartTime(body []byte) (time.Time, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return time.Time{}, err
+	}
+	start := strings.TrimSpace(doc.Find("time.fixtime-full").First().Text())
+	if start == "" {
+		return time.Time{}, errors.New("cannot find the start time of the contest")
+	}
+	return time.Parse(atcoderTime, start)
+}
+
+func (c *AtcoderClient) RaceContest(info Info) (err error) {
+	color.Cyan("Race " + info.Hint())
+
+	URL, err := info.ContestURL(c.host)
+	if err != nil {
+		return
+	}
+
+	body, err := util.GetBody(c.client, URL)
+	if err != nil {
+		return
+	}
+
+	_, err = findUsername(body)
+	if err != nil {
+		return
+	}
+
+	start, err := findStartTime(body)
+	if err != nil {
+		return
+	}
+	if count := time.Until(start); count > 0 {
+		color.Green("Countdown: ")
+		util.Countdown(int64(count.Round(time.Second).Seconds()))
+	}
+	return
+}
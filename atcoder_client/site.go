@@ -0,0 +1,123 @@
+package atcoder_client
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/Arapak/sio-tool/site"
+	"github.com/Arapak/sio-tool/util"
+)
+
+// judge implements site.Site for AtCoder.
+type judge struct {
+	site.Judge
+	c *AtcoderClient
+}
+
+func (c *AtcoderClient) Site() site.Site {
+	return judge{site.Judge{
+		Session:        c,
+		ID:             "atcoder",
+		HostOf:         func() string { return c.host },
+		UsernameOf:     func() string { return c.Username },
+		ErrorNotLogged: ErrorNotLogged,
+	}, c}
+}
+
+func atcoderInfo(info site.Info) (*Info, error) {
+	if i, ok := info.(*Info); ok {
+		return i, nil
+	}
+	return nil, errors.New(site.ErrorWrongInfo)
+}
+
+func (j judge) Parse(info site.Info, db *sql.DB) (paths []string, err error) {
+	i, err := atcoderInfo(info)
+	if err != nil {
+		return
+	}
+	_, paths, err = j.c.Parse(*i, db)
+	return
+}
+
+func (j judge) Submit(info site.Info, options site.SubmitOptions, sourcePath string, db *sql.DB) error {
+	i, err := atcoderInfo(info)
+	if err != nil {
+		return err
+	}
+	if options.Kind != "" || options.User != "" {
+		return errors.New(site.ErrorNotSupported)
+	}
+	return j.c.Submit(*i, options.Lang, sourcePath, db)
+}
+
+func (j judge) Watch(info site.Info, n int, line bool) error {
+	i, err := atcoderInfo(info)
+	if err != nil {
+		return err
+	}
+	_, err = j.c.WatchSubmission(*i, n, line)
+	return err
+}
+
+func (j judge) Statis(info site.Info) (header []string, problems []site.Problem, perf util.Performance, err error) {
+	i, err := atcoderInfo(info)
+	if err != nil {
+		return
+	}
+	statis, perf, err := j.c.Statis(*i)
+	if err != nil {
+		return
+	}
+	header = []string{"#", "problem", "time limit", "memory limit"}
+	for _, prob := range statis {
+		problems = append(problems, site.Problem{
+			Columns: []string{strings.ToUpper(prob.ID), prob.Name, prob.TimeLimit, prob.MemoryLimit},
+			State:   prob.State,
+		})
+	}
+	return
+}
+
+func (j judge) OpenURL(info site.Info) (string, error) {
+	i, err := atcoderInfo(info)
+	if err != nil {
+		return "", err
+	}
+	return i.OpenURL(j.c.host)
+}
+
+func (j judge) StandingsURL(info site.Info) (string, error) {
+	i, err := atcoderInfo(info)
+	if err != nil {
+		return "", err
+	}
+	return i.StandingsURL(j.c.host)
+}
+
+func (j judge) SubmissionURL(info site.Info) (string, error) {
+	i, err := atcoderInfo(info)
+	if err != nil {
+		return "", err
+	}
+	if i.SubmissionID == "" && j.c.LastSubmission != nil {
+		i = j.c.LastSubmission
+	}
+	return i.SubmissionURL(j.c.host)
+}
+
+func (j judge) Race(info site.Info) (urls []string, err error) {
+	i, err := atcoderInfo(info)
+	if err != nil {
+		return
+	}
+	if err = j.c.RaceContest(*i); err != nil {
+		return
+	}
+	URL, err := i.TasksURL(j.c.host)
+	if err != nil {
+		return
+	}
+	return []string{URL}, nil
+}
@@ -0,0 +1,139 @@
+package atcoder_client
+
+import (
+	"errors"
+	"path"
+	"strings"
+
+	"github.com/Arapak/sio-tool/util"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+type StatisInfo struct {
+	ID             string
+	Name           string
+	TaskScreenName string
+	TimeLimit      string
+	MemoryLimit    string
+	State          string
+}
+
+func findProblems(body []byte) (problems []StatisInfo, err error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(body)))
+	if err != nil {
+		return
+	}
+	doc.Find("table tbody tr").Each(func(_ int, s *goquery.Selection) {
+		cells := s.Find("td")
+		if cells.Length() < 4 {
+			return
+		}
+		link, ok := cells.Eq(0).Find("a").Attr("href")
+		if !ok || !strings.Contains(link, "/tasks/") {
+			return
+		}
+		problems = append(problems, StatisInfo{
+			ID:             strings.ToLower(strings.TrimSpace(cells.Eq(0).Text())),
+			Name:           strings.TrimSpace(cells.Eq(1).Text()),
+			TaskScreenName: path.Base(link),
+			TimeLimit:      strings.TrimSpace(cells.Eq(2).Text()),
+			MemoryLimit:    strings.TrimSpace(cells.Eq(3).Text()),
+		})
+	})
+	if len(problems) == 0 {
+		return nil, errors.New("cannot find any problem")
+	}
+	return
+}
+
+func findProblem(problems []StatisInfo, problemID string) (StatisInfo, bool) {
+	for _, prob := range problems {
+		if prob.ID == strings.ToLower(problemID) {
+			return prob, true
+		}
+	}
+	return StatisInfo{}, false
+}
+
+// findSolved returns the task screen names of the problems with a submission on the page.
+func findSolved(body []byte) (map[string]bool, error) {
+	submissions, err := findSubmissions(body, -1)
+	if err != nil {
+		return nil, err
+	}
+	solved := map[string]bool{}
+	for _, s := range submissions {
+		solved[s.task] = true
+	}
+	return solved, nil
+}
+
+// problems returns the problems of the contest without their state.
+func (c *AtcoderClient) problems(info Info) (problems []StatisInfo, err error) {
+	URL, err := info.TasksURL(c.host)
+	if err != nil {
+		return
+	}
+	body, err := util.GetBody(c.client, URL)
+	if err != nil {
+		return
+	}
+	if _, err = findUsername(body); err != nil {
+		return
+	}
+	return findProblems(body)
+}
+
+func (c *AtcoderClient) Statis(info Info) (problems []StatisInfo, perf util.Performance, err error) {
+	URL, err := info.MySubmissionURL(c.host)
+	if err != nil {
+		return
+	}
+
+	perf.StartFetching()
+
+	body, err := util.GetBody(c.client, URL+"?f.Status=AC")
+	if err != nil {
+		return
+	}
+	triedBody, err := util.GetBody(c.client, URL)
+	if err != nil {
+		return
+	}
+
+	perf.StopFetching()
+	perf.StartParsing()
+
+	if _, err = findUsername(body); err != nil {
+		return
+	}
+	accepted, _ := findSolved(body)
+	// only the latest submissions are on the first page, older rejected problems stay unmarked
+	tried, _ := findSolved(triedBody)
+
+	perf.StopParsing()
+
+	if problems, err = c.problems(info); err != nil {
+		return
+	}
+	for i := range problems {
+		if accepted[problems[i].TaskScreenName] {
+			problems[i].State = "accepted"
+		} else if tried[problems[i].TaskScreenName] {
+			problems[i].State = "rejected"
+		}
+	}
+	return
+}
+
+// taskScreenName returns the name of the problem used in the submit form. It is read from the
+// problem list, because problems shared between contests keep the name from the original contest.
+func (c *AtcoderClient) taskScreenName(info Info) string {
+	if problems, err := c.problems(info); err == nil {
+		if prob, ok := findProblem(problems, info.ProblemID); ok {
+			return prob.TaskScreenName
+		}
+	}
+	return info.TaskScreenName()
+}
@@ -0,0 +1,128 @@
+package atcoder_client
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/Arapak/sio-tool/account"
+	"github.com/Arapak/sio-tool/database_client"
+	"github.com/Arapak/sio-tool/util"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/fatih/color"
+)
+
+// findLanguages returns the languages which can be chosen for the problem in the submit form.
+func findLanguages(body []byte, taskScreenName string) (languages []util.Language, err error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	options := doc.Find(fmt.Sprintf("#select-lang-%v option", taskScreenName))
+	if options.Length() == 0 {
+		options = doc.Find(`select[name="data.LanguageId"] option`)
+	}
+	options.Each(func(_ int, s *goquery.Selection) {
+		value, _ := s.Attr("value")
+		if value != "" {
+			languages = append(languages, util.Language{Value: value, Name: strings.TrimSpace(s.Text())})
+		}
+	})
+	if len(languages) == 0 {
+		return nil, errors.New("cannot find any language")
+	}
+	return
+}
+
+func findErrorMessage(body []byte) (string, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	msg := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(doc.Find(".alert-danger").First().Text()), "×"))
+	if msg == "" {
+		return "", errors.New("cannot find error")
+	}
+	return msg, nil
+}
+
+func (c *AtcoderClient) Submit(info Info, lang, sourcePath string, db *sql.DB) (err error) {
+	color.Cyan("Submit " + info.Hint())
+
+	if info.ProblemID == "" {
+		return errors.New(ErrorNeedProblemID)
+	}
+	URL, err := info.SubmitURL(c.host)
+	if err != nil {
+		return
+	}
+
+	body, err := util.GetBody(c.client, URL)
+	if err != nil {
+		return
+	}
+
+	username, err := findUsername(body)
+	if err != nil {
+		return
+	}
+
+	fmt.Printf("Current user: %v\n", account.Label(c.path, username))
+
+	check := database_client.Submission{Judge: judgeName, ContestID: info.ContestID, ShortName: strings.ToUpper(info.ProblemID)}
+	if err = database_client.CheckSubmission(db, check, sourcePath, database_client.UnknownLimit); err != nil {
+		return
+	}
+
+	csrf, err := findCsrf(body)
+	if err != nil {
+		return
+	}
+
+	taskScreenName := c.taskScreenName(info)
+	languages, err := findLanguages(body, taskScreenName)
+	if err != nil {
+		return
+	}
+	language, err := util.ChooseLanguage(languages, lang, sourcePath)
+	if err != nil {
+		return
+	}
+
+	source, err := os.ReadFile(sourcePath)
+	if err != nil {
+		return
+	}
+
+	body, err = util.PostBody(c.client, URL, url.Values{
+		"csrf_token":          {csrf},
+		"data.TaskScreenName": {taskScreenName},
+		"data.LanguageId":     {language},
+		"sourceCode":          {string(source)},
+	})
+	if err != nil {
+		return
+	}
+
+	if errMsg, err := findErrorMessage(body); err == nil {
+		return errors.New(errMsg)
+	}
+
+	color.Green("Submitted")
+
+	submissions, err := c.WatchSubmission(info, 1, true)
+	if err != nil {
+		return
+	}
+
+	info.SubmissionID = submissions[0].ParseID()
+	c.Username = username
+	c.LastSubmission = &info
+	c.recordSubmission(db, info, submissions[0], sourcePath)
+	return c.save()
+}
@@ -0,0 +1,297 @@
+package atcoder_client
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Arapak/sio-tool/util"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/fatih/color"
+	"github.com/k0kubun/go-ansi"
+	"github.com/olekukonko/tablewriter"
+)
+
+type Submission struct {
+	name   string
+	task   string
+	id     uint64
+	status string
+	score  string
+	time   uint64
+	memory uint64
+	lang   string
+	when   string
+	end    bool
+}
+
+var judgingReg = regexp.MustCompile(`^\d+/\d+`)
+
+// isWait reports whether the status is one of the labels shown before the submission is judged,
+// e.g. "WJ" or "3/15 AC".
+func isWait(status string) bool {
+	return status == "WJ" || status == "WR" || status == "Judging" || judgingReg.MatchString(status)
+}
+
+func (s *Submission) ParseStatus() string {
+	if !s.end {
+		return color.New(color.FgWhite).Sprint(s.status)
+	} else if s.status == "AC" {
+		return color.New(color.FgGreen).Sprint(s.status)
+	}
+	return color.New(color.FgRed).Sprint(s.status)
+}
+
+func (s *Submission) ParseID() string {
+	return fmt.Sprintf("%v", s.id)
+}
+
+func (s *Submission) Name() string {
+	return s.name
+}
+
+// End reports whether the submission has been judged.
+func (s *Submission) End() bool {
+	return s.end
+}
+
+func (s *Submission) Accepted() bool {
+	return s.status == "AC"
+}
+
+func (s *Submission) ParseMemory() string {
+	if s.memory > 1024*1024 {
+		return fmt.Sprintf("%.2f MB", float64(s.memory)/1024.0/1024.0)
+	} else if s.memory > 1024 {
+		return fmt.Sprintf("%.2f KB", float64(s.memory)/1024.0)
+	}
+	return fmt.Sprintf("%v B", s.memory)
+}
+
+func (s *Submission) ParseTime() string {
+	return fmt.Sprintf("%v ms", s.time)
+}
+
+func (s *Submission) ParseProblemIndex() string {
+	p := strings.Index(s.name, " - ")
+	if p == -1 {
+		return ""
+	}
+	return strings.ToLower(s.name[:p])
+}
+
+func refreshLine(n int, maxWidth int) {
+	for i := 0; i < n; i++ {
+		_, _ = ansi.Printf("%v\n", strings.Repeat(" ", maxWidth))
+	}
+	ansi.CursorUp(n)
+}
+
+func updateLine(line string, maxWidth *int) string {
+	*maxWidth = len(line)
+	return line
+}
+
+func (s *Submission) display(first bool, maxWidth *int) {
+	if !first {
+		ansi.CursorUp(8)
+	}
+	_, _ = ansi.Printf("      #: %v\n", s.ParseID())
+	_, _ = ansi.Printf("   when: %v\n", s.when)
+	_, _ = ansi.Printf("   prob: %v\n", s.name)
+	_, _ = ansi.Printf("   lang: %v\n", s.lang)
+	refreshLine(1, *maxWidth)
+	_, _ = ansi.Printf(updateLine(fmt.Sprintf(" status: %v\n", s.ParseStatus()), maxWidth))
+	_, _ = ansi.Printf("  score: %v\n", s.score)
+	_, _ = ansi.Printf("   time: %v\n", s.ParseTime())
+	_, _ = ansi.Printf(" memory: %v\n", s.ParseMemory())
+}
+
+func display(submissions []Submission, problemID string, first bool, maxWidth *int, line bool) {
+	if line {
+		submissions[0].display(first, maxWidth)
+		return
+	}
+	var buf bytes.Buffer
+	output := io.Writer(&buf)
+	table := tablewriter.NewWriter(output)
+	table.SetHeader([]string{"#", "when", "problem", "lang", "status", "score", "time", "memory"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetAlignment(tablewriter.ALIGN_CENTER)
+	table.SetCenterSeparator("|")
+	table.SetAutoWrapText(false)
+	for _, sub := range submissions {
+		if problemID != "" && sub.ParseProblemIndex() != strings.ToLower(problemID) {
+			continue
+		}
+		table.Append([]string{
+			sub.ParseID(),
+			sub.when,
+			sub.name,
+			sub.lang,
+			sub.ParseStatus(),
+			sub.score,
+			sub.ParseTime(),
+			sub.ParseMemory(),
+		})
+	}
+	table.Render()
+
+	if !first {
+		ansi.CursorUp(len(submissions) + 2)
+	}
+	refreshLine(len(submissions)+2, *maxWidth)
+
+	scanner := bufio.NewScanner(io.Reader(&buf))
+	for scanner.Scan() {
+		line := scanner.Text()
+		*maxWidth = len(line)
+		_, _ = ansi.Println(line)
+	}
+}
+
+const atcoderTime = "2006-01-02 15:04:05-0700"
+
+func parseWhen(raw string) string {
+	tm, err := time.Parse(atcoderTime, raw)
+	if err != nil {
+		return raw
+	}
+	return tm.In(time.Local).Format("2006-01-02 15:04")
+}
+
+var numberReg = regexp.MustCompile(`\d+`)
+
+func parseNumber(s string) uint64 {
+	n, _ := strconv.ParseUint(numberReg.FindString(s), 10, 64)
+	return n
+}
+
+func parseSubmission(s *goquery.Selection) (ret Submission, err error) {
+	cells := s.Find("td")
+	if cells.Length() < 7 {
+		return ret, errors.New("cannot parse the submission")
+	}
+	task, _ := cells.Eq(1).Find("a").Attr("href")
+	ret = Submission{
+		when:   parseWhen(strings.TrimSpace(cells.Eq(0).Text())),
+		name:   strings.TrimSpace(cells.Eq(1).Text()),
+		task:   path.Base(task),
+		lang:   strings.TrimSpace(cells.Eq(3).Text()),
+		score:  strings.TrimSpace(cells.Eq(4).Text()),
+		status: strings.TrimSpace(cells.Eq(6).Text()),
+	}
+	if id, ok := cells.Eq(4).Attr("data-id"); ok {
+		ret.id, _ = strconv.ParseUint(id, 10, 64)
+	}
+	// a submission which did not compile has no time and memory cells
+	cells.Slice(7, cells.Length()).Each(func(_ int, cell *goquery.Selection) {
+		text := strings.TrimSpace(cell.Text())
+		if strings.HasSuffix(text, " ms") {
+			ret.time = parseNumber(text)
+		} else if strings.HasSuffix(text, " KB") {
+			ret.memory = parseNumber(text) * 1024
+		} else if link, ok := cell.Find("a").Attr("href"); ok && ret.id == 0 {
+			ret.id = parseNumber(path.Base(link))
+		}
+	})
+	if ret.status == "" {
+		ret.status = "Unknown"
+	}
+	ret.end = !isWait(ret.status)
+	return
+}
+
+func findSubmissions(body []byte, n int) (submissions []Submission, err error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	doc.Find("table tbody tr").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		if submission, err := parseSubmission(s); err == nil {
+			submissions = append(submissions, submission)
+		}
+		return n < 0 || len(submissions) < n
+	})
+	return
+}
+
+func (c *AtcoderClient) getSubmissions(URL string, n int) (submissions []Submission, err error) {
+	body, err := util.GetBody(c.client, URL)
+	if err != nil {
+		return
+	}
+
+	if _, err = findUsername(body); err != nil {
+		return
+	}
+
+	if submissions, err = findSubmissions(body, n); err != nil {
+		return
+	}
+
+	if len(submissions) < 1 {
+		return nil, errors.New("cannot find any submission")
+	}
+
+	return
+}
+
+func (c *AtcoderClient) WatchSubmission(info Info, n int, line bool) (submissions []Submission, err error) {
+	URL, err := info.MySubmissionURL(c.host)
+	if err != nil {
+		return
+	}
+
+	maxWidth := 0
+	first := true
+	poller := util.NewPoller()
+	previous := ""
+	for {
+		submissions, err = c.getSubmissions(URL, n)
+		if err != nil {
+			if poller.Backoff(err) {
+				if err = poller.Wait(); err != nil {
+					return
+				}
+				continue
+			}
+			return
+		}
+		display(submissions, info.ProblemID, first, &maxWidth, line)
+		first = false
+		endCount := 0
+		for _, submission := range submissions {
+			if submission.end {
+				endCount++
+			}
+		}
+		if endCount == len(submissions) {
+			return
+		}
+		if state := statusSignature(submissions); state != previous {
+			previous = state
+			poller.Reset()
+		}
+		if err = poller.Wait(); err != nil {
+			return
+		}
+	}
+}
+
+// statusSignature changes whenever the status of any of the submissions changes.
+func statusSignature(submissions []Submission) string {
+	var signature strings.Builder
+	for _, s := range submissions {
+		signature.WriteString(fmt.Sprint(s.id, s.status, s.score, ";"))
+	}
+	return signature.String()
+}
@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/Arapak/sio-tool/account"
+	"github.com/Arapak/sio-tool/atcoder_client"
+	"github.com/Arapak/sio-tool/codeforces_client"
+	"github.com/Arapak/sio-tool/config"
+	"github.com/Arapak/sio-tool/credentials"
+	"github.com/Arapak/sio-tool/domjudge_client"
+	"github.com/Arapak/sio-tool/kattis_client"
+	"github.com/Arapak/sio-tool/sio_client"
+	"github.com/Arapak/sio-tool/site"
+	"github.com/Arapak/sio-tool/szkopul_client"
+	"github.com/Arapak/sio-tool/util"
+
+	"github.com/fatih/color"
+	"github.com/k0kubun/go-ansi"
+	"github.com/olekukonko/tablewriter"
+)
+
+const ErrorAccountNotFound = "there is no such account on this judge, add it with \"st account add\""
+const ErrorAccountExists = "the account already exists"
+const ErrorRemoveDefaultAccount = "the default account can't be removed, log in to another user with \"st config\""
+
+// initJudge starts the client of the judge again with the session file path.
+func initJudge(judge, path string) {
+	cfg := config.Instance
+	switch judge {
+	case "codeforces":
+		codeforces_client.Init(path, cfg.CodeforcesHost, cfg.Proxy)
+	case "szkopul":
+		szkopul_client.Init(path, cfg.SzkopulHost, cfg.Proxy)
+	case "atcoder":
+		atcoder_client.Init(path, cfg.AtcoderHost, cfg.Proxy)
+	case "kattis":
+		kattis_client.Init(path, cfg.KattisRCPath(path), cfg.Proxy)
+	case "domjudge":
+		domjudge_client.Init(path, cfg.DomjudgeHost, cfg.Proxy)
+	default:
+		if instance, ok := cfg.SioInstance(judge); ok {
+			sio_client.Init(path, instance.Host, cfg.Proxy, instance.Name, instance.Flavour)
+		}
+	}
+}
+
+// configLogin asks for the credentials of the judge and logs in.
+func configLogin(judge string) error {
+	switch judge {
+	case "codeforces":
+		return codeforces_client.Instance.ConfigLogin()
+	case "szkopul":
+		return szkopul_client.Instance.ConfigLogin()
+	case "atcoder":
+		return atcoder_client.Instance.ConfigLogin()
+	case "kattis":
+		return kattis_client.Instance.ConfigLogin()
+	case "domjudge":
+		return domjudge_client.Instance.ConfigLogin()
+	}
+	return sio_client.Get(judge).ConfigLogin()
+}
+
+// readSession reads the user and the login (the key of the password, which differs
+// only on Codeforces) from a session file which isn't used in this run.
+func readSession(path string) (user, login string) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	var session struct {
+		Handle        string `json:"handle"`
+		HandleOrEmail string `json:"handle_or_email"`
+	}
+	_ = json.Unmarshal(data, &session)
+	if session.HandleOrEmail != "" {
+		return session.Handle, session.HandleOrEmail
+	}
+	return session.Handle, session.Handle
+}
+
+// loginUsed tells if an account of the judge other than removed logs in as login,
+// as the accounts with the same login share the password in the credential store.
+func loginUsed(path, removed, login string) bool {
+	for _, name := range account.List(path) {
+		if name == removed {
+			continue
+		}
+		if _, other := readSession(account.Path(path, name)); other == login {
+			return true
+		}
+	}
+	return false
+}
+
+// AccountList prints the accounts on every judge, the ones used in this run are green.
+func AccountList() (err error) {
+	cfg := config.Instance
+	var buf bytes.Buffer
+	output := io.Writer(&buf)
+	table := tablewriter.NewWriter(output)
+	table.SetHeader([]string{"judge", "account", "user"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetCenterSeparator("|")
+	table.SetAutoWrapText(false)
+	for _, s := range site.All() {
+		path, err := cfg.DefaultSessionPath(s.Name())
+		if err != nil {
+			continue
+		}
+		used, err := account.Choose(path, cfg.Accounts[s.Name()])
+		if err != nil {
+			used = ""
+		}
+		for _, name := range account.List(path) {
+			user, _ := readSession(account.Path(path, name))
+			if name == used {
+				user = s.Username()
+			}
+			record := []string{s.Name(), name, user}
+			if name == used {
+				for i := range record {
+					record[i] = util.GreenString(record[i])
+				}
+			}
+			table.Append(record)
+		}
+	}
+	table.Render()
+
+	scanner := bufio.NewScanner(io.Reader(&buf))
+	for scanner.Scan() {
+		_, _ = ansi.Println(scanner.Text())
+	}
+	if account.Override != "" {
+		color.Cyan("Account %v is chosen for this run (with --account or %v)", account.Override, account.FileName)
+	}
+	return
+}
+
+// AccountUse makes st use the account on the judge.
+func AccountUse() (err error) {
+	path, err := config.Instance.DefaultSessionPath(Args.Judge)
+	if err != nil {
+		return
+	}
+	if !account.Exists(path, Args.AccountName) {
+		return errors.New(ErrorAccountNotFound)
+	}
+	if err = config.Instance.UseAccount(Args.Judge, Args.AccountName); err != nil {
+		return
+	}
+	color.Green("Using account %v on %v", Args.AccountName, Args.Judge)
+	return
+}
+
+// AccountAdd logs in to a new account on the judge.
+func AccountAdd() (err error) {
+	path, err := config.Instance.DefaultSessionPath(Args.Judge)
+	if err != nil {
+		return
+	}
+	if err = account.ValidName(Args.AccountName); err != nil {
+		return
+	}
+	if account.Exists(path, Args.AccountName) {
+		return errors.New(ErrorAccountExists)
+	}
+	path = account.Path(path, Args.AccountName)
+	if Args.Judge == "kattis" {
+		color.Cyan("Kattis reads the credentials of the account from %v", filepath.Join(filepath.Dir(path), "kattisrc"))
+	}
+	initJudge(Args.Judge, path)
+	if err = configLogin(Args.Judge); err != nil {
+		os.Remove(path)
+		os.Remove(filepath.Dir(path))
+		return
+	}
+	color.Green(`Added account %v on %v, use it with "st account use %v %v" or --account %v`, Args.AccountName, Args.Judge, Args.Judge, Args.AccountName, Args.AccountName)
+	return
+}
+
+// AccountRemove removes the session file of the account, and its password when no other
+// account of the judge uses the same login.
+func AccountRemove() (err error) {
+	cfg := config.Instance
+	path, err := cfg.DefaultSessionPath(Args.Judge)
+	if err != nil {
+		return
+	}
+	if Args.AccountName == account.Default {
+		return errors.New(ErrorRemoveDefaultAccount)
+	}
+	if !account.Exists(path, Args.AccountName) {
+		return errors.New(ErrorAccountNotFound)
+	}
+	defaultPath := path
+	path = account.Path(path, Args.AccountName)
+	if _, login := readSession(path); login != "" && !loginUsed(defaultPath, Args.AccountName, login) {
+		if err = credentials.Delete(Args.Judge, login); err != nil {
+			color.Red(err.Error())
+		}
+	}
+	if err = os.Remove(path); err != nil {
+		return
+	}
+	// the folder is removed only when no other judge uses the account
+	os.Remove(filepath.Dir(path))
+	if cfg.Accounts[Args.Judge] == Args.AccountName {
+		if err = cfg.UseAccount(Args.Judge, account.Default); err != nil {
+			return
+		}
+	}
+	color.Green("Removed account %v on %v", Args.AccountName, Args.Judge)
+	return
+}
@@ -6,9 +6,13 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/Arapak/sio-tool/atcoder_client"
 	"github.com/Arapak/sio-tool/codeforces_client"
 	"github.com/Arapak/sio-tool/config"
+	"github.com/Arapak/sio-tool/domjudge_client"
+	"github.com/Arapak/sio-tool/kattis_client"
 	"github.com/Arapak/sio-tool/sio_client"
+	"github.com/Arapak/sio-tool/site"
 	"github.com/Arapak/sio-tool/szkopul_client"
 
 	"github.com/docopt/docopt-go"
@@ -18,6 +22,9 @@ type ParsedArgs struct {
 	CodeforcesInfo   codeforces_client.Info
 	SzkopulInfo      szkopul_client.Info
 	SioInfo          sio_client.Info
+	AtcoderInfo      atcoder_client.Info
+	KattisInfo       kattis_client.Info
+	DomjudgeInfo     domjudge_client.Info
 	File             string
 	Generator        string
 	Solve            string
@@ -29,6 +36,16 @@ type ParsedArgs struct {
 	Shortname        string
 	Contest          string
 	Stage            string
+	Round            string   `docopt:"--round"`
+	Retries          string   `docopt:"--retries"`
+	User             string   `docopt:"--user"`
+	Status           string   `docopt:"--status"`
+	Since            string   `docopt:"--since"`
+	Until            string   `docopt:"--until"`
+	Format           string   `docopt:"--format"`
+	Output           string   `docopt:"--output"`
+	HTML             string   `docopt:"--html"`
+	Compare          string   `docopt:"--compare"`
 	TimeLimit        string   `docopt:"--time_limit"`
 	MemoryLimit      string   `docopt:"--memory_limit"`
 	Specifier        []string `docopt:"<specifier>"`
@@ -45,11 +62,25 @@ type ParsedArgs struct {
 	Test             bool     `docopt:"test"`
 	PackageTest      bool     `docopt:"package_test"`
 	AddPackage       bool     `docopt:"add_package"`
+	Packages         bool     `docopt:"packages"`
+	Serve            bool     `docopt:"serve"`
+	Fetch            bool     `docopt:"fetch"`
+	Port             string   `docopt:"--port"`
+	URL              string   `docopt:"<url>"`
 	DownloadPackages bool     `docopt:"download_packages"`
 	UploadPackage    bool     `docopt:"upload_package"`
+	Admin            bool     `docopt:"admin"`
+	Problems         bool     `docopt:"problems"`
+	Submissions      bool     `docopt:"submissions"`
+	Sources          bool     `docopt:"sources"`
+	Move             bool     `docopt:"move"`
+	Rename           bool     `docopt:"rename"`
+	Rejudge          bool     `docopt:"rejudge"`
 	Watch            bool     `docopt:"watch"`
 	Open             bool     `docopt:"open"`
 	Stand            bool     `docopt:"stand"`
+	Ranking          bool     `docopt:"ranking"`
+	Report           bool     `docopt:"report"`
 	Sid              bool     `docopt:"sid"`
 	Race             bool     `docopt:"race"`
 	Pull             bool     `docopt:"pull"`
@@ -60,13 +91,28 @@ type ParsedArgs struct {
 	Add              bool     `docopt:"add"`
 	Find             bool     `docopt:"find"`
 	Goto             bool     `docopt:"goto"`
-	Codeforces       bool
-	Szkopul          bool
-	SioStaszic       bool
-	SioMimuw         bool
-	SioTalent        bool
-	Oiejq            bool
-	Verbose          bool
+	Sync             bool     `docopt:"sync"`
+	Stats            bool     `docopt:"stats"`
+	Watcher          bool     `docopt:"watcher"`
+	Account          bool     `docopt:"account"`
+	Use              bool     `docopt:"use"`
+	Remove           bool     `docopt:"remove"`
+	Judge            string   `docopt:"<judge>"`
+	AccountName      string   `docopt:"<account>"`
+	AccountOption    string   `docopt:"--account"`
+	Doctor           bool     `docopt:"doctor"`
+	// Site is the name of the judge chosen by determineClient, empty when no judge was chosen.
+	Site string
+	// Sio is the name of the Sio instance, empty when it isn't a Sio command.
+	Sio     string
+	Oiejq   bool
+	Verbose bool
+	Force   bool   `docopt:"--force"`
+	Kind    string `docopt:"--kind"`
+	As      string `docopt:"--as"`
+	Bell    bool   `docopt:"--bell"`
+	Desktop bool   `docopt:"--desktop"`
+	Hook    string `docopt:"--hook"`
 }
 
 var Args *ParsedArgs
@@ -75,57 +121,68 @@ func SubPath(parent, sub string) bool {
 	return strings.HasPrefix(parent, sub)
 }
 
+// judgeArgs parses the specifiers of a judge into the info of the judge.
+type judgeArgs struct {
+	parse func() error
+	info  func() site.Info
+}
+
+// judges are the judgeArgs of every judge but the Sio instances, which share sioArgs.
+var judges = map[string]judgeArgs{
+	"codeforces": {parseArgsCodeforces, func() site.Info { return &Args.CodeforcesInfo }},
+	"szkopul":    {parseArgsSzkopul, func() site.Info { return &Args.SzkopulInfo }},
+	"atcoder":    {parseArgsAtcoder, func() site.Info { return &Args.AtcoderInfo }},
+	"kattis":     {parseArgsKattis, func() site.Info { return &Args.KattisInfo }},
+	"domjudge":   {parseArgsDomjudge, func() site.Info { return &Args.DomjudgeInfo }},
+}
+
+var sioArgs = judgeArgs{
+	func() error {
+		if Args.Handle == "" {
+			Args.Handle = getSioClient().Username
+		}
+		return parseArgsSio(config.Instance.Root(Args.Sio))
+	},
+	func() site.Info { return &Args.SioInfo },
+}
+
+// argsOf returns the judgeArgs of the judge called name.
+func argsOf(name string) (judgeArgs, bool) {
+	if _, ok := config.Instance.SioInstance(name); ok {
+		return sioArgs, true
+	}
+	a, ok := judges[name]
+	return a, ok
+}
+
+// chooseSite makes s the judge of the command.
+func chooseSite(s site.Site) {
+	Args.Site = s.Name()
+	if _, ok := config.Instance.SioInstance(s.Name()); ok {
+		Args.Sio = s.Name()
+	}
+}
+
+// determineClient chooses the judge whose folder is the working directory,
+// or else the judge whose host is in a specifier.
 func determineClient() error {
 	path, err := os.Getwd()
 	if err != nil {
 		return err
 	}
 	cfg := config.Instance
-	codeforcesDir := SubPath(path, cfg.FolderName["codeforces-root"])
-	if codeforcesDir {
-		Args.Codeforces = true
-		return nil
-	}
-	sioStaszicDir := SubPath(path, cfg.FolderName["sio-staszic-root"])
-	if sioStaszicDir {
-		Args.SioStaszic = true
-		return nil
-	}
-	sioMimuwDir := SubPath(path, cfg.FolderName["sio-mimuw-root"])
-	if sioMimuwDir {
-		Args.SioMimuw = true
-		return nil
-	}
-	sioTalentDir := SubPath(path, cfg.FolderName["sio-talent-root"])
-	if sioTalentDir {
-		Args.SioTalent = true
-		return nil
-	}
-	szkopulDir := SubPath(path, cfg.FolderName["szkopul-root"])
-	if szkopulDir {
-		Args.Szkopul = true
-		return nil
-	}
-	for _, arg := range Args.Specifier {
-		if strings.Contains(arg, config.Instance.CodeforcesHost) {
-			Args.Codeforces = true
-			return nil
-		}
-		if strings.Contains(arg, config.Instance.SioStaszicHost) {
-			Args.SioStaszic = true
-			return nil
-		}
-		if strings.Contains(arg, config.Instance.SioMimuwHost) {
-			Args.SioMimuw = true
-			return nil
-		}
-		if strings.Contains(arg, config.Instance.SioTalentHost) {
-			Args.SioTalent = true
+	for _, s := range site.All() {
+		if root := cfg.Root(s.Name()); root != "" && SubPath(path, root) {
+			chooseSite(s)
 			return nil
 		}
-		if strings.Contains(arg, config.Instance.SzkopulHost) {
-			Args.Szkopul = true
-			return nil
+	}
+	for _, arg := range Args.Specifier {
+		for _, s := range site.All() {
+			if host := s.Host(); host != "" && strings.Contains(arg, host) {
+				chooseSite(s)
+				return nil
+			}
 		}
 	}
 	return nil
@@ -141,49 +198,42 @@ func parseArgs(opts docopt.Opts) error {
 	if err != nil {
 		return err
 	}
-	cfg := config.Instance
-	if Args.Codeforces {
-		return parseArgsCodeforces()
-	}
-	if Args.SioStaszic {
-		if Args.Handle == "" {
-			Args.Handle = sio_client.StaszicInstance.Username
-		}
-		return parseArgsSio(cfg.FolderName["sio-staszic-root"])
+	if a, ok := argsOf(Args.Site); ok {
+		return a.parse()
 	}
-	if Args.SioMimuw {
-		if Args.Handle == "" {
-			Args.Handle = sio_client.MimuwInstance.Username
-		}
-		return parseArgsSio(cfg.FolderName["sio-mimuw-root"])
-	}
-	if Args.SioTalent {
-		if Args.Handle == "" {
-			Args.Handle = sio_client.TalentInstance.Username
-		}
-		return parseArgsSio(cfg.FolderName["sio-talent-root"])
+	return nil
+}
+
+func isSio() bool {
+	return Args.Sio != ""
+}
+
+// currentSite returns the judge chosen by determineClient and the info parsed for it,
+// or nil when no judge was chosen.
+func currentSite() (site.Site, site.Info) {
+	a, ok := argsOf(Args.Site)
+	if !ok {
+		return nil, nil
 	}
-	if Args.Szkopul {
-		return parseArgsSzkopul()
+	s, err := site.Get(Args.Site)
+	if err != nil {
+		return nil, nil
 	}
-	return nil
+	return s, a.info()
 }
 
 const ErrorPackageCouldntBeDetermined = "package path couldn't be determined"
 
 func ArgsPackagePath() (path string, err error) {
 	cfg := config.Instance
-	if Args.Codeforces {
+	if Args.Site == "codeforces" {
 		Args.CodeforcesInfo.RootPath = filepath.Join(cfg.PackagesPath, "codeforces")
 		return Args.CodeforcesInfo.PackagePath()
-	} else if Args.Szkopul {
+	} else if Args.Site == "szkopul" {
 		Args.SzkopulInfo.RootPath = filepath.Join(cfg.PackagesPath, "szkopul")
 		return Args.SzkopulInfo.PackagePath()
-	} else if Args.SioStaszic {
-		Args.SioInfo.RootPath = filepath.Join(cfg.PackagesPath, "sio-staszic")
-		return Args.SioInfo.PackagePath()
-	} else if Args.SioMimuw {
-		Args.SioInfo.RootPath = filepath.Join(cfg.PackagesPath, "sio-mimuw")
+	} else if isSio() {
+		Args.SioInfo.RootPath = filepath.Join(cfg.PackagesPath, Args.Sio)
 		return Args.SioInfo.PackagePath()
 	}
 	return "", errors.New(ErrorPackageCouldntBeDetermined)
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/Arapak/sio-tool/atcoder_client"
+	"github.com/Arapak/sio-tool/config"
+)
+
+func parseArgsAtcoder() error {
+	cfg := config.Instance
+	cln := atcoder_client.Instance
+	path, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	if Args.Handle == "" {
+		Args.Handle = cln.Username
+	}
+	info := atcoder_client.Info{}
+	for _, arg := range Args.Specifier {
+		parsed := parseArgAtcoder(arg)
+		if value, ok := parsed["contestID"]; ok {
+			if info.ContestID != "" && info.ContestID != value {
+				return fmt.Errorf("contest ID conflicts: %v %v", info.ContestID, value)
+			}
+			info.ContestID = value
+		}
+		if value, ok := parsed["problemID"]; ok {
+			if info.ProblemID != "" && info.ProblemID != value {
+				return fmt.Errorf("problem ID conflicts: %v %v", info.ProblemID, value)
+			}
+			info.ProblemID = value
+		}
+		if value, ok := parsed["submissionID"]; ok {
+			if info.SubmissionID != "" && info.SubmissionID != value {
+				return fmt.Errorf("submission ID conflicts: %v %v", info.SubmissionID, value)
+			}
+			info.SubmissionID = value
+		}
+	}
+	if info.ContestID == "" {
+		parsed := parsePathAtcoder(cfg.FolderName["atcoder-root"], path)
+		if value, ok := parsed["contestID"]; ok {
+			info.ContestID = value
+		}
+		if value, ok := parsed["problemID"]; ok && info.ProblemID == "" {
+			info.ProblemID = value
+		}
+	}
+	info.RootPath = cfg.FolderName["atcoder-root"]
+	Args.AtcoderInfo = info
+	return nil
+}
+
+const AtcoderContestRegStr = `[\w-]+`
+
+const AtcoderStrictContestRegStr = `[a-z]+\d+`
+
+const AtcoderProblemRegStr = `[a-zA-Z]+`
+
+const AtcoderStrictProblemRegStr = `[a-zA-Z]{1,2}`
+
+const AtcoderSubmissionRegStr = `\d+`
+
+var AtcoderArgRegStr = [...]string{
+	fmt.Sprintf(`/contests/(?P<contestID>%v)/submissions/(?P<submissionID>%v)`, AtcoderContestRegStr, AtcoderSubmissionRegStr),
+	fmt.Sprintf(`/contests/(?P<contestID>%v)(/tasks/\w+?_(?P<problemID>%v))?`, AtcoderContestRegStr, AtcoderProblemRegStr),
+	fmt.Sprintf(`^(?P<contestID>%v)$`, AtcoderStrictContestRegStr),
+	fmt.Sprintf(`^(?P<problemID>%v)$`, AtcoderStrictProblemRegStr),
+	fmt.Sprintf(`^(?P<submissionID>%v)$`, AtcoderSubmissionRegStr),
+}
+
+func parseArgAtcoder(arg string) map[string]string {
+	output := make(map[string]string)
+	for _, regStr := range AtcoderArgRegStr {
+		reg := regexp.MustCompile(regStr)
+		names := reg.SubexpNames()
+		found := false
+		for i, val := range reg.FindStringSubmatch(arg) {
+			if names[i] != "" && val != "" {
+				output[names[i]] = val
+				found = true
+			}
+		}
+		if found {
+			break
+		}
+	}
+	return output
+}
+
+var AtcoderPathRegStr = fmt.Sprintf("%v/((?P<contestID>%v)/((?P<problemID>%v)/)?)?", "%v", AtcoderContestRegStr, AtcoderProblemRegStr)
+
+func parsePathAtcoder(dir, path string) map[string]string {
+	path = filepath.ToSlash(path) + "/"
+	output := make(map[string]string)
+	reg := regexp.MustCompile(fmt.Sprintf(AtcoderPathRegStr, regexp.QuoteMeta(dir)))
+	names := reg.SubexpNames()
+	for i, val := range reg.FindStringSubmatch(path) {
+		if names[i] != "" && val != "" {
+			output[names[i]] = val
+		}
+	}
+	return output
+}
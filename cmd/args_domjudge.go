@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Arapak/sio-tool/config"
+	"github.com/Arapak/sio-tool/domjudge_client"
+)
+
+func parseArgsDomjudge() error {
+	cfg := config.Instance
+	cln := domjudge_client.Instance
+	path, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	if Args.Handle == "" {
+		Args.Handle = cln.Username
+	}
+	info := domjudge_client.Info{}
+	for _, arg := range Args.Specifier {
+		parsed := parseArgDomjudge(arg)
+		if value, ok := parsed["contestID"]; ok {
+			if info.ContestID != "" && info.ContestID != value {
+				return fmt.Errorf("contest ID conflicts: %v %v", info.ContestID, value)
+			}
+			info.ContestID = value
+		}
+		if value, ok := parsed["problemID"]; ok {
+			value = strings.ToLower(value)
+			if info.ProblemID != "" && info.ProblemID != value {
+				return fmt.Errorf("problem ID conflicts: %v %v", info.ProblemID, value)
+			}
+			info.ProblemID = value
+		}
+		if value, ok := parsed["submissionID"]; ok {
+			if info.SubmissionID != "" && info.SubmissionID != value {
+				return fmt.Errorf("submission ID conflicts: %v %v", info.SubmissionID, value)
+			}
+			info.SubmissionID = value
+		}
+	}
+	if info.ContestID == "" {
+		parsed := parsePathDomjudge(cfg.FolderName["domjudge-root"], path)
+		if value, ok := parsed["contestID"]; ok {
+			info.ContestID = value
+		}
+		if value, ok := parsed["problemID"]; ok && info.ProblemID == "" {
+			info.ProblemID = value
+		}
+	}
+	info.RootPath = cfg.FolderName["domjudge-root"]
+	Args.DomjudgeInfo = info
+	return nil
+}
+
+const DomjudgeContestRegStr = `[\w.-]+`
+
+const DomjudgeStrictContestRegStr = `[a-zA-Z][\w.-]+`
+
+const DomjudgeProblemRegStr = `[a-zA-Z]\d?`
+
+const DomjudgeSubmissionRegStr = `\d+`
+
+var DomjudgeArgRegStr = [...]string{
+	fmt.Sprintf(`/submission/(?P<submissionID>%v)`, DomjudgeSubmissionRegStr),
+	fmt.Sprintf(`/contests/(?P<contestID>%v)`, DomjudgeContestRegStr),
+	fmt.Sprintf(`^(?P<submissionID>%v)$`, DomjudgeSubmissionRegStr),
+	fmt.Sprintf(`^(?P<problemID>%v)$`, DomjudgeProblemRegStr),
+	fmt.Sprintf(`^(?P<contestID>%v)$`, DomjudgeStrictContestRegStr),
+}
+
+func parseArgDomjudge(arg string) map[string]string {
+	output := make(map[string]string)
+	for _, regStr := range DomjudgeArgRegStr {
+		reg := regexp.MustCompile(regStr)
+		names := reg.SubexpNames()
+		found := false
+		for i, val := range reg.FindStringSubmatch(arg) {
+			if names[i] != "" && val != "" {
+				output[names[i]] = val
+				found = true
+			}
+		}
+		if found {
+			break
+		}
+	}
+	return output
+}
+
+var DomjudgePathRegStr = fmt.Sprintf("%v/((?P<contestID>%v)/((?P<problemID>%v)/)?)?", "%v", DomjudgeContestRegStr, DomjudgeProblemRegStr)
+
+func parsePathDomjudge(dir, path string) map[string]string {
+	path = filepath.ToSlash(path) + "/"
+	output := make(map[string]string)
+	reg := regexp.MustCompile(fmt.Sprintf(DomjudgePathRegStr, regexp.QuoteMeta(dir)))
+	names := reg.SubexpNames()
+	for i, val := range reg.FindStringSubmatch(path) {
+		if names[i] != "" && val != "" {
+			output[names[i]] = val
+		}
+	}
+	return output
+}
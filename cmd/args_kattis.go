@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/Arapak/sio-tool/config"
+	"github.com/Arapak/sio-tool/kattis_client"
+)
+
+func parseArgsKattis() error {
+	cfg := config.Instance
+	cln := kattis_client.Instance
+	path, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	if Args.Handle == "" {
+		Args.Handle = cln.Username()
+	}
+	info := kattis_client.Info{}
+	for _, arg := range Args.Specifier {
+		parsed := parseArgKattis(arg)
+		if value, ok := parsed["problemID"]; ok {
+			if info.ProblemID != "" && info.ProblemID != value {
+				return fmt.Errorf("problem ID conflicts: %v %v", info.ProblemID, value)
+			}
+			info.ProblemID = value
+		}
+		if value, ok := parsed["submissionID"]; ok {
+			if info.SubmissionID != "" && info.SubmissionID != value {
+				return fmt.Errorf("submission ID conflicts: %v %v", info.SubmissionID, value)
+			}
+			info.SubmissionID = value
+		}
+	}
+	if info.ProblemID == "" {
+		parsed := parsePathKattis(cfg.FolderName["kattis-root"], path)
+		if value, ok := parsed["problemID"]; ok {
+			info.ProblemID = value
+		}
+	}
+	info.RootPath = cfg.FolderName["kattis-root"]
+	Args.KattisInfo = info
+	return nil
+}
+
+const KattisProblemRegStr = `[a-z0-9][a-z0-9.]*`
+
+const KattisSubmissionRegStr = `\d+`
+
+var KattisArgRegStr = [...]string{
+	fmt.Sprintf(`/problems/(?P<problemID>%v)`, KattisProblemRegStr),
+	fmt.Sprintf(`/submissions/(?P<submissionID>%v)`, KattisSubmissionRegStr),
+	fmt.Sprintf(`^(?P<submissionID>%v)$`, KattisSubmissionRegStr),
+	fmt.Sprintf(`^(?P<problemID>%v)$`, KattisProblemRegStr),
+}
+
+func parseArgKattis(arg string) map[string]string {
+	output := make(map[string]string)
+	for _, regStr := range KattisArgRegStr {
+		reg := regexp.MustCompile(regStr)
+		names := reg.SubexpNames()
+		found := false
+		for i, val := range reg.FindStringSubmatch(arg) {
+			if names[i] != "" && val != "" {
+				output[names[i]] = val
+				found = true
+			}
+		}
+		if found {
+			break
+		}
+	}
+	return output
+}
+
+var KattisPathRegStr = fmt.Sprintf("%v/((?P<problemID>%v)/)?", "%v", KattisProblemRegStr)
+
+func parsePathKattis(dir, path string) map[string]string {
+	path = filepath.ToSlash(path) + "/"
+	output := make(map[string]string)
+	reg := regexp.MustCompile(fmt.Sprintf(KattisPathRegStr, regexp.QuoteMeta(dir)))
+	names := reg.SubexpNames()
+	for i, val := range reg.FindStringSubmatch(path) {
+		if names[i] != "" && val != "" {
+			output[names[i]] = val
+		}
+	}
+	return output
+}
@@ -69,6 +69,7 @@ const SioContestRegStr = `[\w-]+?`
 const SioRoundRegStr = `\w+?`
 
 var SioArgRegStr = [...]string{
+	fmt.Sprintf(`/c/(?P<contestID>%v)/s/(?P<submissionID>\d+)`, SioContestRegStr),
 	fmt.Sprintf(`/c/(?P<contestID>%v)/(p/(?P<problemAlias>%v)?)?`, SioContestRegStr, SioProblemRegStr),
 	fmt.Sprintf(`^(?P<problemID>%v)$`, SioProblemIdRegStr),
 	fmt.Sprintf(`^(?P<problemAlias>%v)$`, SioProblemRegStr),
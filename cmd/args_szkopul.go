@@ -100,6 +100,8 @@ var SzkopulArgRegStr = [...]string{
 	fmt.Sprintf(`^(?P<problemAlias>%v)$`, StrictSzkopulProblemRegStr),
 	fmt.Sprintf(`^(?P<contestID>%v)$`, OIContestRegStr),
 	fmt.Sprintf(`^(?P<stageID>%v)$`, OIStageRegStr),
+	`/s/(?P<submissionID>\d+)`,
+	`^(?P<submissionID>\d{2,})$`,
 }
 
 var SzkopulArgType = [...]string{
@@ -109,6 +111,8 @@ var SzkopulArgType = [...]string{
 	"OI",
 	"OI",
 	"OI",
+	"",
+	"",
 }
 
 func parseArgSzkopul(arg string) map[string]string {
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"github.com/Arapak/sio-tool/site"
+
+	"github.com/fatih/color"
+	"github.com/skratchdot/open-golang/open"
+)
+
+func openURL(url string) error {
+	color.Green("Open %v", url)
+	return open.Run(url)
+}
+
+func Open(s site.Site, info site.Info) (err error) {
+	var URL string
+	err = withRelogin(s, func() (err error) {
+		URL, err = s.OpenURL(info)
+		return
+	})
+	if err != nil {
+		return
+	}
+	return openURL(URL)
+}
+
+func Stand(s site.Site, info site.Info) (err error) {
+	var URL string
+	err = withRelogin(s, func() (err error) {
+		URL, err = s.StandingsURL(info)
+		return
+	})
+	if err != nil {
+		return
+	}
+	return openURL(URL)
+}
+
+func Sid(s site.Site, info site.Info) (err error) {
+	var URL string
+	err = withRelogin(s, func() (err error) {
+		URL, err = s.SubmissionURL(info)
+		return
+	})
+	if err != nil {
+		return
+	}
+	return openURL(URL)
+}
@@ -39,6 +39,30 @@ func Eval(opts docopt.Opts) error {
 		return PackageTest()
 	} else if Args.AddPackage {
 		return AddPackage()
+	} else if Args.Packages {
+		if Args.Serve {
+			return PackagesServe()
+		} else if Args.Fetch {
+			return PackagesFetch()
+		}
+	} else if Args.Sync {
+		return Sync()
+	} else if Args.Stats {
+		return Stats()
+	} else if Args.Watcher {
+		return Watcher()
+	} else if Args.Account {
+		if Args.List {
+			return AccountList()
+		} else if Args.Use {
+			return AccountUse()
+		} else if Args.Add {
+			return AccountAdd()
+		} else if Args.Remove {
+			return AccountRemove()
+		}
+	} else if Args.Doctor {
+		return Doctor()
 	} else if Args.Database {
 		if Args.Add {
 			return DatabaseAdd()
@@ -47,64 +71,72 @@ func Eval(opts docopt.Opts) error {
 		} else if Args.Goto {
 			return DatabaseGoto()
 		}
-	} else {
-		if Args.Codeforces {
-			if Args.Submit {
-				return CodeforcesSubmit()
-			} else if Args.List {
-				return CodeforcesList()
-			} else if Args.Parse {
-				return CodeforcesParse()
-			} else if Args.Watch {
-				return CodeforcesWatch()
-			} else if Args.Open {
-				return CodeforcesOpen()
-			} else if Args.Stand {
-				return CodeforcesStand()
-			} else if Args.Sid {
-				return CodeforcesSid()
-			} else if Args.Race {
-				return CodeforcesRace()
-			} else if Args.Pull {
+	} else if s, info := currentSite(); s != nil {
+		if err = config.Instance.AccountError(s.Name()); err != nil {
+			return err
+		}
+		if Args.Site == "codeforces" {
+			if Args.Pull {
 				return CodeforcesPull()
 			}
-		} else if Args.Szkopul {
+		} else if Args.Site == "szkopul" {
 			if Args.Submit {
 				return SzkopulSubmit()
-			} else if Args.Watch {
-				return SzkopulWatch()
-			} else if Args.Parse {
-				return SzkopulParse()
-			} else if Args.Sid {
-				return SzkopulSid()
 			} else if Args.Open {
 				return SzkopulOpen()
-			} else if Args.List {
-				return SzkopulList()
+			} else if Args.Report {
+				return SzkopulReport()
+			}
+		} else if Args.Site == "domjudge" {
+			if Args.Stand {
+				return DomjudgeStand()
 			}
-		} else if Args.SioStaszic || Args.SioMimuw || Args.SioTalent {
+		} else if isSio() {
 			if Args.Submit {
 				return SioSubmit()
-			} else if Args.Watch {
-				return SioWatch()
 			} else if Args.List {
 				return SioList()
-			} else if Args.Sid {
-				return SioSid()
-			} else if Args.Open {
-				return SioOpen()
-			} else if Args.Parse {
-				return SioParse()
-			} else if Args.Race {
-				return SioRace()
-			} else if Args.Stand {
-				return SioStand()
+			} else if Args.Ranking {
+				return SioRanking()
+			} else if Args.Report {
+				return SioReport()
 			} else if Args.DownloadPackages {
 				return SioDownloadPackages()
 			} else if Args.UploadPackage {
 				return SioUploadPackage()
+			} else if Args.Admin {
+				if Args.Problems {
+					return SioAdminProblems()
+				} else if Args.Move {
+					return SioAdminMove()
+				} else if Args.Rename {
+					return SioAdminRename()
+				} else if Args.Submissions {
+					return SioAdminSubmissions()
+				} else if Args.Sources {
+					return SioAdminSources()
+				} else if Args.Rejudge {
+					return SioAdminRejudge()
+				}
 			}
 		}
+		if Args.Submit {
+			return Submit(s, info)
+		} else if Args.List {
+			return List(s, info)
+		} else if Args.Parse {
+			return Parse(s, info)
+		} else if Args.Watch {
+			return Watch(s, info)
+		} else if Args.Open {
+			return Open(s, info)
+		} else if Args.Stand {
+			return Stand(s, info)
+		} else if Args.Sid {
+			return Sid(s, info)
+		} else if Args.Race {
+			return Race(s, info)
+		}
 	}
 	color.Red("This function is not available here. Maybe you are in the wrong folder?")
 	return nil
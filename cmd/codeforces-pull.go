@@ -18,10 +18,8 @@ func CodeforcesPull() (err error) {
 	if err != nil {
 		return
 	}
-	if err = cln.Pull(info, rootPath, ac); err != nil {
-		if err = loginAgainCodeforces(cln, err); err == nil {
-			err = cln.Pull(info, rootPath, ac)
-		}
-	}
+	err = withRelogin(cln.Site(), func() error {
+		return cln.Pull(info, rootPath, ac)
+	})
 	return
 }
@@ -1,46 +0,0 @@
-package cmd
-
-import (
-	"os"
-
-	"github.com/Arapak/sio-tool/codeforces_client"
-	"github.com/Arapak/sio-tool/config"
-
-	"github.com/fatih/color"
-)
-
-func CodeforcesSubmit() (err error) {
-	cln := codeforces_client.Instance
-	err = cln.Ping()
-	if err != nil {
-		return
-	}
-	cfg := config.Instance
-	info := Args.CodeforcesInfo
-	filename, index, err := getOneCode(Args.File, cfg.Template, map[string]struct{}{})
-	if err != nil {
-		return
-	}
-
-	bytes, err := os.ReadFile(filename)
-	if err != nil {
-		return
-	}
-	source := string(bytes)
-
-	lang := cfg.Template[index].Lang
-	if err = cln.Submit(info, lang, source); err != nil {
-		if err = loginAgainCodeforces(cln, err); err == nil {
-			err = cln.Submit(info, lang, source)
-		}
-	}
-	return
-}
-
-func loginAgainCodeforces(cln *codeforces_client.CodeforcesClient, err error) error {
-	if err != nil && err.Error() == codeforces_client.ErrorNotLogged {
-		color.Red("Not logged. Try to login\n")
-		err = cln.Login()
-	}
-	return err
-}
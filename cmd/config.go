@@ -1,9 +1,14 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/Arapak/sio-tool/atcoder_client"
 	"github.com/Arapak/sio-tool/codeforces_client"
 	"github.com/Arapak/sio-tool/config"
+	"github.com/Arapak/sio-tool/domjudge_client"
+	"github.com/Arapak/sio-tool/kattis_client"
 	"github.com/Arapak/sio-tool/sio_client"
 	"github.com/Arapak/sio-tool/szkopul_client"
 )
@@ -12,9 +17,6 @@ func Config() (err error) {
 	cfg := config.Instance
 	codeforcesCln := codeforces_client.Instance
 	szkopulCln := szkopul_client.Instance
-	sioStaszicCln := sio_client.StaszicInstance
-	sioMimuwCln := sio_client.MimuwInstance
-	sioTalentCln := sio_client.TalentInstance
 
 	index := 0
 	prompt := &survey.Select{
@@ -30,22 +32,32 @@ func Config() (err error) {
 			`set folders' name`,
 			`set default naming`,
 			`set database path`,
+			`test before submitting`,
+			`set polling intervals`,
+			`add a Sio instance`,
+			`remove a Sio instance`,
+			`set network options`,
+			`set credential store`,
 		},
-		PageSize: 10,
+		PageSize: 16,
 	}
 	if err = survey.AskOne(prompt, &index); err != nil {
 		return
 	}
 	if index == 0 {
+		options := []string{
+			`Codeforces`,
+			`Szkopul`,
+			`AtCoder`,
+			`Kattis (from .kattisrc)`,
+			`DOMjudge`,
+		}
+		for _, cln := range sio_client.Instances {
+			options = append(options, fmt.Sprintf("Sio2 %v (%v)", cln.Name(), cln.Site().Host()))
+		}
 		prompt := &survey.Select{
 			Message: "Select client",
-			Options: []string{
-				`Codeforces`,
-				`Szkopul`,
-				`Sio2 (staszic.waw.pl)`,
-				`Sio2 (mimuw.edu.pl)`,
-				`Sio2 (talent.edu.pl)`,
-			},
+			Options: options,
 		}
 		if err = survey.AskOne(prompt, &index); err != nil {
 			return
@@ -55,12 +67,13 @@ func Config() (err error) {
 		} else if index == 1 {
 			return szkopulCln.ConfigLogin()
 		} else if index == 2 {
-			return sioStaszicCln.ConfigLogin()
+			return atcoder_client.Instance.ConfigLogin()
 		} else if index == 3 {
-			return sioMimuwCln.ConfigLogin()
+			return kattis_client.Instance.ConfigLogin()
 		} else if index == 4 {
-			return sioTalentCln.ConfigLogin()
+			return domjudge_client.Instance.ConfigLogin()
 		}
+		return sio_client.Instances[index-5].ConfigLogin()
 	} else if index == 1 {
 		return cfg.AddTemplate()
 	} else if index == 2 {
@@ -79,6 +92,18 @@ func Config() (err error) {
 		return cfg.SetDefaultNaming()
 	} else if index == 9 {
 		return cfg.SetDbPath()
+	} else if index == 10 {
+		return cfg.SetTestBeforeSubmit()
+	} else if index == 11 {
+		return cfg.SetPolling()
+	} else if index == 12 {
+		return cfg.AddSioInstance()
+	} else if index == 13 {
+		return cfg.RemoveSioInstance()
+	} else if index == 14 {
+		return cfg.SetNetwork()
+	} else if index == 15 {
+		return cfg.SetCredentialStore()
 	}
 	return
 }
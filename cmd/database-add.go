@@ -115,7 +115,7 @@ type valuesProperties struct {
 
 func getValuesProperties(source string) valuesProperties {
 	switch source {
-	case "cf":
+	case "cf", "atcoder", "domjudge":
 		return valuesProperties{
 			properties{true, true},
 			properties{true, true},
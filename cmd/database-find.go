@@ -30,10 +30,21 @@ func DatabaseFind() (err error) {
 		color.Red(`no task found matching criteria`)
 		return
 	}
-	database_client.Display(tasks)
+	best, err := database_client.BestPoints(db)
+	if err != nil {
+		return
+	}
+	database_client.Display(tasks, best)
 	task := getTask(tasks)
 	if task != nil {
 		task.Display()
+		var submissions []database_client.Submission
+		if submissions, err = database_client.FindSubmissions(db, task.ID); err != nil {
+			return
+		}
+		if len(submissions) > 0 {
+			database_client.DisplaySubmissions(submissions)
+		}
 		deleteTask := false
 		if err = survey.AskOne(&survey.Confirm{Message: `Do you want to delete this task?`, Default: false}, &deleteTask); err != nil {
 			return
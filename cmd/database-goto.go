@@ -26,7 +26,7 @@ func DatabaseGoto() (err error) {
 	} else if len(tasks) == 0 {
 		return errors.New("no tasks match given criteria")
 	} else {
-		database_client.Display(tasks)
+		database_client.Display(tasks, nil)
 		return errors.New("more than one task matches given criteria")
 	}
 	return
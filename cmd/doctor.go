@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"sync"
+
+	"github.com/Arapak/sio-tool/account"
+	"github.com/Arapak/sio-tool/config"
+	"github.com/Arapak/sio-tool/site"
+	"github.com/Arapak/sio-tool/util"
+
+	"github.com/fatih/color"
+	"github.com/k0kubun/go-ansi"
+	"github.com/olekukonko/tablewriter"
+)
+
+// checkJudge returns the state of the judge and of its session, and whether both are fine.
+func checkJudge(s site.Site) (ping, session string, ok bool) {
+	if s.Host() == "" {
+		return "not configured", "", true
+	}
+	if err := s.Ping(); err != nil {
+		return util.RedString(err.Error()), "", false
+	}
+	ping = util.GreenString("ok")
+	if s.Username() == "" {
+		return ping, "not logged in (st config)", true
+	}
+	err := s.CheckSession()
+	switch {
+	case err == nil:
+		return ping, util.GreenString("valid"), true
+	case err.Error() == site.ErrorNotSupported:
+		return ping, "can't be checked", true
+	case s.NotLogged(err):
+		return ping, util.RedString("expired, st logs in again when needed"), false
+	}
+	return ping, util.RedString(err.Error()), false
+}
+
+// Doctor checks every judge at once and shows whether it answers and whether its session is valid.
+func Doctor() (err error) {
+	cfg := config.Instance
+	sites := site.All()
+	records := make([][]string, len(sites))
+	failed := make([]bool, len(sites))
+	wg := sync.WaitGroup{}
+	for i, s := range sites {
+		wg.Add(1)
+		go func(i int, s site.Site) {
+			defer wg.Done()
+			ping, session, ok := checkJudge(s)
+			name := account.Default
+			if path, err := cfg.DefaultSessionPath(s.Name()); err == nil {
+				if name, err = account.Choose(path, cfg.Accounts[s.Name()]); err != nil {
+					name, ok = util.RedString(account.Override+" (not found)"), false
+				}
+			}
+			records[i] = []string{s.Name(), s.Host(), name, s.Username(), ping, session}
+			failed[i] = !ok
+		}(i, s)
+	}
+	wg.Wait()
+
+	var buf bytes.Buffer
+	output := io.Writer(&buf)
+	table := tablewriter.NewWriter(output)
+	table.SetHeader([]string{"judge", "host", "account", "user", "ping", "session"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetCenterSeparator("|")
+	table.SetAutoWrapText(false)
+	problems := 0
+	for i, record := range records {
+		table.Append(record)
+		if failed[i] {
+			problems++
+		}
+	}
+	table.Render()
+
+	scanner := bufio.NewScanner(io.Reader(&buf))
+	for scanner.Scan() {
+		_, _ = ansi.Println(scanner.Text())
+	}
+	if problems == 0 {
+		color.Green("Everything is fine")
+	} else {
+		color.Red("%v judges have problems", problems)
+	}
+	return
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Arapak/sio-tool/site"
+	"github.com/Arapak/sio-tool/util"
+)
+
+const errorExpired = "not logged in"
+
+// standIn is a judge whose answers are set by the tests. It panics on the methods they don't use.
+type standIn struct {
+	site.Site
+	host, user string
+	ping       error
+	session    error
+	login      error
+	logins     int
+}
+
+func (s *standIn) Name() string     { return "stand-in" }
+func (s *standIn) Host() string     { return s.host }
+func (s *standIn) Username() string { return s.user }
+func (s *standIn) Ping() error      { return s.ping }
+
+func (s *standIn) CheckSession() error { return s.session }
+
+func (s *standIn) NotLogged(err error) bool {
+	return err != nil && err.Error() == errorExpired
+}
+
+func (s *standIn) Login() error {
+	s.logins++
+	if s.login == nil {
+		s.session = nil
+	}
+	return s.login
+}
+
+func TestCheckJudge(t *testing.T) {
+	tests := []struct {
+		name    string
+		judge   standIn
+		ping    string
+		session string
+		ok      bool
+	}{
+		{"not configured", standIn{}, "not configured", "", true},
+		{"offline", standIn{host: "h", ping: errors.New("offline")}, util.RedString("offline"), "", false},
+		{"no user", standIn{host: "h"}, util.GreenString("ok"), "not logged in (st config)", true},
+		{"valid", standIn{host: "h", user: "u"}, util.GreenString("ok"), util.GreenString("valid"), true},
+		{"not supported", standIn{host: "h", user: "u", session: errors.New(site.ErrorNotSupported)}, util.GreenString("ok"), "can't be checked", true},
+		{"expired", standIn{host: "h", user: "u", session: errors.New(errorExpired)}, util.GreenString("ok"), util.RedString("expired, st logs in again when needed"), false},
+		{"broken", standIn{host: "h", user: "u", session: errors.New("500 Internal Server Error")}, util.GreenString("ok"), util.RedString("500 Internal Server Error"), false},
+	}
+	for _, test := range tests {
+		ping, session, ok := checkJudge(&test.judge)
+		if ping != test.ping || session != test.session || ok != test.ok {
+			t.Errorf("%v: expect %q %q %v, but found %q %q %v.", test.name, test.ping, test.session, test.ok, ping, session, ok)
+		}
+		if test.judge.logins != 0 {
+			t.Errorf("%v: expect no login, but logged in %v times.", test.name, test.judge.logins)
+		}
+	}
+}
+
+func TestWithRelogin(t *testing.T) {
+	tests := []struct {
+		name   string
+		judge  standIn
+		err    error
+		calls  int
+		logins int
+	}{
+		{"valid", standIn{}, nil, 1, 0},
+		{"expired", standIn{session: errors.New(errorExpired)}, nil, 2, 1},
+		{"login fails", standIn{session: errors.New(errorExpired), login: errors.New("wrong password")}, errors.New("wrong password"), 1, 1},
+		{"other error", standIn{session: errors.New("404 Not Found")}, errors.New("404 Not Found"), 1, 0},
+	}
+	for _, test := range tests {
+		calls := 0
+		err := withRelogin(&test.judge, func() error {
+			calls++
+			return test.judge.session
+		})
+		if (err == nil) != (test.err == nil) || (err != nil && err.Error() != test.err.Error()) {
+			t.Errorf("%v: expect %v, but found %v.", test.name, test.err, err)
+		}
+		if calls != test.calls || test.judge.logins != test.logins {
+			t.Errorf("%v: expect %v calls and %v logins, but found %v and %v.", test.name, test.calls, test.logins, calls, test.judge.logins)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/Arapak/sio-tool/domjudge_client"
+	"github.com/Arapak/sio-tool/util"
+
+	"github.com/k0kubun/go-ansi"
+	"github.com/olekukonko/tablewriter"
+)
+
+func displayScoreboard(scoreboard domjudge_client.Scoreboard) {
+	var buf bytes.Buffer
+	output := io.Writer(&buf)
+	table := tablewriter.NewWriter(output)
+	header := []string{"#", "team", "solved", "time"}
+	table.SetHeader(append(header, scoreboard.Problems...))
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetAlignment(tablewriter.ALIGN_CENTER)
+	table.SetCenterSeparator("|")
+	table.SetAutoWrapText(false)
+	for _, row := range scoreboard.Rows {
+		record := []string{fmt.Sprint(row.Rank), util.LimitNumOfChars(row.Team, 25), fmt.Sprint(row.Solved), fmt.Sprint(row.Time)}
+		record = append(record, row.Scores...)
+		if row.Current {
+			for i := range record {
+				record[i] = util.GreenString(record[i])
+			}
+		}
+		table.Append(record)
+	}
+	table.Render()
+
+	scanner := bufio.NewScanner(io.Reader(&buf))
+	for scanner.Scan() {
+		_, _ = ansi.Println(scanner.Text())
+	}
+}
+
+// DomjudgeStand shows the scoreboard of the contest from the API instead of opening it in the browser.
+func DomjudgeStand() (err error) {
+	cln := domjudge_client.Instance
+	s := cln.Site()
+	if err = s.Ping(); err != nil {
+		return
+	}
+	var scoreboard domjudge_client.Scoreboard
+	var perf util.Performance
+	err = withRelogin(s, func() (err error) {
+		scoreboard, perf, err = cln.GetScoreboard(Args.DomjudgeInfo)
+		return
+	})
+	if err != nil {
+		return
+	}
+	fmt.Printf("Statis: (%v)\n", perf.Parse())
+	displayScoreboard(scoreboard)
+	return
+}
@@ -9,10 +9,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/Arapak/sio-tool/codeforces_client"
 	"github.com/Arapak/sio-tool/config"
-	"github.com/Arapak/sio-tool/sio_client"
-	"github.com/Arapak/sio-tool/szkopul_client"
 	"github.com/fatih/color"
 )
 
@@ -88,17 +85,8 @@ func Gen() (err error) {
 	}
 
 	var handle string
-	if Args.Codeforces {
-		handle = codeforces_client.Instance.Handle
-	}
-	if Args.Szkopul {
-		handle = szkopul_client.Instance.Username
-	}
-	if Args.SioStaszic {
-		handle = sio_client.StaszicInstance.Username
-	}
-	if Args.SioMimuw {
-		handle = sio_client.MimuwInstance.Username
+	if s, _ := currentSite(); s != nil {
+		handle = s.Username()
 	}
 	source, err := readTemplateSource(path, handle)
 	if err != nil {
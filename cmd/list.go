@@ -7,26 +7,26 @@ import (
 	"io"
 	"strings"
 
-	"github.com/Arapak/sio-tool/codeforces_client"
+	"github.com/Arapak/sio-tool/site"
+	"github.com/Arapak/sio-tool/util"
 
 	"github.com/fatih/color"
 	"github.com/k0kubun/go-ansi"
 	"github.com/olekukonko/tablewriter"
 )
 
-func CodeforcesList() (err error) {
-	cln := codeforces_client.Instance
-	err = cln.Ping()
+func List(s site.Site, info site.Info) (err error) {
+	err = s.Ping()
 	if err != nil {
 		return
 	}
-	info := Args.CodeforcesInfo
-	problems, perf, err := cln.Statis(info)
-	if err != nil {
-		if err = loginAgainCodeforces(cln, err); err == nil {
-			problems, perf, err = cln.Statis(info)
-		}
-	}
+	var header []string
+	var problems []site.Problem
+	var perf util.Performance
+	err = withRelogin(s, func() (err error) {
+		header, problems, perf, err = s.Statis(info)
+		return
+	})
 	if err != nil {
 		return
 	}
@@ -34,26 +34,20 @@ func CodeforcesList() (err error) {
 	var buf bytes.Buffer
 	output := io.Writer(&buf)
 	table := tablewriter.NewWriter(output)
-	table.SetHeader([]string{"#", "problem", "passed", "limit", "IO"})
+	table.SetHeader(header)
 	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
 	table.SetAlignment(tablewriter.ALIGN_CENTER)
 	table.SetCenterSeparator("|")
 	table.SetAutoWrapText(false)
 	for _, prob := range problems {
-		table.Append([]string{
-			prob.ID,
-			prob.Name,
-			prob.Passed,
-			prob.Limit,
-			prob.IO,
-		})
+		table.Append(prob.Columns)
 	}
 	table.Render()
 
 	scanner := bufio.NewScanner(io.Reader(&buf))
 	for i := -2; scanner.Scan(); i++ {
 		line := scanner.Text()
-		if i >= 0 {
+		if i >= 0 && i < len(problems) {
 			if strings.Contains(problems[i].State, "accepted") {
 				line = color.New(color.BgGreen).Sprint(line)
 			} else if strings.Contains(problems[i].State, "rejected") {
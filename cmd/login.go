@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"github.com/Arapak/sio-tool/site"
+
+	"github.com/fatih/color"
+)
+
+// withRelogin runs f, and when it fails because the session has expired (the judge showed
+// the login page or no user), logs in again with the saved credentials and runs f once more.
+// Every request to a judge goes through it.
+func withRelogin(s site.Site, f func() error) (err error) {
+	if err = f(); s.NotLogged(err) {
+		color.Red("Not logged. Try to login\n")
+		if err = s.Login(); err == nil {
+			err = f()
+		}
+	}
+	return
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"math"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -154,60 +153,14 @@ func printReport(m map[judge.VerdictStatus]int, testsRan int, maxTime, maxMemory
 	}
 }
 
-func PackageTest() (err error) {
-	cfg := config.Instance
-	if len(cfg.Template) == 0 {
-		return errors.New("you have to add at least one code template by `st config`")
-	}
-
-	filename, index, err := getOneCode(Args.File, cfg.Template, map[string]struct{}{})
-	if err != nil {
-		return
-	}
-	template := cfg.Template[index]
-	path, full := filepath.Split(filename)
-	ext := filepath.Ext(filename)
-	file := full[:len(full)-len(ext)]
-	rand := util.RandString(8)
-
-	packagesPath, err := ArgsPackagePath()
-	if err != nil {
-		return
-	}
-	packagePath, err := getOnePackage(packagesPath)
-	if err != nil {
-		return
-	}
-	packagePath = filepath.Join(packagesPath, packagePath)
+// runPackage runs the code built by the runner on every test of the package
+// and returns the number of each verdict.
+func runPackage(runner scriptRunner, template config.CodeTemplate, packagePath string, oiejqOptions *judge.OiejqOptions) (m map[judge.VerdictStatus]int, err error) {
 	in, out, err := getAllTests(packagePath)
 	if err != nil {
 		return
 	}
 
-	filter := func(cmd string) string {
-		cmd = strings.ReplaceAll(cmd, "$%rand%$", rand)
-		cmd = strings.ReplaceAll(cmd, "$%path%$", path)
-		cmd = strings.ReplaceAll(cmd, "$%full%$", full)
-		cmd = strings.ReplaceAll(cmd, "$%file%$", file)
-		return cmd
-	}
-
-	run := func(script string) error {
-		if s := filter(script); len(s) > 0 {
-			fmt.Println(s)
-			cmds := util.SplitCmd(s)
-			cmd := exec.Command(cmds[0], cmds[1:]...)
-			cmd.Stdout = os.Stdout
-			cmd.Stderr = os.Stderr
-			return cmd.Run()
-		}
-		return nil
-	}
-
-	if err = run(template.BeforeScript); err != nil {
-		return
-	}
-
 	numberOfWorkers := 10
 
 	wg := sync.WaitGroup{}
@@ -216,18 +169,9 @@ func PackageTest() (err error) {
 
 	currentTestNumber := 0
 
-	runScript := filter(template.Script)
-
-	var oiejqOptions *judge.OiejqOptions
-	if Args.Oiejq {
-		err = judge.InstallSio2Jail()
-		if err != nil {
-			return
-		}
-		oiejqOptions = &judge.OiejqOptions{MemorylimitInMegaBytes: Args.MemoryLimit, TimeLimitInSeconds: Args.TimeLimit}
-	}
+	runScript := runner.filter(template.Script)
 
-	m := make(map[judge.VerdictStatus]int)
+	m = make(map[judge.VerdictStatus]int)
 	testsRan := 0
 	maxTime := 0.0
 	maxMemory := 0.0
@@ -269,3 +213,42 @@ func PackageTest() (err error) {
 	color.Blue("\n----FINISHED----")
 	return
 }
+
+func PackageTest() (err error) {
+	cfg := config.Instance
+	if len(cfg.Template) == 0 {
+		return errors.New("you have to add at least one code template by `st config`")
+	}
+
+	filename, index, err := getOneCode(Args.File, cfg.Template, map[string]struct{}{})
+	if err != nil {
+		return
+	}
+
+	packagesPath, err := ArgsPackagePath()
+	if err != nil {
+		return
+	}
+	packagePath, err := getOnePackage(packagesPath)
+	if err != nil {
+		return
+	}
+
+	var oiejqOptions *judge.OiejqOptions
+	if Args.Oiejq {
+		err = judge.InstallSio2Jail()
+		if err != nil {
+			return
+		}
+		oiejqOptions = &judge.OiejqOptions{MemorylimitInMegaBytes: Args.MemoryLimit, TimeLimitInSeconds: Args.TimeLimit}
+	}
+
+	template := cfg.Template[index]
+	runner := newScriptRunner(filename)
+	defer runner.cleanup(template.AfterScript, &err)
+	if err = runner.run(template.BeforeScript); err != nil {
+		return
+	}
+	_, err = runPackage(runner, template, filepath.Join(packagesPath, packagePath), oiejqOptions)
+	return
+}
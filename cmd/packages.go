@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"path/filepath"
+
+	"github.com/Arapak/sio-tool/config"
+	"github.com/Arapak/sio-tool/package_share"
+
+	"github.com/fatih/color"
+)
+
+func PackagesServe() (err error) {
+	cfg := config.Instance
+	port := Args.Port
+	if port == "" {
+		port = "8080"
+	}
+	return package_share.Serve(cfg.PackagesPath, net.JoinHostPort("", port))
+}
+
+func argsPackageTask() (task string, err error) {
+	path, err := ArgsPackagePath()
+	if err != nil {
+		return
+	}
+	task, err = filepath.Rel(config.Instance.PackagesPath, path)
+	if err != nil {
+		return
+	}
+	return filepath.ToSlash(task), nil
+}
+
+func PackagesFetch() (err error) {
+	if Args.URL == "" {
+		return errors.New("you have to specify the url of the packages server")
+	}
+	task, err := argsPackageTask()
+	if err != nil {
+		return
+	}
+	destination, err := ArgsPackagePath()
+	if err != nil {
+		return
+	}
+	color.Cyan("Fetch packages for %v from %v", task, Args.URL)
+	fetched, err := package_share.Fetch(http.DefaultClient, Args.URL, task, func() string {
+		return getPackageNumber(destination)
+	})
+	for _, p := range fetched {
+		color.Green("Fetched package %v (%v files)", p.Name, len(p.Files))
+	}
+	return
+}
@@ -9,16 +9,15 @@ import (
 	"github.com/fatih/color"
 
 	"github.com/Arapak/sio-tool/config"
+	"github.com/Arapak/sio-tool/site"
 )
 
-func SioParse() (err error) {
+func Parse(s site.Site, info site.Info) (err error) {
 	cfg := config.Instance
-	cln := getSioClient()
-	err = cln.Ping()
+	err = s.Ping()
 	if err != nil {
 		return
 	}
-	info := Args.SioInfo
 	source := ""
 	ext := ""
 	if cfg.GenAfterParse {
@@ -27,7 +26,7 @@ func SioParse() (err error) {
 		}
 		path := cfg.Template[cfg.Default].Path
 		ext = filepath.Ext(path)
-		if source, err = readTemplateSource(path, cln.Username); err != nil {
+		if source, err = readTemplateSource(path, s.Username()); err != nil {
 			return
 		}
 	}
@@ -40,24 +39,15 @@ func SioParse() (err error) {
 	defer db.Close()
 
 	work := func() error {
-		_, paths, err := cln.Parse(info, db)
+		paths, err := s.Parse(info, db)
 		if cfg.GenAfterParse {
 			for _, path := range paths {
-				err = GenFiles(source, path, ext)
-				if err != nil {
+				if err := GenFiles(source, path, ext); err != nil {
 					color.Red(err.Error())
 				}
 			}
 		}
-		if err != nil {
-			return err
-		}
-		return nil
-	}
-	if err = work(); err != nil {
-		if err = loginAgainSio(cln, err); err == nil {
-			err = work()
-		}
+		return err
 	}
-	return
+	return withRelogin(s, work)
 }
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"github.com/Arapak/sio-tool/site"
+)
+
+func Race(s site.Site, info site.Info) (err error) {
+	err = s.Ping()
+	if err != nil {
+		return
+	}
+	var urls []string
+	err = withRelogin(s, func() (err error) {
+		urls, err = s.Race(info)
+		return
+	})
+	if err != nil {
+		return
+	}
+	for _, URL := range urls {
+		if err = openURL(URL); err != nil {
+			return
+		}
+	}
+	return Parse(s, info)
+}
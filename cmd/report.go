@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Arapak/sio-tool/sio_submissions"
+	"github.com/Arapak/sio-tool/szkopul_client"
+	"github.com/mitchellh/go-homedir"
+)
+
+const reportsPath = "~/.st/reports"
+
+func reportCachePath(site, submissionID string) (string, error) {
+	folder, err := homedir.Expand(reportsPath)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(folder, fmt.Sprintf("%v_%v.json", site, submissionID)), nil
+}
+
+func loadReport(site, submissionID string) (report sio_submissions.Report, err error) {
+	path, err := reportCachePath(site, submissionID)
+	if err != nil {
+		return
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(data, &report)
+	return
+}
+
+func saveReport(site string, report sio_submissions.Report) (err error) {
+	path, err := reportCachePath(site, report.SubmissionID)
+	if err != nil {
+		return
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return
+	}
+	if err = os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// displayReport fetches a report, saves it in the cache and prints it,
+// optionally next to a cached (or freshly fetched) report of another submission.
+func displayReport(site string, fetch func(submissionID string) (sio_submissions.Report, error), submissionID string) (err error) {
+	report, err := fetch(submissionID)
+	if err != nil {
+		return
+	}
+	if err = saveReport(site, report); err != nil {
+		return
+	}
+	if Args.Compare == "" {
+		report.Display()
+		return
+	}
+	other, err := loadReport(site, Args.Compare)
+	if err != nil {
+		if other, err = fetch(Args.Compare); err != nil {
+			return
+		}
+		if err = saveReport(site, other); err != nil {
+			return
+		}
+	}
+	sio_submissions.DisplayComparison(report, other)
+	return
+}
+
+func SioReport() (err error) {
+	info := Args.SioInfo
+	cln := getSioClient()
+	if info.SubmissionID == "" && info.ProblemID != "" {
+		// A bare number is parsed as a problem ID, but here it can only mean a submission.
+		info.SubmissionID = info.ProblemID
+	}
+	if info.SubmissionID == "" && cln.LastSubmission != nil {
+		info = *cln.LastSubmission
+	}
+	if info.Contest == "" && cln.LastSubmission != nil {
+		info.Contest = cln.LastSubmission.Contest
+	}
+	fetch := func(submissionID string) (report sio_submissions.Report, err error) {
+		info := info
+		info.SubmissionID = submissionID
+		err = withRelogin(cln.Site(), func() (err error) {
+			report, err = cln.GetReport(info)
+			return
+		})
+		return
+	}
+	return displayReport(getSioInstanceName(), fetch, info.SubmissionID)
+}
+
+func SzkopulReport() (err error) {
+	info := Args.SzkopulInfo
+	cln := szkopul_client.Instance
+	if info.SubmissionID == "" && cln.LastSubmission != nil {
+		info = *cln.LastSubmission
+	}
+	fetch := func(submissionID string) (report sio_submissions.Report, err error) {
+		info := info
+		info.SubmissionID = submissionID
+		err = withRelogin(cln.Site(), func() (err error) {
+			report, err = cln.GetReport(info)
+			return
+		})
+		return
+	}
+	return displayReport("szkopul", fetch, info.SubmissionID)
+}
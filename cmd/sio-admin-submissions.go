@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/Arapak/sio-tool/sio_client"
+	"github.com/Arapak/sio-tool/util"
+	"github.com/k0kubun/go-ansi"
+	"github.com/olekukonko/tablewriter"
+)
+
+const ErrorUnknownFormat = "unknown format, use csv or json"
+const ErrorInvalidTime = "invalid time, use YYYY-MM-DD or YYYY-MM-DD HH:MM"
+
+var timeLayouts = []string{"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"}
+
+func parseTime(value string, endOfDay bool) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			if endOfDay && layout == "2006-01-02" {
+				t = t.Add(24*time.Hour - time.Second)
+			}
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New(ErrorInvalidTime)
+}
+
+func argsSubmissionFilter() (filter sio_client.SubmissionFilter, err error) {
+	filter = sio_client.SubmissionFilter{
+		User:   Args.User,
+		Alias:  Args.SioInfo.ProblemAlias,
+		Status: Args.Status,
+	}
+	if Args.Since != "" {
+		if filter.Since, err = parseTime(Args.Since, false); err != nil {
+			return
+		}
+	}
+	if Args.Until != "" {
+		filter.Until, err = parseTime(Args.Until, true)
+	}
+	return
+}
+
+func adminSubmissions() (cln *sio_client.SioClient, submissions []sio_client.AdminSubmission, err error) {
+	filter, err := argsSubmissionFilter()
+	if err != nil {
+		return
+	}
+	cln = getSioClient()
+	err = cln.Ping()
+	if err != nil {
+		return
+	}
+	info := Args.SioInfo
+	var perf util.Performance
+	err = withRelogin(cln.Site(), func() (err error) {
+		submissions, perf, err = cln.AdminSubmissions(info, filter)
+		return
+	})
+	if err == nil {
+		fmt.Fprintf(os.Stderr, "Statis: (%v)\n", perf.Parse())
+	}
+	return
+}
+
+func writeSubmissionsCSV(w io.Writer, submissions []sio_client.AdminSubmission) error {
+	writer := csv.NewWriter(w)
+	_ = writer.Write([]string{"id", "user", "full_name", "date", "problem", "alias", "status", "score"})
+	for _, s := range submissions {
+		_ = writer.Write([]string{s.ID, s.User, s.FullName, s.Date, s.Problem, s.Alias, s.Status, s.Score})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeSubmissionsJSON(w io.Writer, submissions []sio_client.AdminSubmission) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(submissions)
+}
+
+func displayAdminSubmissions(submissions []sio_client.AdminSubmission) {
+	var buf bytes.Buffer
+	output := io.Writer(&buf)
+	table := tablewriter.NewWriter(output)
+	table.SetHeader([]string{"id", "user", "when", "alias", "status", "score"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetAlignment(tablewriter.ALIGN_CENTER)
+	table.SetCenterSeparator("|")
+	table.SetAutoWrapText(false)
+	for _, s := range submissions {
+		table.Append([]string{
+			s.ID,
+			util.LimitNumOfChars(s.User, 20),
+			s.Date,
+			s.Alias,
+			util.LimitNumOfChars(s.Status, 25),
+			s.Score,
+		})
+	}
+	table.Render()
+
+	scanner := bufio.NewScanner(io.Reader(&buf))
+	for i := -2; scanner.Scan(); i++ {
+		line := scanner.Text()
+		_, _ = ansi.Println(line)
+	}
+}
+
+// checkFormat fails for a --format that can't be written, before anything is fetched or created.
+func checkFormat() error {
+	switch Args.Format {
+	case "", "csv", "json":
+		return nil
+	}
+	return errors.New(ErrorUnknownFormat)
+}
+
+// writeOutput writes to the --output file, or to the standard output when none is given.
+func writeOutput(write func(w io.Writer) error) (err error) {
+	if Args.Output == "" {
+		return write(os.Stdout)
+	}
+	file, err := os.Create(Args.Output)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if e := file.Close(); err == nil {
+			err = e
+		}
+	}()
+	return write(file)
+}
+
+func SioAdminSubmissions() (err error) {
+	if err = checkFormat(); err != nil {
+		return
+	}
+	_, submissions, err := adminSubmissions()
+	if err != nil {
+		return
+	}
+	if Args.Format == "" {
+		displayAdminSubmissions(submissions)
+		return
+	}
+	return writeOutput(func(w io.Writer) error {
+		if Args.Format == "csv" {
+			return writeSubmissionsCSV(w, submissions)
+		}
+		return writeSubmissionsJSON(w, submissions)
+	})
+}
+
+func SioAdminSources() (err error) {
+	cln, submissions, err := adminSubmissions()
+	if err != nil {
+		return
+	}
+	rootPath := Args.Output
+	if rootPath == "" {
+		if rootPath, err = os.Getwd(); err != nil {
+			return
+		}
+	}
+	var perf util.Performance
+	err = withRelogin(cln.Site(), func() (err error) {
+		perf, err = cln.DownloadSources(Args.SioInfo, submissions, rootPath)
+		return
+	})
+	fmt.Printf("Statis: (%v)\n", perf.Parse())
+	return
+}
+
+func sioAdminRejudgeSubmissions() (err error) {
+	cln, submissions, err := adminSubmissions()
+	if err != nil {
+		return
+	}
+	err = withRelogin(cln.Site(), func() error {
+		return cln.RejudgeSubmissions(Args.SioInfo, submissions)
+	})
+	return
+}
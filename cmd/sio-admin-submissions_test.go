@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUnknownFormat(t *testing.T) {
+	args := Args
+	defer func() { Args = args }()
+	output := filepath.Join(t.TempDir(), "submissions.xml")
+	Args = &ParsedArgs{Format: "xml", Output: output}
+	if err := SioAdminSubmissions(); err == nil || err.Error() != ErrorUnknownFormat {
+		t.Errorf("Expect %q, but found %v.", ErrorUnknownFormat, err)
+	}
+	if _, err := os.Stat(output); !os.IsNotExist(err) {
+		t.Errorf("Expect no output file, but found %v.", err)
+	}
+}
+
+func TestWriteOutput(t *testing.T) {
+	args := Args
+	defer func() { Args = args }()
+	output := filepath.Join(t.TempDir(), "submissions.csv")
+	Args = &ParsedArgs{Output: output}
+	if err := writeOutput(func(w io.Writer) error {
+		_, err := io.WriteString(w, "id,user\n")
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(output); err != nil || string(data) != "id,user\n" {
+		t.Errorf("Expect the written file, but found %q (%v).", data, err)
+	}
+}
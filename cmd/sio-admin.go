@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Arapak/sio-tool/sio_client"
+	"github.com/Arapak/sio-tool/util"
+	"github.com/k0kubun/go-ansi"
+	"github.com/olekukonko/tablewriter"
+)
+
+const ErrorNeedTargetRound = "you have to specify the target round with --round"
+const ErrorNeedShortname = "you have to specify the new short name with --shortname"
+
+func SioAdminProblems() (err error) {
+	cln := getSioClient()
+	err = cln.Ping()
+	if err != nil {
+		return
+	}
+	info := Args.SioInfo
+	if Args.Round != "" {
+		info.Round = Args.Round
+	}
+	var instances []sio_client.ProblemInstance
+	var perf util.Performance
+	err = withRelogin(cln.Site(), func() (err error) {
+		instances, perf, err = cln.ListProblemInstances(info)
+		return
+	})
+	if err != nil {
+		return
+	}
+	fmt.Printf("Statis: (%v)\n", perf.Parse())
+	var buf bytes.Buffer
+	output := io.Writer(&buf)
+	table := tablewriter.NewWriter(output)
+	table.SetHeader([]string{"id", "round", "name", "alias", "submissions limit"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetAlignment(tablewriter.ALIGN_CENTER)
+	table.SetCenterSeparator("|")
+	table.SetAutoWrapText(false)
+	for _, instance := range instances {
+		table.Append([]string{
+			instance.ID,
+			util.LimitNumOfChars(instance.Round, 20),
+			util.LimitNumOfChars(instance.Name, 25),
+			instance.Alias,
+			instance.SubmissionsLimit,
+		})
+	}
+	table.Render()
+
+	scanner := bufio.NewScanner(io.Reader(&buf))
+	for i := -2; scanner.Scan(); i++ {
+		line := scanner.Text()
+		_, _ = ansi.Println(line)
+	}
+	return
+}
+
+func SioAdminMove() (err error) {
+	if Args.Round == "" {
+		return errors.New(ErrorNeedTargetRound)
+	}
+	cln := getSioClient()
+	err = cln.Ping()
+	if err != nil {
+		return
+	}
+	info := Args.SioInfo
+	err = withRelogin(cln.Site(), func() error {
+		return cln.MoveProblemInstance(info, Args.Round)
+	})
+	return
+}
+
+func SioAdminRename() (err error) {
+	if Args.Shortname == "" {
+		return errors.New(ErrorNeedShortname)
+	}
+	cln := getSioClient()
+	err = cln.Ping()
+	if err != nil {
+		return
+	}
+	info := Args.SioInfo
+	err = withRelogin(cln.Site(), func() error {
+		return cln.RenameProblemInstance(info, Args.Shortname)
+	})
+	return
+}
+
+func SioAdminRejudge() (err error) {
+	if Args.User != "" || Args.Status != "" || Args.Since != "" || Args.Until != "" {
+		return sioAdminRejudgeSubmissions()
+	}
+	cln := getSioClient()
+	err = cln.Ping()
+	if err != nil {
+		return
+	}
+	info := Args.SioInfo
+	err = withRelogin(cln.Site(), func() error {
+		return cln.RejudgeProblemInstance(info)
+	})
+	return
+}
@@ -2,8 +2,13 @@ package cmd
 
 import (
 	"os"
+	"strconv"
+
+	"github.com/Arapak/sio-tool/sio_client"
 )
 
+const defaultDownloadRetries = 3
+
 func SioDownloadPackages() (err error) {
 	cln := getSioClient()
 	err = cln.Ping()
@@ -15,10 +20,21 @@ func SioDownloadPackages() (err error) {
 	if err != nil {
 		return
 	}
-	if _, err = cln.DownloadAllPackages(info, rootPath); err != nil {
-		if err = loginAgainSio(cln, err); err == nil {
-			_, err = cln.DownloadAllPackages(info, rootPath)
+	options := sio_client.DownloadOptions{
+		Filter:  sio_client.PackageFilter{Alias: info.ProblemAlias, Round: info.Round},
+		Retries: defaultDownloadRetries,
+	}
+	if Args.Round != "" {
+		options.Filter.Round = Args.Round
+	}
+	if Args.Retries != "" {
+		if options.Retries, err = strconv.Atoi(Args.Retries); err != nil {
+			return
 		}
 	}
+	err = withRelogin(cln.Site(), func() (err error) {
+		_, _, err = cln.DownloadAllPackages(info, rootPath, options)
+		return
+	})
 	return
 }
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/Arapak/sio-tool/sio_client"
+	"github.com/Arapak/sio-tool/util"
+	"github.com/k0kubun/go-ansi"
+	"github.com/mitchellh/go-homedir"
+	"github.com/olekukonko/tablewriter"
+)
+
+const rankingsPath = "~/.st/rankings"
+
+func rankingCachePath(ranking sio_client.Ranking) (string, error) {
+	folder, err := homedir.Expand(rankingsPath)
+	if err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("%v_%v.json", getSioInstanceName(), ranking.Name())
+	return filepath.Join(folder, name), nil
+}
+
+func loadRanking(path string) (ranking sio_client.Ranking, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(data, &ranking)
+	return
+}
+
+func saveRanking(path string, ranking sio_client.Ranking) (err error) {
+	data, err := json.MarshalIndent(ranking, "", "  ")
+	if err != nil {
+		return
+	}
+	if err = os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func writeRankingCSV(w io.Writer, ranking sio_client.Ranking, diff bool) error {
+	writer := csv.NewWriter(w)
+	header := []string{"place", "user"}
+	if diff {
+		header = []string{"place", "change", "user"}
+	}
+	header = append(header, ranking.Problems...)
+	_ = writer.Write(append(header, "total"))
+	for _, row := range ranking.Rows {
+		record := []string{row.Place, row.User}
+		if diff {
+			record = []string{row.Place, plainRankChange(row), row.User}
+		}
+		record = append(record, row.Scores...)
+		_ = writer.Write(append(record, row.Total))
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func plainRankChange(row sio_client.RankingRow) string {
+	if row.New {
+		return "new"
+	} else if row.Change > 0 {
+		return fmt.Sprintf("+%v", row.Change)
+	} else if row.Change < 0 {
+		return fmt.Sprint(row.Change)
+	}
+	return ""
+}
+
+func rankChange(row sio_client.RankingRow, diff bool) string {
+	if !diff {
+		return ""
+	}
+	if row.New {
+		return util.BlueString("new")
+	} else if row.Change > 0 {
+		return util.GreenString(plainRankChange(row))
+	} else if row.Change < 0 {
+		return util.RedString(plainRankChange(row))
+	}
+	return ""
+}
+
+func displayRanking(ranking sio_client.Ranking, diff bool) {
+	var buf bytes.Buffer
+	output := io.Writer(&buf)
+	table := tablewriter.NewWriter(output)
+	header := []string{"#", "change", "user"}
+	header = append(header, ranking.Problems...)
+	table.SetHeader(append(header, "total"))
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetAlignment(tablewriter.ALIGN_CENTER)
+	table.SetCenterSeparator("|")
+	table.SetAutoWrapText(false)
+	for _, row := range ranking.Rows {
+		record := []string{row.Place, rankChange(row, diff), util.LimitNumOfChars(row.User, 25)}
+		record = append(record, row.Scores...)
+		record = append(record, row.Total)
+		if row.Current {
+			for i := range record {
+				if i != 1 {
+					record[i] = util.GreenString(record[i])
+				}
+			}
+		}
+		table.Append(record)
+	}
+	table.Render()
+
+	scanner := bufio.NewScanner(io.Reader(&buf))
+	for i := -2; scanner.Scan(); i++ {
+		line := scanner.Text()
+		_, _ = ansi.Println(line)
+	}
+}
+
+func SioRanking() (err error) {
+	if err = checkFormat(); err != nil {
+		return
+	}
+	cln := getSioClient()
+	err = cln.Ping()
+	if err != nil {
+		return
+	}
+	info := Args.SioInfo
+	if Args.Round != "" {
+		info.Round = Args.Round
+	}
+	var ranking sio_client.Ranking
+	var perf util.Performance
+	err = withRelogin(cln.Site(), func() (err error) {
+		ranking, perf, err = cln.GetRanking(info)
+		return
+	})
+	if err != nil {
+		return
+	}
+
+	cachePath, err := rankingCachePath(ranking)
+	if err != nil {
+		return
+	}
+	previous, e := loadRanking(cachePath)
+	diff := e == nil
+	if diff {
+		ranking.Diff(previous)
+	}
+	if err = saveRanking(cachePath, ranking); err != nil {
+		return
+	}
+
+	if Args.Format == "" {
+		fmt.Printf("Statis: (%v)\n", perf.Parse())
+		if diff {
+			fmt.Printf("Changes since %v\n", previous.Fetched.Format("2006-01-02 15:04"))
+		}
+		displayRanking(ranking, diff)
+		return
+	}
+	return writeOutput(func(w io.Writer) error {
+		if Args.Format == "csv" {
+			return writeRankingCSV(w, ranking, diff)
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(ranking)
+	})
+}
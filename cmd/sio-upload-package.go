@@ -23,10 +23,9 @@ func SioUploadPackage() (err error) {
 		file = path.Join(rootPath, file)
 	}
 
-	if _, err = cln.UploadPackage(info, file); err != nil {
-		if err = loginAgainSio(cln, err); err == nil {
-			_, err = cln.UploadPackage(info, file)
-		}
-	}
+	err = withRelogin(cln.Site(), func() (err error) {
+		_, err = cln.UploadPackage(info, file)
+		return
+	})
 	return
 }
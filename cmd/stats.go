@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"database/sql"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/Arapak/sio-tool/config"
+	"github.com/Arapak/sio-tool/database_client"
+	"github.com/Arapak/sio-tool/stats"
+	"github.com/Arapak/sio-tool/szkopul_client"
+	"github.com/Arapak/sio-tool/util"
+
+	"github.com/fatih/color"
+	"github.com/k0kubun/go-ansi"
+	"github.com/olekukonko/tablewriter"
+	_ "modernc.org/sqlite"
+)
+
+func renderTable(header []string, rows [][]string) {
+	var buf bytes.Buffer
+	output := io.Writer(&buf)
+	table := tablewriter.NewWriter(output)
+	table.SetHeader(header)
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetAlignment(tablewriter.ALIGN_CENTER)
+	table.SetCenterSeparator("|")
+	table.SetAutoWrapText(false)
+	table.AppendBulk(rows)
+	table.Render()
+
+	scanner := bufio.NewScanner(io.Reader(&buf))
+	for scanner.Scan() {
+		_, _ = ansi.Println(scanner.Text())
+	}
+}
+
+func statsRows(rows []stats.Row, contests bool) (ret [][]string) {
+	for _, r := range rows {
+		record := []string{r.Source}
+		if contests {
+			record = append(record, r.Contest, r.Stage)
+		}
+		ret = append(ret, append(record,
+			fmt.Sprint(r.Tasks),
+			util.GreenString(fmt.Sprint(r.Solved)),
+			color.New(color.FgCyan).Sprint(r.Partial),
+			util.RedString(fmt.Sprint(r.Attempted)),
+		))
+	}
+	return
+}
+
+func oiCoverage() []szkopul_client.StatisInfo {
+	cln := szkopul_client.Instance
+	if cln.Username == "" {
+		return nil
+	}
+	info := szkopul_client.Info{Archive: "OI"}
+	var problems []szkopul_client.StatisInfo
+	err := withRelogin(cln.Site(), func() (err error) {
+		problems, _, err = cln.Statis(info)
+		return
+	})
+	if err != nil {
+		color.Red("Cannot fetch OI coverage: %v", err.Error())
+		return nil
+	}
+	return problems
+}
+
+// Stats prints a summary of solved, partially solved and attempted tasks from the database.
+func Stats() (err error) {
+	cfg := config.Instance
+	db, err := sql.Open("sqlite", cfg.DbPath)
+	if err != nil {
+		fmt.Printf("failed to open database connection: %v\n", err)
+		return
+	}
+	defer db.Close()
+
+	tasks, err := database_client.TaskSummaries(db)
+	if err != nil {
+		return
+	}
+	days, err := database_client.SubmissionDays(db)
+	if err != nil {
+		return
+	}
+	report := stats.NewReport(tasks, days, oiCoverage())
+
+	renderTable([]string{"source", "tasks", "solved", "partial", "attempted"}, statsRows(report.Sources, false))
+	renderTable([]string{"source", "contest", "stage", "tasks", "solved", "partial", "attempted"}, statsRows(report.Contests, true))
+	if len(report.OI) > 0 {
+		var rows [][]string
+		for _, e := range report.OI {
+			rows = append(rows, []string{e.Contest, fmt.Sprint(e.Problems), util.GreenString(fmt.Sprint(e.Solved)),
+				color.New(color.FgCyan).Sprint(e.Partial), fmt.Sprintf("%v/%v", e.Points, e.MaxPoints)})
+		}
+		renderTable([]string{"edition", "problems", "solved", "partial", "points"}, rows)
+	}
+	fmt.Printf("Current streak: %v days, longest streak: %v days\n", report.CurrentStreak, report.LongestStreak)
+	if len(report.Solves) > 0 {
+		fmt.Printf("Median time from parse to full score: %v (%v tasks)\n",
+			stats.FormatDuration(report.MedianSolveTime()), len(report.Solves))
+	}
+
+	if Args.HTML != "" {
+		path, err := filepath.Abs(Args.HTML)
+		if err != nil {
+			return err
+		}
+		if err = report.WriteHTML(path); err != nil {
+			return err
+		}
+		color.Green("Saved the report to %v", path)
+	}
+	return
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Arapak/sio-tool/codeforces_client"
+	"github.com/Arapak/sio-tool/config"
+	"github.com/Arapak/sio-tool/site"
+	_ "modernc.org/sqlite"
+)
+
+const ErrorSioAdminOption = "--kind and --as can be used only on Sio"
+
+func Submit(s site.Site, info site.Info) (err error) {
+	if (Args.Kind != "" || Args.As != "") && !isSio() {
+		return errors.New(ErrorSioAdminOption)
+	}
+	err = s.Ping()
+	if err != nil {
+		return
+	}
+	cfg := config.Instance
+	filename, index, err := getOneCode(Args.File, cfg.Template, s.AcceptedExtensions(info))
+	if err != nil {
+		return
+	}
+	if err = testBeforeSubmit(s.Name(), filename, index); err != nil {
+		return
+	}
+
+	db, err := sql.Open("sqlite", cfg.DbPath)
+	if err != nil {
+		fmt.Printf("failed to open database connection: %v\n", err)
+		return
+	}
+	defer db.Close()
+
+	lang := cfg.Template[index].Lang
+	options := site.SubmitOptions{LangID: lang, Lang: codeforces_client.Langs[lang], Kind: Args.Kind, User: Args.As}
+	err = withRelogin(s, func() error {
+		return s.Submit(info, options, filename, db)
+	})
+	return
+}
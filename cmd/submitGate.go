@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/Arapak/sio-tool/config"
+	"github.com/Arapak/sio-tool/judge"
+
+	"github.com/fatih/color"
+)
+
+const ErrorTestsFailed = "the code doesn't pass the tests, use --force to submit anyway"
+const ErrorCompilationFailed = "the code doesn't compile, use --force to submit anyway"
+
+func passed(m map[judge.VerdictStatus]int) bool {
+	for status, num := range m {
+		if status != judge.OK && num > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// testBeforeSubmit compiles the code and runs the samples (and the local package if configured)
+// when testing before submitting is turned on for the site or the template, and fails if the code
+// doesn't compile or any test doesn't pass.
+func testBeforeSubmit(site string, filename string, index int) (err error) {
+	cfg := config.Instance
+	if Args.Force || !cfg.ShouldTestBeforeSubmit(site, index) {
+		return
+	}
+	template := cfg.Template[index]
+
+	color.Cyan("Test before submit")
+	runner := newScriptRunner(filename)
+	defer runner.cleanup(template.AfterScript, &err)
+	if err = runner.run(template.BeforeScript); err != nil {
+		color.Red(err.Error())
+		return errors.New(ErrorCompilationFailed)
+	}
+	m, err := runSamples(runner, filename, template, nil)
+	if err != nil && err.Error() != ErrorSamplesNotFound {
+		return
+	} else if err != nil {
+		color.Yellow(err.Error())
+		err = nil
+	} else if !passed(m) {
+		return errors.New(ErrorTestsFailed)
+	}
+
+	if cfg.PackageBeforeSubmit {
+		packagesPath, err := ArgsPackagePath()
+		if err != nil {
+			return err
+		}
+		packagePath, err := getOnePackage(packagesPath)
+		if err != nil {
+			if err.Error() != ErrorPackageNotFound && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+			color.Yellow(ErrorPackageNotFound)
+			return nil
+		}
+		m, err = runPackage(runner, template, filepath.Join(packagesPath, packagePath), nil)
+		if err != nil {
+			return err
+		}
+		if !passed(m) {
+			return errors.New(ErrorTestsFailed)
+		}
+	}
+	return
+}
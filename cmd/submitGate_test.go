@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+
+	"github.com/Arapak/sio-tool/config"
+)
+
+func TestTestBeforeSubmit(t *testing.T) {
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(dir)
+	if err = os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	cfg, args := config.Instance, Args
+	defer func() { config.Instance, Args = cfg, args }()
+
+	build := `sh -c "echo build >> log"`
+	fail := `sh -c "echo build >> log; false"`
+	tests := []struct {
+		name    string
+		compile string
+		samples bool
+		test    bool
+		force   bool
+		err     string
+		log     string
+	}{
+		{"compiles without samples", build, false, true, false, "", "build\nclean\n"},
+		{"compiles with samples", build, true, true, false, "", "build\nclean\n"},
+		{"doesn't compile without samples", fail, false, true, false, ErrorCompilationFailed, "build\nclean\n"},
+		{"forced", fail, false, true, true, "", ""},
+		{"testing turned off", fail, false, false, false, "", ""},
+	}
+	for _, test := range tests {
+		_ = os.Remove("log")
+		_ = os.Remove("in1.txt")
+		_ = os.Remove("out1.txt")
+		if test.samples {
+			if err = os.WriteFile("in1.txt", nil, 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err = os.WriteFile("out1.txt", nil, 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		config.Instance = &config.Config{Template: []config.CodeTemplate{{
+			BeforeScript:     test.compile,
+			Script:           "true",
+			AfterScript:      `sh -c "echo clean >> log"`,
+			TestBeforeSubmit: test.test,
+		}}}
+		Args = &ParsedArgs{Force: test.force}
+		err := testBeforeSubmit("codeforces", "a.cpp", 0)
+		if (err == nil && test.err != "") || (err != nil && err.Error() != test.err) {
+			t.Errorf("%v: expect %q, but found %v.", test.name, test.err, err)
+		}
+		log, _ := os.ReadFile("log")
+		if string(log) != test.log {
+			t.Errorf("%v: expect the scripts %q, but found %q.", test.name, test.log, log)
+		}
+	}
+}
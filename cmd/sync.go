@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/Arapak/sio-tool/codeforces_client"
+	"github.com/Arapak/sio-tool/config"
+	"github.com/Arapak/sio-tool/sio_client"
+	"github.com/Arapak/sio-tool/szkopul_client"
+	"github.com/fatih/color"
+	_ "modernc.org/sqlite"
+)
+
+func syncJudge(name string, sync func() (int, error)) {
+	if err := config.Instance.AccountError(name); err != nil {
+		color.Red(err.Error())
+		return
+	}
+	color.Cyan("Syncing %v", name)
+	synced, err := sync()
+	if err != nil {
+		color.Red("%v: %v", name, err.Error())
+		return
+	}
+	color.Green("Saved %v submissions from %v", synced, name)
+}
+
+// Sync saves the submission history from every judge you are logged in to in the database.
+func Sync() (err error) {
+	cfg := config.Instance
+	db, err := sql.Open("sqlite", cfg.DbPath)
+	if err != nil {
+		fmt.Printf("failed to open database connection: %v\n", err)
+		return
+	}
+	defer db.Close()
+
+	if cln := codeforces_client.Instance; cln.Handle != "" {
+		syncJudge("codeforces", func() (synced int, err error) {
+			err = withRelogin(cln.Site(), func() (err error) {
+				synced, err = cln.Sync(db)
+				return
+			})
+			return
+		})
+	}
+	if cln := szkopul_client.Instance; cln.Username != "" {
+		syncJudge("szkopul", func() (synced int, err error) {
+			err = withRelogin(cln.Site(), func() (err error) {
+				synced, err = cln.Sync(db)
+				return
+			})
+			return
+		})
+	}
+	for _, cln := range sio_client.Instances {
+		if cln.Username == "" {
+			continue
+		}
+		name := cln.Name()
+		info := sio_client.Info{}
+		if name == getSioInstanceName() {
+			info.Contest = Args.SioInfo.Contest
+		}
+		syncJudge(name, func() (synced int, err error) {
+			err = withRelogin(cln.Site(), func() (err error) {
+				synced, err = cln.Sync(info, db)
+				return
+			})
+			return
+		})
+	}
+	return
+}
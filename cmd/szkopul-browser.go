@@ -93,21 +93,5 @@ func SzkopulOpen() (err error) {
 			return openURL(URL)
 		}
 	}
-	URL, err = Args.SzkopulInfo.OpenURL(config.Instance.SzkopulHost)
-	if err != nil {
-		return
-	}
-	return openURL(URL)
-}
-
-func SzkopulSid() (err error) {
-	info := Args.SzkopulInfo
-	if info.SubmissionID == "" && szkopul_client.Instance.LastSubmission != nil {
-		info = *szkopul_client.Instance.LastSubmission
-	}
-	URL, err := info.SubmissionURL(config.Instance.SzkopulHost)
-	if err != nil {
-		return
-	}
-	return openURL(URL)
+	return Open(szkopul_client.Instance.Site(), &Args.SzkopulInfo)
 }
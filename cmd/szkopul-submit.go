@@ -4,9 +4,7 @@ import (
 	"errors"
 	"regexp"
 
-	"github.com/Arapak/sio-tool/config"
 	"github.com/Arapak/sio-tool/szkopul_client"
-
 	"github.com/fatih/color"
 )
 
@@ -24,16 +22,6 @@ func getProblemIDFromLink(link string) (problemID string, err error) {
 }
 
 func SzkopulSubmit() (err error) {
-	cln := szkopul_client.Instance
-	err = cln.Ping()
-	if err != nil {
-		return
-	}
-	cfg := config.Instance
-	filename, _, err := getOneCode(Args.File, cfg.Template, szkopul_client.AcceptedExtensions)
-	if err != nil {
-		return
-	}
 	if Args.SzkopulInfo.ProblemID == "" {
 		link, err := searchForLinkSzkopul()
 		if err != nil {
@@ -45,20 +33,5 @@ func SzkopulSubmit() (err error) {
 		}
 		color.Green("Found problem secret key")
 	}
-	info := Args.SzkopulInfo
-
-	if err = cln.Submit(info, filename); err != nil {
-		if err = loginAgainSzkopul(cln, err); err == nil {
-			err = cln.Submit(info, filename)
-		}
-	}
-	return
-}
-
-func loginAgainSzkopul(cln *szkopul_client.SzkopulClient, err error) error {
-	if err != nil && err.Error() == szkopul_client.ErrorNotLogged {
-		color.Red("Not logged. Try to login\n")
-		err = cln.Login()
-	}
-	return err
+	return Submit(szkopul_client.Instance.Site(), &Args.SzkopulInfo)
 }
@@ -15,32 +15,18 @@ import (
 	"github.com/fatih/color"
 )
 
-func Test() (err error) {
-	cfg := config.Instance
-	if len(cfg.Template) == 0 {
-		return errors.New("you have to add at least one code template by `st config`")
-	}
+const ErrorSamplesNotFound = "cannot find any sample file"
 
-	filename, index, err := getOneCode(Args.File, cfg.Template, map[string]struct{}{})
-	if err != nil {
-		return
-	}
-	template := cfg.Template[index]
+type scriptRunner struct {
+	filter func(cmd string) string
+	run    func(script string) error
+}
+
+func newScriptRunner(filename string) scriptRunner {
 	path, full := filepath.Split(filename)
 	ext := filepath.Ext(filename)
 	file := full[:len(full)-len(ext)]
 	rand := util.RandString(8)
-	task := judge.ExtractTaskName(file)
-
-	samples := getSampleByName(task)
-	samplesWithName := true
-	if len(samples) == 0 {
-		samplesWithName = false
-		samples = getSampleID()
-		if len(samples) == 0 {
-			return errors.New("cannot find any sample file")
-		}
-	}
 
 	filter := func(cmd string) string {
 		cmd = strings.ReplaceAll(cmd, "$%rand%$", rand)
@@ -61,21 +47,36 @@ func Test() (err error) {
 		}
 		return nil
 	}
+	return scriptRunner{filter, run}
+}
 
-	if err = run(template.BeforeScript); err != nil {
-		return
+// cleanup runs the after script and keeps the first error, so that deferring it removes the build
+// however the caller returns.
+func (r scriptRunner) cleanup(script string, err *error) {
+	if e := r.run(script); *err == nil {
+		*err = e
 	}
+}
 
-	var oiejqOptions *judge.OiejqOptions
-	if Args.Oiejq {
-		err = judge.InstallSio2Jail()
-		if err != nil {
-			return
+// runSamples runs the code built by the runner on the samples from the current folder
+// and returns the number of each verdict.
+func runSamples(runner scriptRunner, filename string, template config.CodeTemplate, oiejqOptions *judge.OiejqOptions) (m map[judge.VerdictStatus]int, err error) {
+	_, full := filepath.Split(filename)
+	file := full[:len(full)-len(filepath.Ext(full))]
+	task := judge.ExtractTaskName(file)
+
+	samples := getSampleByName(task)
+	samplesWithName := true
+	if len(samples) == 0 {
+		samplesWithName = false
+		samples = getSampleID()
+		if len(samples) == 0 {
+			return nil, errors.New(ErrorSamplesNotFound)
 		}
-		oiejqOptions = &judge.OiejqOptions{MemorylimitInMegaBytes: Args.MemoryLimit, TimeLimitInSeconds: Args.TimeLimit}
 	}
 
-	if s := filter(template.Script); len(s) > 0 {
+	m = make(map[judge.VerdictStatus]int)
+	if s := runner.filter(template.Script); len(s) > 0 {
 		for _, i := range samples {
 			var verdict judge.Verdict
 
@@ -84,6 +85,7 @@ func Test() (err error) {
 			} else {
 				verdict = judge.Judge(fmt.Sprintf("in%v.txt", i), fmt.Sprintf("out%v.txt", i), i, s, oiejqOptions)
 			}
+			m[verdict.Status]++
 
 			if verdict.Err != nil {
 				color.Red(verdict.Err.Error())
@@ -92,7 +94,37 @@ func Test() (err error) {
 			}
 		}
 	} else {
-		return errors.New("invalid script command, please check config file")
+		return nil, errors.New("invalid script command, please check config file")
+	}
+	return
+}
+
+func Test() (err error) {
+	cfg := config.Instance
+	if len(cfg.Template) == 0 {
+		return errors.New("you have to add at least one code template by `st config`")
+	}
+
+	filename, index, err := getOneCode(Args.File, cfg.Template, map[string]struct{}{})
+	if err != nil {
+		return
+	}
+
+	var oiejqOptions *judge.OiejqOptions
+	if Args.Oiejq {
+		err = judge.InstallSio2Jail()
+		if err != nil {
+			return
+		}
+		oiejqOptions = &judge.OiejqOptions{MemorylimitInMegaBytes: Args.MemoryLimit, TimeLimitInSeconds: Args.TimeLimit}
+	}
+
+	template := cfg.Template[index]
+	runner := newScriptRunner(filename)
+	defer runner.cleanup(template.AfterScript, &err)
+	if err = runner.run(template.BeforeScript); err != nil {
+		return
 	}
-	return run(template.AfterScript)
+	_, err = runSamples(runner, filename, template, oiejqOptions)
+	return
 }
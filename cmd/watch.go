@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"github.com/Arapak/sio-tool/site"
+)
+
+func Watch(s site.Site, info site.Info) (err error) {
+	err = s.Ping()
+	if err != nil {
+		return
+	}
+	n := 10
+	if Args.All {
+		n = -1
+	}
+	err = withRelogin(s, func() error {
+		return s.Watch(info, n, false)
+	})
+	return
+}
@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Arapak/sio-tool/codeforces_client"
+	"github.com/Arapak/sio-tool/config"
+	"github.com/Arapak/sio-tool/database_client"
+	"github.com/Arapak/sio-tool/sio_client"
+	"github.com/Arapak/sio-tool/sio_submissions"
+	"github.com/Arapak/sio-tool/szkopul_client"
+	"github.com/Arapak/sio-tool/util"
+	"github.com/Arapak/sio-tool/watcher"
+	"github.com/fatih/color"
+)
+
+func fromSioSubmission(judge, contest string, s sio_submissions.Submission) watcher.Submission {
+	problem := s.ShortName
+	if problem == "" {
+		problem = s.Name
+	}
+	points := ""
+	if s.Points != sio_submissions.Inf {
+		points = fmt.Sprint(s.Points)
+	}
+	return watcher.Submission{
+		Judge:   judge,
+		ID:      s.ParseID(),
+		Contest: contest,
+		Problem: problem,
+		Status:  s.PlainStatus(),
+		Points:  points,
+		Waiting: strings.Contains(s.Status, "${c-waiting}"),
+		End:     s.End,
+	}
+}
+
+func fromSioSubmissions(judge, contest string, submissions []sio_submissions.Submission) (ret []watcher.Submission) {
+	for _, s := range submissions {
+		ret = append(ret, fromSioSubmission(judge, contest, s))
+	}
+	return
+}
+
+func watcherSources() (sources []watcher.Source) {
+	if cln := codeforces_client.Instance; cln.Handle != "" {
+		sources = append(sources, watcher.Source{Judge: "codeforces", Fetch: func(_ *watcher.State) (ret []watcher.Submission, err error) {
+			var submissions []codeforces_client.Submission
+			err = withRelogin(cln.Site(), func() (err error) {
+				submissions, err = cln.RecentSubmissions()
+				return
+			})
+			if err != nil {
+				if err.Error() == sio_submissions.ErrorNoSubmissions {
+					err = nil
+				}
+				return
+			}
+			for _, s := range submissions {
+				ret = append(ret, watcher.Submission{
+					Judge:   "codeforces",
+					ID:      s.ParseID(),
+					Problem: s.Name(),
+					Status:  s.PlainStatus(),
+					Waiting: !s.End(),
+					End:     s.End(),
+				})
+			}
+			return
+		}})
+	}
+	if cln := szkopul_client.Instance; cln.Username != "" {
+		sources = append(sources, watcher.Source{Judge: "szkopul", Fetch: func(_ *watcher.State) ([]watcher.Submission, error) {
+			var submissions []sio_submissions.Submission
+			err := withRelogin(cln.Site(), func() (err error) {
+				submissions, err = cln.RecentSubmissions()
+				return
+			})
+			if err != nil {
+				if err.Error() == sio_submissions.ErrorNoSubmissions {
+					return nil, nil
+				}
+				return nil, err
+			}
+			return fromSioSubmissions("szkopul", "", submissions), nil
+		}})
+	}
+	for _, cln := range sio_client.Instances {
+		if cln.Username == "" {
+			continue
+		}
+		cln, name := cln, cln.Name()
+		// active are the contests with recent submissions, checked when no contest was added to the watcher.
+		var active []string
+		var listed time.Time
+		sources = append(sources, watcher.Source{Judge: name, Fetch: func(state *watcher.State) (ret []watcher.Submission, err error) {
+			contests := state.Contests[name]
+			if len(contests) == 0 {
+				if time.Since(listed) >= activeRefresh {
+					if active, ret, err = activeContests(cln); err == nil {
+						listed = time.Now()
+					}
+					return
+				}
+				contests = active
+			}
+			for _, contest := range contests {
+				submissions, err := recentSioSubmissions(cln, contest)
+				if err != nil {
+					if skippable(err) {
+						color.Red("%v: %v", contest, err.Error())
+						continue
+					}
+					return nil, err
+				}
+				ret = append(ret, fromSioSubmissions(name, contest, submissions)...)
+			}
+			return
+		}})
+	}
+	return
+}
+
+// activeFor is how long a contest stays watched after the last submission to it, when no contest
+// was added to the watcher. The contests of the instance are checked again every activeRefresh.
+const activeFor = 7 * 24 * time.Hour
+const activeRefresh = time.Hour
+
+func recentSioSubmissions(cln *sio_client.SioClient, contest string) (submissions []sio_submissions.Submission, err error) {
+	info := sio_client.Info{Contest: contest}
+	err = withRelogin(cln.Site(), func() (err error) {
+		submissions, err = cln.RecentSubmissions(info)
+		return
+	})
+	if err != nil && err.Error() == sio_submissions.ErrorNoSubmissions {
+		return nil, nil
+	}
+	return
+}
+
+// skippable reports whether the error concerns only one contest (e.g. an archived one the user can't
+// access), so that the other contests are still checked. An expired session or a rate limit stops the check.
+func skippable(err error) bool {
+	var rateLimit *util.RateLimitError
+	return err.Error() != sio_client.ErrorNotLogged && !errors.As(err, &rateLimit)
+}
+
+// activeContests checks every contest of the instance and returns the ones the user submitted to
+// within activeFor, together with their recent submissions.
+func activeContests(cln *sio_client.SioClient) (contests []string, ret []watcher.Submission, err error) {
+	var aliases []string
+	err = withRelogin(cln.Site(), func() (err error) {
+		aliases, err = cln.ContestAliases()
+		return
+	})
+	if err != nil {
+		return
+	}
+	since := time.Now().Add(-activeFor)
+	for _, contest := range aliases {
+		submissions, err := recentSioSubmissions(cln, contest)
+		if err != nil {
+			if skippable(err) {
+				color.Red("%v: %v", contest, err.Error())
+				continue
+			}
+			return nil, nil, err
+		}
+		if submittedSince(submissions, since) {
+			contests = append(contests, contest)
+			ret = append(ret, fromSioSubmissions(cln.Name(), contest, submissions)...)
+		}
+	}
+	return
+}
+
+// submittedSince reports whether any of the submissions was sent after the given time.
+func submittedSince(submissions []sio_submissions.Submission, since time.Time) bool {
+	for _, s := range submissions {
+		if when, err := time.ParseInLocation(database_client.TimeLayout, s.When, time.Local); err == nil && when.After(since) {
+			return true
+		}
+	}
+	return false
+}
+
+// Watcher tracks the pending submissions on all judges you are logged in to and notifies about their verdicts.
+func Watcher() (err error) {
+	if name := getSioInstanceName(); name != "" && Args.SioInfo.Contest != "" {
+		if err = watcher.AddContest(name, Args.SioInfo.Contest); err != nil {
+			return
+		}
+	}
+	notifier := &watcher.Notifier{Bell: Args.Bell, Desktop: Args.Desktop, Hook: Args.Hook}
+	if !notifier.Bell && !notifier.Desktop && notifier.Hook == "" {
+		notifier.Bell = true
+	}
+	var sources []watcher.Source
+	for _, source := range watcherSources() {
+		if err := config.Instance.AccountError(source.Judge); err != nil {
+			color.Red(err.Error())
+			continue
+		}
+		sources = append(sources, source)
+	}
+	return watcher.Run(sources, notifier)
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Arapak/sio-tool/sio_submissions"
+)
+
+func TestSubmittedSince(t *testing.T) {
+	since := time.Date(2024, 4, 20, 0, 0, 0, 0, time.Local)
+	tests := []struct {
+		when []string
+		want bool
+	}{
+		{nil, false},
+		{[]string{"2024-04-19 23:59:59"}, false},
+		{[]string{"2024-04-19 12:00:00", "2024-04-20 16:58:40"}, true},
+		{[]string{"yesterday"}, false},
+	}
+	for _, test := range tests {
+		var submissions []sio_submissions.Submission
+		for _, when := range test.when {
+			submissions = append(submissions, sio_submissions.Submission{When: when})
+		}
+		if got := submittedSince(submissions, since); got != test.want {
+			t.Errorf("%v: expect %v, but found %v.", test.when, test.want, got)
+		}
+	}
+}
@@ -6,21 +6,24 @@ import (
 	"net/http"
 	"net/url"
 	"os"
-	"path/filepath"
 
 	"github.com/Arapak/sio-tool/cookiejar"
+	"github.com/Arapak/sio-tool/credentials"
+	"github.com/Arapak/sio-tool/site"
+	"github.com/Arapak/sio-tool/util"
 
 	"github.com/fatih/color"
 )
 
 type CodeforcesClient struct {
-	Jar            *cookiejar.Jar `json:"cookies"`
-	Handle         string         `json:"handle"`
-	HandleOrEmail  string         `json:"handle_or_email"`
-	Password       string         `json:"password"`
-	Ftaa           string         `json:"ftaa"`
-	Bfaa           string         `json:"bfaa"`
-	LastSubmission *Info          `json:"last_submission"`
+	Jar           *cookiejar.Jar `json:"cookies"`
+	Handle        string         `json:"handle"`
+	HandleOrEmail string         `json:"handle_or_email"`
+	// Password is the password encrypted by older versions of st, it is moved to the credential store.
+	Password       string `json:"password,omitempty"`
+	Ftaa           string `json:"ftaa"`
+	Bfaa           string `json:"bfaa"`
+	LastSubmission *Info  `json:"last_submission"`
 	host           string
 	proxy          string
 	path           string
@@ -36,6 +39,7 @@ func Init(path, host, proxy string) {
 		color.Red(err.Error())
 		color.Green("Create a new session in %v", path)
 	}
+	credentials.Migrate("codeforces", c.HandleOrEmail, c.Password, decrypt, func() { c.Password = "" })
 	Proxy := http.ProxyFromEnvironment
 	if len(proxy) > 0 {
 		proxyURL, err := url.Parse(proxy)
@@ -46,11 +50,12 @@ func Init(path, host, proxy string) {
 			Proxy = http.ProxyURL(proxyURL)
 		}
 	}
-	c.client = &http.Client{Jar: c.Jar, Transport: &http.Transport{Proxy: Proxy}}
+	c.client = &http.Client{Jar: c.Jar, Transport: util.NewTransport(Proxy)}
 	if err := c.save(); err != nil {
 		color.Red(err.Error())
 	}
 	Instance = c
+	site.Register(c.Site())
 }
 
 func (c *CodeforcesClient) load() (err error) {
@@ -75,10 +80,7 @@ func (c *CodeforcesClient) load() (err error) {
 func (c *CodeforcesClient) save() (err error) {
 	data, err := json.MarshalIndent(c, "", "  ")
 	if err == nil {
-		err = os.MkdirAll(filepath.Dir(c.path), os.ModePerm)
-		if err == nil {
-			err = os.WriteFile(c.path, data, 0644)
-		}
+		err = credentials.WriteFile(c.path, data)
 	}
 	if err != nil {
 		color.Red("Cannot save session to %v\n%v", c.path, err.Error())
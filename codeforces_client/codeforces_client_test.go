@@ -1,6 +1,36 @@
 package codeforces_client
 
-import "testing"
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Arapak/sio-tool/credentials"
+	"github.com/Arapak/sio-tool/replay/replaytest"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/mitchellh/go-homedir"
+)
+
+// host is the site the fixtures are recorded from, ST_RECORD_FIXTURES=1 records them again
+// with the session of "st config". The expectations are read from the recorded pages.
+const host = "https://codeforces.com"
+
+func init() {
+	replaytest.Session = func() (*http.Client, string, error) {
+		vault, _ := homedir.Expand("~/.st/vault")
+		credentials.Init(credentials.Auto, vault)
+		path, _ := homedir.Expand("~/.st/codeforces_session")
+		Init(path, host, "")
+		if err := Instance.CheckSession(); err != nil {
+			if err = Instance.Login(); err != nil {
+				return nil, "", err
+			}
+		}
+		return Instance.client, Instance.Handle, nil
+	}
+}
 
 func TestSimpleFindSample(t *testing.T) {
 	body := "<div class=\"sample-tests\"><div class=\"section-title\">Example</div><div class=\"sample-test\"><div class=\"input\"><div class=\"title\">Input<div title=\"Copy\" data-clipboard-target=\"#id004679888550762963\" id=\"id0081034027387341\" class=\"input-output-copier\">Copy</div></div><pre id=\"id004679888550762963\">4\n3876\n387\n4489\n3\n</pre></div><div class=\"output\"><div class=\"title\">Output<div title=\"Copy\" data-clipboard-target=\"#id008440845462789317\" id=\"id002626928483810227\" class=\"input-output-copier\">Copy</div></div><pre id=\"id008440845462789317\">0\n2\n1\n-1\n</pre></div></div></div>"
@@ -43,3 +73,141 @@ func TestHighlightFindSample(t *testing.T) {
 		t.Errorf("Expect %s, but found %s.", expectOutput, realOutput)
 	}
 }
+
+func TestFindStrings(t *testing.T) {
+	problem := replaytest.Fixture(t, host+"/contest/1950/problem/A")
+	enter := replaytest.AnonymousFixture(t, host+"/enter")
+	my := replaytest.Fixture(t, host+"/contest/1950/my")
+	submission := replaytest.Fixture(t, host+"/contest/1950/submission/255600121")
+	submit := replaytest.Fixture(t, host+"/contest/1950/submit")
+	replaytest.TestStrings(t, []replaytest.StringTest{
+		{Name: "handle", Page: problem, Find: findHandle, Want: replaytest.User(t)},
+		{Name: "not logged", Page: enter, Find: findHandle},
+		{Name: "csrf", Page: enter, Find: findCsrf, Want: replaytest.Select(t, enter, `input[name="csrf_token"]`, "value")},
+		{Name: "name", Page: problem, Find: findName, Want: replaytest.Select(t, problem, ".problem-statement .header .title", "")},
+		{Name: "utc offset", Page: my, Find: findCfOffset, Want: replaytest.Select(t, my, `meta[name="utc_offset"]`, "content")},
+		{Name: "code", Page: submission, Find: findCode, Want: replaytest.Document(t, submission).Find("#program-source-text").Text()},
+		{Name: "error", Page: submit, Find: findErrorMessage, Want: replaytest.Select(t, submit, "span.error", "")},
+	})
+}
+
+func TestFindMessage(t *testing.T) {
+	body := replaytest.Fixture(t, host+"/contest/1950/status")
+	message, err := findMessage(body)
+	if strings.Contains(string(body), "Codeforces.showMessage(") != (err == nil) {
+		t.Errorf("Expect the message shown by the page, but found %q (%v).", message, err)
+	}
+}
+
+// sampleText reads a sample like a browser shows it, every line of a highlighted sample is a div.
+func sampleText(s *goquery.Selection) string {
+	lines := s.Find(".test-example-line")
+	if lines.Length() == 0 {
+		return s.Text()
+	}
+	text := ""
+	lines.Each(func(_ int, line *goquery.Selection) {
+		text += line.Text() + "\n"
+	})
+	return text
+}
+
+func TestFixtureSample(t *testing.T) {
+	body := replaytest.Fixture(t, host+"/contest/1950/problem/A")
+	input, output, err := findSample(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := replaytest.Document(t, body)
+	inputs, outputs := doc.Find(".sample-test .input pre"), doc.Find(".sample-test .output pre")
+	if inputs.Length() == 0 || len(input) != inputs.Length() || len(output) != outputs.Length() {
+		t.Fatalf("Expect %v samples, but found %q and %q.", inputs.Length(), input, output)
+	}
+	for i := range input {
+		if string(input[i]) != sampleText(inputs.Eq(i)) || string(output[i]) != sampleText(outputs.Eq(i)) {
+			t.Errorf("Sample %v: expect %q %q, but found %q %q.", i+1, sampleText(inputs.Eq(i)), sampleText(outputs.Eq(i)), input[i], output[i])
+		}
+	}
+}
+
+func TestFindCountdown(t *testing.T) {
+	body := replaytest.Fixture(t, host+"/contest/1951/countdown")
+	seconds, err := findCountdown(body)
+	countdown := replaytest.Select(t, body, ".countdown", "")
+	if countdown == "" {
+		if err == nil {
+			t.Errorf("Expect no countdown, but found %v.", seconds)
+		}
+		return
+	}
+	want := 0
+	for _, part := range strings.Split(countdown, ":") {
+		n, _ := strconv.Atoi(part)
+		want = want*60 + n
+	}
+	if err != nil || seconds != want {
+		t.Errorf("Expect %v (%v), but found %v (%v).", want, countdown, seconds, err)
+	}
+}
+
+func TestStatis(t *testing.T) {
+	c := &CodeforcesClient{host: host, client: replaytest.FixtureClient(t)}
+	problems, _, err := c.Statis(Info{ProblemType: "contest", ContestID: "1950"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := replaytest.Document(t, replaytest.Fixture(t, host+"/contest/1950")).Find("table.problems tr").Has("td.id")
+	if len(problems) == 0 || len(problems) != rows.Length() {
+		t.Fatalf("Expect %v problems, but found %v.", rows.Length(), problems)
+	}
+	for i, problem := range problems {
+		row := rows.Eq(i)
+		id, name := strings.TrimSpace(row.Find("td.id").Text()), strings.TrimSpace(row.Find("td").Eq(1).Find("a").First().Text())
+		state := ""
+		if row.HasClass("accepted-problem") {
+			state = "accepted-problem"
+		} else if row.HasClass("rejected-problem") {
+			state = "rejected-problem"
+		}
+		if problem.ID != id || problem.Name != name || problem.State != state {
+			t.Errorf("Expect %v %v %v, but found %+v.", id, name, state, problem)
+		}
+	}
+}
+
+func TestParseSubmission(t *testing.T) {
+	body := replaytest.Fixture(t, host+"/contest/1950/my")
+	rows, err := findSubmission(body, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := replaytest.Document(t, body).Find("tr[data-submission-id]")
+	if len(rows) != want.Length() {
+		t.Fatalf("Expect %v submissions, but found %v.", want.Length(), len(rows))
+	}
+	offset := replaytest.Select(t, body, `meta[name="utc_offset"]`, "content")
+	for i, row := range rows {
+		s, err := parseSubmission(row, offset)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cells := want.Eq(i)
+		id, _ := cells.Attr("data-submission-id")
+		waiting, _ := cells.Find(".status-verdict-cell").Attr("waiting")
+		name := strings.TrimSpace(cells.Find("td[data-problemId]").Text())
+		if strconv.FormatUint(s.id, 10) != id || s.name != name || s.end != (waiting != "true") || s.lang != strings.TrimSpace(cells.Find("td").Eq(4).Text()) {
+			t.Errorf("Expect %v %v (waiting %v), but found %+v.", id, name, waiting, s)
+		}
+	}
+}
+
+func TestCheckSession(t *testing.T) {
+	c := &CodeforcesClient{host: host, client: replaytest.FixtureClient(t)}
+	if err := c.CheckSession(); err != nil {
+		t.Errorf("Expect a valid session, but found %v.", err)
+	}
+	c.client = replaytest.AnonymousClient()
+	if err := c.CheckSession(); !c.Site().NotLogged(err) {
+		t.Errorf("Expect an expired session, but found %v.", err)
+	}
+}
@@ -0,0 +1,51 @@
+package codeforces_client
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/Arapak/sio-tool/util"
+)
+
+func (s *Submission) CompilationError() bool {
+	return strings.Contains(strings.ToLower(s.status), "compilation error")
+}
+
+func (c *CodeforcesClient) GetCompilationLog(info Info) (compilerLog string, err error) {
+	URL, err := info.MySubmissionURL(c.host)
+	if err != nil {
+		return
+	}
+	body, err := util.GetBody(c.client, URL)
+	if err != nil {
+		return
+	}
+	if _, err = findHandle(body); err != nil {
+		return
+	}
+	csrf, err := findCsrf(body)
+	if err != nil {
+		return
+	}
+	body, err = util.PostBody(c.client, c.host+"/data/judgeProtocol", url.Values{
+		"submissionId": {info.SubmissionID},
+		"csrf_token":   {csrf},
+	})
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &compilerLog)
+	return
+}
+
+// PrintCompilationLog prints the compiler output of a submission with references to
+// the submitted file mapped to sourcePath (if it is not empty).
+func (c *CodeforcesClient) PrintCompilationLog(info Info, sourcePath string) (err error) {
+	compilerLog, err := c.GetCompilationLog(info)
+	if err != nil {
+		return
+	}
+	util.PrintCompilationLog(compilerLog, sourcePath)
+	return
+}
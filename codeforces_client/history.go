@@ -0,0 +1,124 @@
+package codeforces_client
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Arapak/sio-tool/database_client"
+	"github.com/Arapak/sio-tool/util"
+	"github.com/fatih/color"
+)
+
+const judgeName = "codeforces"
+
+type apiSubmission struct {
+	ID                  uint64 `json:"id"`
+	ContestID           uint64 `json:"contestId"`
+	CreationTimeSeconds int64  `json:"creationTimeSeconds"`
+	Problem             struct {
+		Index string `json:"index"`
+		Name  string `json:"name"`
+	} `json:"problem"`
+	ProgrammingLanguage string  `json:"programmingLanguage"`
+	Verdict             string  `json:"verdict"`
+	Points              float64 `json:"points"`
+}
+
+var colorTagReg = regexp.MustCompile(`\$\{c-\w+}`)
+
+// PlainStatus returns the status without color tags.
+func (s *Submission) PlainStatus() string {
+	status := strings.ReplaceAll(s.status, "${f-points}", fmt.Sprintf("%v", s.points))
+	status = strings.ReplaceAll(status, "${f-passed}", fmt.Sprintf("%v", s.passed))
+	status = strings.ReplaceAll(status, "${f-judged}", fmt.Sprintf("%v", s.judged))
+	return strings.TrimSpace(colorTagReg.ReplaceAllString(status, ""))
+}
+
+// verdictPoints turns a Codeforces verdict into a score comparable with Sio and Szkopul.
+func verdictPoints(verdict string, points float64) int {
+	if points > 0 {
+		return int(points)
+	} else if verdict == "OK" {
+		return 100
+	} else if isWait(verdict) || verdict == "" {
+		return database_client.NoPoints
+	}
+	return 0
+}
+
+// recordSubmission saves a submission made with st, together with the hash of its source, in the database.
+func (c *CodeforcesClient) recordSubmission(db *sql.DB, info Info, s Submission, sourcePath string) {
+	if db == nil {
+		return
+	}
+	submission := database_client.Submission{
+		Judge:        judgeName,
+		SubmissionID: s.ParseID(),
+		ContestID:    info.ContestID,
+		ShortName:    strings.ToUpper(info.ProblemID),
+		When:         s.when,
+		Language:     s.lang,
+		Status:       s.PlainStatus(),
+		Points:       database_client.NoPoints,
+		FilePath:     sourcePath,
+	}
+	if strings.Contains(s.status, "${c-accepted}") {
+		submission.Points = 100
+	} else if s.end {
+		submission.Points = 0
+	}
+	if source, err := os.ReadFile(sourcePath); err == nil {
+		submission.SourceHash = database_client.SourceHash(source)
+	}
+	submission.TaskID, _ = database_client.FindTaskID(db, database_client.Task{Source: "cf", ContestID: info.ContestID, ShortName: submission.ShortName})
+	if err := database_client.AddSubmission(db, submission); err != nil {
+		color.Red(err.Error())
+	}
+}
+
+// Sync saves the whole submission history of the current user (from the Codeforces API) in the database.
+func (c *CodeforcesClient) Sync(db *sql.DB) (synced int, err error) {
+	if c.Handle == "" {
+		return 0, errors.New(ErrorNotLogged)
+	}
+	body, err := util.GetBody(c.client, fmt.Sprintf("%v/api/user.status?handle=%v", c.host, url.QueryEscape(c.Handle)))
+	if err != nil {
+		return
+	}
+	var response struct {
+		Status  string          `json:"status"`
+		Comment string          `json:"comment"`
+		Result  []apiSubmission `json:"result"`
+	}
+	if err = json.Unmarshal(body, &response); err != nil {
+		return
+	}
+	if response.Status != "OK" {
+		return 0, errors.New(response.Comment)
+	}
+	for _, s := range response.Result {
+		submission := database_client.Submission{
+			Judge:        judgeName,
+			SubmissionID: fmt.Sprint(s.ID),
+			ContestID:    fmt.Sprint(s.ContestID),
+			ShortName:    strings.ToUpper(s.Problem.Index),
+			When:         time.Unix(s.CreationTimeSeconds, 0).Format("2006-01-02 15:04"),
+			Language:     s.ProgrammingLanguage,
+			Status:       s.Verdict,
+			Points:       verdictPoints(s.Verdict, s.Points),
+		}
+		if err = database_client.AddSubmission(db, submission); err != nil {
+			return
+		}
+		synced++
+	}
+	err = database_client.LinkSubmissions(db, judgeName, "cf")
+	return
+}
@@ -4,14 +4,13 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/md5"
-	"crypto/rand"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/Arapak/sio-tool/cookiejar"
+	"github.com/Arapak/sio-tool/credentials"
 	"github.com/Arapak/sio-tool/util"
-	"io"
 	"net/http"
 	"net/url"
 	"regexp"
@@ -86,6 +85,19 @@ func addRCPC(c *CodeforcesClient, body []byte) ([]byte, error) {
 	return body, nil
 }
 
+// CheckSession checks that the saved session is still logged in as Handle.
+func (c *CodeforcesClient) CheckSession() (err error) {
+	body, err := util.GetBody(c.client, c.host)
+	if err != nil {
+		return
+	}
+	if body, err = addRCPC(c, body); err != nil {
+		return
+	}
+	_, err = findHandle(body)
+	return
+}
+
 func (c *CodeforcesClient) Login() (err error) {
 	color.Cyan("Login %v...\n", c.HandleOrEmail)
 
@@ -147,24 +159,7 @@ func createHash(key string) []byte {
 	return hasher.Sum(nil)
 }
 
-func encrypt(handle, password string) (ret string, err error) {
-	block, err := aes.NewCipher(createHash("glhf" + handle + "233"))
-	if err != nil {
-		return
-	}
-	gcm, err := cipher.NewGCM(block)
-	if err != nil {
-		return
-	}
-	nonce := make([]byte, gcm.NonceSize())
-	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
-		return
-	}
-	text := gcm.Seal(nonce, nonce, []byte(password), nil)
-	ret = hex.EncodeToString(text)
-	return
-}
-
+// decrypt reads a password saved by older versions of st, see credentials.Migrate.
 func decrypt(handle, password string) (ret string, err error) {
 	data, err := hex.DecodeString(password)
 	if err != nil {
@@ -190,10 +185,10 @@ func decrypt(handle, password string) (ret string, err error) {
 }
 
 func (c *CodeforcesClient) DecryptPassword() (string, error) {
-	if len(c.Password) == 0 || len(c.HandleOrEmail) == 0 {
-		return "", errors.New("you have to configure your handle and password by `st config`")
+	if len(c.HandleOrEmail) == 0 {
+		return "", errors.New(credentials.ErrorNotFound)
 	}
-	return decrypt(c.HandleOrEmail, c.Password)
+	return credentials.Get("codeforces", c.HandleOrEmail)
 }
 
 func (c *CodeforcesClient) ConfigLogin() (err error) {
@@ -211,8 +206,7 @@ func (c *CodeforcesClient) ConfigLogin() (err error) {
 	}
 
 	c.HandleOrEmail = handleOrEmail
-	c.Password, err = encrypt(handleOrEmail, password)
-	if err != nil {
+	if err = credentials.Set("codeforces", handleOrEmail, password); err != nil {
 		return
 	}
 	return c.Login()
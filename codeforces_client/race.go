@@ -3,15 +3,12 @@ package codeforces_client
 import (
 	"bytes"
 	"errors"
-	"fmt"
 	"regexp"
 	"strconv"
-	"time"
 
 	"github.com/Arapak/sio-tool/util"
 
 	"github.com/fatih/color"
-	"github.com/k0kubun/go-ansi"
 )
 
 func findCountdown(body []byte) (int, error) {
@@ -55,16 +52,7 @@ func (c *CodeforcesClient) RaceContest(info Info) (err error) {
 			return err
 		}
 		color.Green("Countdown: ")
-		for count > 0 {
-			h := count / 60 / 60
-			m := count/60 - h*60
-			s := count - h*60*60 - m*60
-			fmt.Printf("%02d:%02d:%02d\n", h, m, s)
-			ansi.CursorUp(1)
-			count--
-			time.Sleep(time.Second)
-		}
-		time.Sleep(900 * time.Millisecond)
+		util.Countdown(int64(count))
 	}
 
 	return
@@ -1,12 +1,15 @@
 package codeforces_client
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 	"net/url"
 	"regexp"
 	"strings"
 
+	"github.com/Arapak/sio-tool/account"
+	"github.com/Arapak/sio-tool/database_client"
 	"github.com/Arapak/sio-tool/util"
 
 	"github.com/fatih/color"
@@ -21,7 +24,7 @@ func findErrorMessage(body []byte) (string, error) {
 	return string(tmp[1]), nil
 }
 
-func (c *CodeforcesClient) Submit(info Info, langID, source string) (err error) {
+func (c *CodeforcesClient) Submit(info Info, langID, sourcePath, source string, db *sql.DB) (err error) {
 	color.Cyan("Submit " + info.Hint())
 
 	URL, err := info.SubmitURL(c.host)
@@ -39,7 +42,12 @@ func (c *CodeforcesClient) Submit(info Info, langID, source string) (err error)
 		return
 	}
 
-	fmt.Printf("Current user: %v\n", handle)
+	fmt.Printf("Current user: %v\n", account.Label(c.path, handle))
+
+	check := database_client.Submission{Judge: judgeName, ContestID: info.ContestID, ShortName: strings.ToUpper(info.ProblemID)}
+	if err = database_client.CheckSubmission(db, check, sourcePath, database_client.UnknownLimit); err != nil {
+		return
+	}
 
 	csrf, err := findCsrf(body)
 	if err != nil {
@@ -86,5 +94,11 @@ func (c *CodeforcesClient) Submit(info Info, langID, source string) (err error)
 	info.SubmissionID = submissions[0].ParseID()
 	c.Handle = handle
 	c.LastSubmission = &info
+	c.recordSubmission(db, info, submissions[0], sourcePath)
+	if submissions[0].CompilationError() {
+		if err = c.PrintCompilationLog(info, sourcePath); err != nil {
+			color.Red(err.Error())
+		}
+	}
 	return c.save()
 }
@@ -54,6 +54,15 @@ func (s *Submission) ParseID() string {
 	return fmt.Sprintf("%v", s.id)
 }
 
+func (s *Submission) Name() string {
+	return s.name
+}
+
+// End reports whether the submission has been judged.
+func (s *Submission) End() bool {
+	return s.end
+}
+
 func (s *Submission) ParseMemory() string {
 	if s.memory > 1024*1024 {
 		return fmt.Sprintf("%.2f MB", float64(s.memory)/1024.0/1024.0)
@@ -277,26 +286,70 @@ func (c *CodeforcesClient) WatchSubmission(info Info, n int, line bool) (submiss
 
 	maxWidth := 0
 	first := true
+	poller := util.NewPoller()
+	previous := ""
+	pending := make(map[uint64]bool)
 	for {
-		st := time.Now()
 		submissions, err = c.getSubmissions(URL, n)
 		if err != nil {
+			if poller.Backoff(err) {
+				if err = poller.Wait(); err != nil {
+					return
+				}
+				continue
+			}
 			return
 		}
 		display(submissions, info.ProblemID, first, &maxWidth, line)
-		first = false
 		endCount := 0
 		for _, submission := range submissions {
 			if submission.end {
 				endCount++
+			} else if first {
+				pending[submission.id] = true
 			}
 		}
+		first = false
 		if endCount == len(submissions) {
+			if !line {
+				c.printCompilationLogs(info, submissions, pending)
+			}
+			return
+		}
+		if state := statusSignature(submissions); state != previous {
+			previous = state
+			poller.Reset()
+		}
+		if err = poller.Wait(); err != nil {
 			return
 		}
-		sub := time.Since(st)
-		if sub < time.Second {
-			time.Sleep(time.Second - sub)
+	}
+}
+
+// statusSignature changes whenever the status of any of the submissions changes.
+func statusSignature(submissions []Submission) string {
+	var signature strings.Builder
+	for _, s := range submissions {
+		signature.WriteString(fmt.Sprint(s.id, s.status, s.passed, s.judged, s.points, ";"))
+	}
+	return signature.String()
+}
+
+// RecentSubmissions returns the latest submissions of the current user from all contests.
+func (c *CodeforcesClient) RecentSubmissions() ([]Submission, error) {
+	return c.getSubmissions(fmt.Sprintf(c.host+"/submissions/%v", c.Handle), -1)
+}
+
+// printCompilationLogs prints compiler output of the submissions which failed to compile while being watched.
+func (c *CodeforcesClient) printCompilationLogs(info Info, submissions []Submission, pending map[uint64]bool) {
+	for _, submission := range submissions {
+		if !pending[submission.id] || !submission.CompilationError() {
+			continue
+		}
+		info.SubmissionID = submission.ParseID()
+		color.Cyan("Submission #%v", info.SubmissionID)
+		if err := c.PrintCompilationLog(info, ""); err != nil {
+			color.Red(err.Error())
 		}
 	}
 }
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/Arapak/sio-tool/account"
+	"github.com/Arapak/sio-tool/util"
+)
+
+// SessionPath returns the session file of the account used on the judge,
+// path is the session file of its default account. When the account chosen for
+// this run doesn't exist on the judge, it returns path and AccountError reports it.
+func (c *Config) SessionPath(judge, path string) string {
+	c.sessionPaths[judge] = path
+	name, err := account.Choose(path, c.Accounts[judge])
+	if err != nil {
+		c.accountErrors[judge] = err
+	}
+	return account.Path(path, name)
+}
+
+// AccountError returns the error of choosing the account of the judge, the commands
+// on the judge have to stop on it.
+func (c *Config) AccountError(judge string) error {
+	if err, ok := c.accountErrors[judge]; ok {
+		return fmt.Errorf("%v: %w", judge, err)
+	}
+	return nil
+}
+
+// DefaultSessionPath returns the session file of the default account of the judge.
+func (c *Config) DefaultSessionPath(judge string) (string, error) {
+	path, ok := c.sessionPaths[judge]
+	if !ok {
+		return "", fmt.Errorf("unknown judge %v", judge)
+	}
+	return path, nil
+}
+
+// UseAccount makes st use the account on the judge from now on.
+func (c *Config) UseAccount(judge, name string) error {
+	if name == account.Default {
+		delete(c.Accounts, judge)
+	} else {
+		c.Accounts[judge] = name
+	}
+	return c.save()
+}
+
+// KattisRCPath returns the .kattisrc of the Kattis account with the session file path:
+// a named account can keep its own "kattisrc" next to the session file.
+func (c *Config) KattisRCPath(path string) string {
+	if account.Name(path) == account.Default {
+		return c.KattisRC
+	}
+	if rc := filepath.Join(filepath.Dir(path), "kattisrc"); util.FileExists(rc) {
+		return rc
+	}
+	return c.KattisRC
+}
@@ -7,9 +7,12 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/Arapak/sio-tool/codeforces_client"
+	"github.com/Arapak/sio-tool/credentials"
 	"github.com/Arapak/sio-tool/szkopul_client"
+	"github.com/Arapak/sio-tool/util"
 
 	"github.com/fatih/color"
 	"github.com/mitchellh/go-homedir"
@@ -23,29 +26,57 @@ type CodeTemplate struct {
 	BeforeScript string   `json:"before_script"`
 	Script       string   `json:"script"`
 	AfterScript  string   `json:"after_script"`
+	// TestBeforeSubmit makes "st submit" run the samples first for code using this template.
+	TestBeforeSubmit bool `json:"test_before_submit"`
 }
 
 type Config struct {
-	Template       []CodeTemplate    `json:"template"`
-	Default        int               `json:"default"`
-	GenAfterParse  bool              `json:"gen_after_parse"`
-	CodeforcesHost string            `json:"codeforces_host"`
-	SzkopulHost    string            `json:"szkopul_host"`
-	SioStaszicHost string            `json:"sio_staszic_host"`
-	SioMimuwHost   string            `json:"sio_mimuw_host"`
-	SioTalentHost  string            `json:"sio_talent_host"`
-	Proxy          string            `json:"proxy"`
-	FolderName     map[string]string `json:"folder_name"`
-	DefaultNaming  map[string]string `json:"default_naming"`
-	DbPath         string            `json:"db_path"`
-	PackagesPath   string            `json:"packages_path"`
-	path           string
+	Template       []CodeTemplate `json:"template"`
+	Default        int            `json:"default"`
+	GenAfterParse  bool           `json:"gen_after_parse"`
+	CodeforcesHost string         `json:"codeforces_host"`
+	SzkopulHost    string         `json:"szkopul_host"`
+	AtcoderHost    string         `json:"atcoder_host"`
+	// KattisRC is the .kattisrc file with the credentials and the URLs of the Kattis judge.
+	KattisRC string `json:"kattisrc"`
+	// DomjudgeHost is the DOMjudge of a local contest, st doesn't use DOMjudge when it is empty.
+	DomjudgeHost string `json:"domjudge_host"`
+	// SioInstances are the Sio2 instances st can use, see DefaultSioInstances.
+	SioInstances  []SioInstance     `json:"sio_instances"`
+	Proxy         string            `json:"proxy"`
+	FolderName    map[string]string `json:"folder_name"`
+	DefaultNaming map[string]string `json:"default_naming"`
+	DbPath        string            `json:"db_path"`
+	PackagesPath  string            `json:"packages_path"`
+	// TestBeforeSubmit lists the sites on which "st submit" runs the samples first.
+	TestBeforeSubmit    map[string]bool `json:"test_before_submit"`
+	PackageBeforeSubmit bool            `json:"package_before_submit"`
+	// PollInterval, PollMaxInterval and PollTimeout (in seconds, 0 means no timeout) set how often
+	// st asks the judges about pending submissions.
+	PollInterval    int `json:"poll_interval"`
+	PollMaxInterval int `json:"poll_max_interval"`
+	PollTimeout     int `json:"poll_timeout"`
+	// HTTPTimeout (in seconds, 0 means no timeout) and HTTPRetries set how long st waits for
+	// the headers of a response and how many times it repeats a failed request, see util.HTTPOptions.
+	HTTPTimeout int    `json:"http_timeout"`
+	HTTPRetries int    `json:"http_retries"`
+	UserAgent   string `json:"user_agent"`
+	// DebugLog is the file st logs all requests and the headers of the responses to, or empty for no logging.
+	DebugLog string `json:"debug_log"`
+	// CredentialStore is where the passwords are kept: "auto", "keyring" or "vault".
+	CredentialStore string `json:"credential_store"`
+	// Accounts are the accounts chosen with "st account use", the judges which are
+	// not listed use their default account.
+	Accounts      map[string]string `json:"accounts"`
+	path          string
+	sessionPaths  map[string]string
+	accountErrors map[string]error
 }
 
 var Instance *Config
 
 func Init(path string) {
-	c := &Config{path: path, CodeforcesHost: "https://codeforces.com", SzkopulHost: "https://szkopul.edu.pl", SioStaszicHost: "https://sio2.staszic.waw.pl", SioMimuwHost: "https://sio2.mimuw.edu.pl", SioTalentHost: "https://wyzwania.programuj.edu.pl", DbPath: "~/.st/tasks.db", Proxy: "", PackagesPath: "~/.st/packages"}
+	c := &Config{path: path, CodeforcesHost: "https://codeforces.com", SzkopulHost: "https://szkopul.edu.pl", AtcoderHost: "https://atcoder.jp", KattisRC: "~/.kattisrc", DbPath: "~/.st/tasks.db", Proxy: "", PackagesPath: "~/.st/packages", PollInterval: 1, PollMaxInterval: 30, HTTPTimeout: 30, HTTPRetries: 2, UserAgent: util.DefaultUserAgent, CredentialStore: credentials.Auto}
 	if err := c.load(); err != nil {
 		color.Red(err.Error())
 		color.Green("Create a new configuration in %v", path)
@@ -56,15 +87,15 @@ func Init(path string) {
 	if c.FolderName == nil {
 		c.FolderName = map[string]string{}
 	}
-	if _, ok := c.FolderName["sio-staszic-root"]; !ok {
-		c.FolderName["sio-staszic-root"] = "~/st/sio-staszic"
+	if c.Accounts == nil {
+		c.Accounts = map[string]string{}
 	}
-	if _, ok := c.FolderName["sio-mimuw-root"]; !ok {
-		c.FolderName["sio-mimuw-root"] = "~/st/sio-mimuw"
-	}
-	if _, ok := c.FolderName["sio-talent-root"]; !ok {
-		c.FolderName["sio-talent-root"] = "~/st/sio-talent"
+	c.sessionPaths = map[string]string{}
+	c.accountErrors = map[string]error{}
+	if c.SioInstances == nil {
+		c.migrateSioInstances(nil)
 	}
+	c.checkSioInstances()
 	if _, ok := c.FolderName["codeforces-root"]; !ok {
 		c.FolderName["codeforces-root"] = "~/st/codeforces"
 	}
@@ -81,6 +112,15 @@ func Init(path string) {
 			c.FolderName[fmt.Sprintf("szkopul-%v", archive)] = archive
 		}
 	}
+	if _, ok := c.FolderName["atcoder-root"]; !ok {
+		c.FolderName["atcoder-root"] = "~/st/atcoder"
+	}
+	if _, ok := c.FolderName["kattis-root"]; !ok {
+		c.FolderName["kattis-root"] = "~/st/kattis"
+	}
+	if _, ok := c.FolderName["domjudge-root"]; !ok {
+		c.FolderName["domjudge-root"] = "~/st/domjudge"
+	}
 
 	if c.DefaultNaming == nil {
 		c.DefaultNaming = map[string]string{}
@@ -102,19 +142,33 @@ func Init(path string) {
 		color.Red(err.Error())
 		return
 	}
-	c.FolderName["sio-staszic-root"], err = homedir.Expand(c.FolderName["sio-staszic-root"])
+	for i := range c.SioInstances {
+		c.SioInstances[i].Root, err = homedir.Expand(c.SioInstances[i].Root)
+		if err != nil {
+			color.Red(err.Error())
+		}
+	}
+	c.FolderName["codeforces-root"], err = homedir.Expand(c.FolderName["codeforces-root"])
 	if err != nil {
 		color.Red(err.Error())
 	}
-	c.FolderName["sio-mimuw-root"], err = homedir.Expand(c.FolderName["sio-mimuw-root"])
+	c.FolderName["szkopul-root"], err = homedir.Expand(c.FolderName["szkopul-root"])
 	if err != nil {
 		color.Red(err.Error())
 	}
-	c.FolderName["codeforces-root"], err = homedir.Expand(c.FolderName["codeforces-root"])
+	c.FolderName["atcoder-root"], err = homedir.Expand(c.FolderName["atcoder-root"])
 	if err != nil {
 		color.Red(err.Error())
 	}
-	c.FolderName["szkopul-root"], err = homedir.Expand(c.FolderName["szkopul-root"])
+	c.FolderName["kattis-root"], err = homedir.Expand(c.FolderName["kattis-root"])
+	if err != nil {
+		color.Red(err.Error())
+	}
+	c.FolderName["domjudge-root"], err = homedir.Expand(c.FolderName["domjudge-root"])
+	if err != nil {
+		color.Red(err.Error())
+	}
+	c.KattisRC, err = homedir.Expand(c.KattisRC)
 	if err != nil {
 		color.Red(err.Error())
 	}
@@ -126,9 +180,32 @@ func Init(path string) {
 	if err != nil {
 		color.Red(err.Error())
 	}
+	c.DebugLog, err = homedir.Expand(c.DebugLog)
+	if err != nil {
+		color.Red(err.Error())
+	}
+	c.applyPollOptions()
+	c.applyHTTPOptions()
 	Instance = c
 }
 
+func (c *Config) applyPollOptions() {
+	if c.PollInterval > 0 {
+		util.Poll.Interval = time.Duration(c.PollInterval) * time.Second
+	}
+	if c.PollMaxInterval > 0 {
+		util.Poll.MaxInterval = time.Duration(c.PollMaxInterval) * time.Second
+	}
+	util.Poll.Timeout = time.Duration(c.PollTimeout) * time.Second
+}
+
+func (c *Config) applyHTTPOptions() {
+	util.HTTP.Timeout = time.Duration(c.HTTPTimeout) * time.Second
+	util.HTTP.Retries = c.HTTPRetries
+	util.HTTP.UserAgent = c.UserAgent
+	util.HTTP.DebugLog = c.DebugLog
+}
+
 func (c *Config) load() (err error) {
 	file, err := os.Open(c.path)
 	if err != nil {
@@ -146,6 +223,9 @@ func (c *Config) load() (err error) {
 	if err != nil {
 		return err
 	}
+	if c.SioInstances == nil {
+		c.migrateSioInstances(data)
+	}
 	return nil
 }
 
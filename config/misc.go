@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"strconv"
 
 	"github.com/AlecAivazis/survey/v2"
 
 	"github.com/Arapak/sio-tool/codeforces_client"
+	"github.com/Arapak/sio-tool/credentials"
 	"github.com/Arapak/sio-tool/szkopul_client"
 	"github.com/fatih/color"
 	"github.com/mitchellh/go-homedir"
@@ -33,6 +35,18 @@ func validateHost(host interface{}) error {
 	return nil
 }
 
+// validateLocalHost accepts also the hosts without a domain and with a port, e.g. "http://localhost:12345".
+func validateLocalHost(host interface{}) error {
+	if host == "" {
+		return nil
+	}
+	reg := regexp.MustCompile(`^https?://[\w\-]+(\.[\w\-]+)*(:\d+)?(/.*)?$`)
+	if !reg.MatchString(host.(string)) {
+		return fmt.Errorf(`invalid host "%v"`, host)
+	}
+	return nil
+}
+
 func formatHost(host string) string {
 	for host[len(host)-1:] == "/" {
 		host = host[:len(host)-1]
@@ -58,20 +72,24 @@ func (c *Config) SetHost() (err error) {
 	if c.SzkopulHost, err = inputDontOverwriteEmpty(`Szkopul host`, c.SzkopulHost, validateHost); err != nil {
 		return
 	}
-	if c.SioStaszicHost, err = inputDontOverwriteEmpty(`SioStaszic host`, c.SioStaszicHost, validateHost); err != nil {
+	if c.AtcoderHost, err = inputDontOverwriteEmpty(`AtCoder host`, c.AtcoderHost, validateHost); err != nil {
 		return
 	}
-	if c.SioMimuwHost, err = inputDontOverwriteEmpty(`SioMimuw host`, c.SioMimuwHost, validateHost); err != nil {
+	if c.DomjudgeHost, err = inputDontOverwriteEmpty(`DOMjudge host (of your local contest)`, c.DomjudgeHost, validateLocalHost); err != nil {
 		return
 	}
-	if c.SioTalentHost, err = inputDontOverwriteEmpty(`SioTalent host`, c.SioTalentHost, validateHost); err != nil {
-		return
+	for i, instance := range c.SioInstances {
+		if c.SioInstances[i].Host, err = inputDontOverwriteEmpty(fmt.Sprintf(`%v host`, instance.Name), instance.Host, validateHost); err != nil {
+			return
+		}
+		c.SioInstances[i].Host = formatHost(c.SioInstances[i].Host)
 	}
 	c.CodeforcesHost = formatHost(c.CodeforcesHost)
 	c.SzkopulHost = formatHost(c.SzkopulHost)
-	c.SioStaszicHost = formatHost(c.SioStaszicHost)
-	c.SioMimuwHost = formatHost(c.SioMimuwHost)
-	c.SioTalentHost = formatHost(c.SioTalentHost)
+	c.AtcoderHost = formatHost(c.AtcoderHost)
+	if c.DomjudgeHost != "" {
+		c.DomjudgeHost = formatHost(c.DomjudgeHost)
+	}
 	return c.save()
 }
 
@@ -155,26 +173,32 @@ func (c *Config) SetFolderName() (err error) {
 			return
 		}
 	}
-	if c.FolderName["sio-staszic-root"], err = inputDontOverwriteEmpty(`Sio staszic root path (absolute)`, c.FolderName["sio-staszic-root"], validateAbsolutePath); err != nil {
+	if c.FolderName["atcoder-root"], err = inputDontOverwriteEmpty(`AtCoder root path (absolute)`, c.FolderName["atcoder-root"], validateAbsolutePath); err != nil {
 		return
 	}
-	if c.FolderName["sio-staszic-root"], err = homedir.Expand(c.FolderName["sio-staszic-root"]); err != nil {
+	if c.FolderName["atcoder-root"], err = homedir.Expand(c.FolderName["atcoder-root"]); err != nil {
 		return
 	}
-
-	if c.FolderName["sio-mimuw-root"], err = inputDontOverwriteEmpty(`Sio mimuw root path (absolute)`, c.FolderName["sio-mimuw-root"], validateAbsolutePath); err != nil {
+	if c.FolderName["kattis-root"], err = inputDontOverwriteEmpty(`Kattis root path (absolute)`, c.FolderName["kattis-root"], validateAbsolutePath); err != nil {
 		return
 	}
-	if c.FolderName["sio-mimuw-root"], err = homedir.Expand(c.FolderName["sio-mimuw-root"]); err != nil {
+	if c.FolderName["kattis-root"], err = homedir.Expand(c.FolderName["kattis-root"]); err != nil {
 		return
 	}
-
-	if c.FolderName["sio-talent-root"], err = inputDontOverwriteEmpty(`Sio talent root path (absolute)`, c.FolderName["sio-talent-root"], validateAbsolutePath); err != nil {
+	if c.FolderName["domjudge-root"], err = inputDontOverwriteEmpty(`DOMjudge root path (absolute)`, c.FolderName["domjudge-root"], validateAbsolutePath); err != nil {
 		return
 	}
-	if c.FolderName["sio-talent-root"], err = homedir.Expand(c.FolderName["sio-talent-root"]); err != nil {
+	if c.FolderName["domjudge-root"], err = homedir.Expand(c.FolderName["domjudge-root"]); err != nil {
 		return
 	}
+	for i, instance := range c.SioInstances {
+		if c.SioInstances[i].Root, err = inputDontOverwriteEmpty(fmt.Sprintf(`%v root path (absolute)`, instance.Name), instance.Root, validateAbsolutePath); err != nil {
+			return
+		}
+		if c.SioInstances[i].Root, err = homedir.Expand(c.SioInstances[i].Root); err != nil {
+			return
+		}
+	}
 	return c.save()
 }
 
@@ -223,3 +247,137 @@ func (c *Config) SetDbPath() (err error) {
 	color.Green("New database path is %v", dbPath)
 	return c.save()
 }
+
+func (c *Config) SetTestBeforeSubmit() (err error) {
+	color.Cyan(`"st submit" can run the samples (and the local package) before submitting and abort on any failed test`)
+	var sites []string
+	for _, site := range c.Sites() {
+		if c.TestBeforeSubmit[site] {
+			sites = append(sites, site)
+		}
+	}
+	if err = survey.AskOne(&survey.MultiSelect{Message: `Test before submitting to:`, Options: c.Sites(), Default: sites}, &sites); err != nil {
+		return
+	}
+	c.TestBeforeSubmit = map[string]bool{}
+	for _, site := range sites {
+		c.TestBeforeSubmit[site] = true
+	}
+
+	if len(c.Template) > 0 {
+		var aliases, selected []string
+		for _, template := range c.Template {
+			aliases = append(aliases, template.Alias)
+			if template.TestBeforeSubmit {
+				selected = append(selected, template.Alias)
+			}
+		}
+		prompt := &survey.MultiSelect{Message: `Test before submitting code using the templates (on any site):`, Options: aliases, Default: selected}
+		var indexes []int
+		if err = survey.AskOne(prompt, &indexes); err != nil {
+			return
+		}
+		for i := range c.Template {
+			c.Template[i].TestBeforeSubmit = false
+		}
+		for _, i := range indexes {
+			c.Template[i].TestBeforeSubmit = true
+		}
+	}
+
+	prompt := &survey.Confirm{Message: `Also run the local package before submitting?`, Default: c.PackageBeforeSubmit}
+	if err = survey.AskOne(prompt, &c.PackageBeforeSubmit); err != nil {
+		return
+	}
+	return c.save()
+}
+
+// ShouldTestBeforeSubmit reports whether the code using the template should be tested before submitting to the site.
+func (c *Config) ShouldTestBeforeSubmit(site string, template int) bool {
+	if template >= 0 && template < len(c.Template) && c.Template[template].TestBeforeSubmit {
+		return true
+	}
+	return c.TestBeforeSubmit[site]
+}
+
+func validateSeconds(value interface{}) error {
+	if _, err := strconv.Atoi(value.(string)); value.(string) != "" && err != nil {
+		return fmt.Errorf(`invalid number of seconds "%v"`, value)
+	}
+	return nil
+}
+
+func inputSeconds(message string, value int) (int, error) {
+	newValue, err := inputDontOverwriteEmpty(message, fmt.Sprint(value), validateSeconds)
+	if err != nil {
+		return value, err
+	}
+	return strconv.Atoi(newValue)
+}
+
+func (c *Config) SetPolling() (err error) {
+	color.Cyan(`While watching submissions, st waits longer and longer between requests when nothing changes`)
+	color.Cyan(`Enter empty line if you don't want to change the value`)
+	if c.PollInterval, err = inputSeconds(`First interval in seconds`, c.PollInterval); err != nil {
+		return
+	}
+	if c.PollMaxInterval, err = inputSeconds(`Maximum interval in seconds`, c.PollMaxInterval); err != nil {
+		return
+	}
+	if c.PollTimeout, err = inputSeconds(`Stop watching after (seconds, 0 means never)`, c.PollTimeout); err != nil {
+		return
+	}
+	c.applyPollOptions()
+	return c.save()
+}
+
+func validateNumber(value interface{}) error {
+	if n, err := strconv.Atoi(value.(string)); value.(string) != "" && (err != nil || n < 0) {
+		return fmt.Errorf(`invalid number "%v"`, value)
+	}
+	return nil
+}
+
+func (c *Config) SetNetwork() (err error) {
+	color.Cyan(`st retries GET requests after network errors and server errors (5xx), but never submissions`)
+	color.Cyan(`Enter empty line if you don't want to change the value`)
+	if c.HTTPTimeout, err = inputSeconds(`Timeout of waiting for a response in seconds (0 means no timeout)`, c.HTTPTimeout); err != nil {
+		return
+	}
+	retries, err := inputDontOverwriteEmpty(`Number of retries`, fmt.Sprint(c.HTTPRetries), validateNumber)
+	if err != nil {
+		return
+	}
+	if c.HTTPRetries, err = strconv.Atoi(retries); err != nil {
+		return
+	}
+	if c.UserAgent, err = inputDontOverwriteEmpty(`User-Agent`, c.UserAgent, nil); err != nil {
+		return
+	}
+	color.Cyan(`st can log all requests and the statuses and headers of the responses (without cookies) to a file, e.g. to report a bug`)
+	debugLog := c.DebugLog
+	if err = survey.AskOne(&survey.Input{Message: `Debug log file (empty to turn off):`, Default: debugLog}, &debugLog); err != nil {
+		return
+	}
+	if c.DebugLog, err = homedir.Expand(debugLog); err != nil {
+		return
+	}
+	c.applyHTTPOptions()
+	return c.save()
+}
+
+func (c *Config) SetCredentialStore() (err error) {
+	color.Green("Current credential store: %v", c.CredentialStore)
+	color.Cyan(`The passwords can be kept in the keyring of your system (Secret Service, Keychain or Credential Manager)`)
+	color.Cyan(`or in a vault file encrypted with a passphrase, "auto" uses the keyring when there is one`)
+	stores := []string{credentials.Auto, credentials.Keyring, credentials.Vault}
+	store := c.CredentialStore
+	if err = survey.AskOne(&survey.Select{Message: `credential store:`, Options: stores, Default: store}, &store); err != nil {
+		return
+	}
+	if store != c.CredentialStore {
+		color.Yellow(`The passwords saved so far are not moved, log in again with "st config"`)
+	}
+	c.CredentialStore = store
+	return c.save()
+}
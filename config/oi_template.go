@@ -40,7 +40,7 @@ func (c *Config) AddOiTemplate() (err error) {
 	}
 	c.Template = append(c.Template, CodeTemplate{
 		"oi-cpp", "54", oiTemplatePath, []string{"cpp", "cxx", "cc"},
-		oiTemplateCompilation, oiTemplateRun, "",
+		oiTemplateCompilation, oiTemplateRun, "", false,
 	})
 	return c.save()
 }
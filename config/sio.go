@@ -0,0 +1,181 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+	"github.com/mitchellh/go-homedir"
+
+	"github.com/Arapak/sio-tool/sio_client"
+)
+
+// SioInstance is a Sio2 (OIOIOI) instance. Its name is used for the session file, in the database
+// and in the site specific settings, the flavour selects the quirks of the instance's pages.
+type SioInstance struct {
+	Name    string `json:"name"`
+	Host    string `json:"host"`
+	Root    string `json:"root"`
+	Flavour string `json:"flavour"`
+}
+
+var DefaultSioInstances = []SioInstance{
+	{Name: "sio-staszic", Host: "https://sio2.staszic.waw.pl", Root: "~/st/sio-staszic", Flavour: "staszic"},
+	{Name: "sio-mimuw", Host: "https://sio2.mimuw.edu.pl", Root: "~/st/sio-mimuw", Flavour: "mimuw"},
+	{Name: "sio-talent", Host: "https://wyzwania.programuj.edu.pl", Root: "~/st/sio-talent", Flavour: "talent"},
+}
+
+// legacySioHosts are the host fields used before the instances could be configured.
+type legacySioHosts struct {
+	SioStaszicHost string `json:"sio_staszic_host"`
+	SioMimuwHost   string `json:"sio_mimuw_host"`
+	SioTalentHost  string `json:"sio_talent_host"`
+}
+
+// migrateSioInstances creates the default instances, keeping the hosts and root folders
+// of a configuration from before the instances could be configured.
+func (c *Config) migrateSioInstances(data []byte) {
+	var legacy legacySioHosts
+	if data != nil {
+		_ = json.Unmarshal(data, &legacy)
+	}
+	hosts := map[string]string{
+		"sio-staszic": legacy.SioStaszicHost,
+		"sio-mimuw":   legacy.SioMimuwHost,
+		"sio-talent":  legacy.SioTalentHost,
+	}
+	c.SioInstances = nil
+	for _, instance := range DefaultSioInstances {
+		if host := hosts[instance.Name]; host != "" {
+			instance.Host = host
+		}
+		if root, ok := c.FolderName[instance.Name+"-root"]; ok {
+			instance.Root = root
+			delete(c.FolderName, instance.Name+"-root")
+		}
+		c.SioInstances = append(c.SioInstances, instance)
+	}
+}
+
+var sioNameReg = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+func validateSioName(name string) error {
+	if !sioNameReg.MatchString(name) {
+		return errors.New("the name can contain only lowercase letters, digits and dashes")
+	}
+	if name == "codeforces" || name == "szkopul" || name == "atcoder" || name == "kattis" || name == "domjudge" {
+		return fmt.Errorf("%v is already used", name)
+	}
+	return nil
+}
+
+func (c *Config) validateNewSioName(name interface{}) error {
+	if _, ok := c.SioInstance(name.(string)); ok {
+		return fmt.Errorf("%v is already used", name)
+	}
+	return validateSioName(name.(string))
+}
+
+// checkSioInstances drops the instances which cannot be used and tells why.
+func (c *Config) checkSioInstances() {
+	var instances []SioInstance
+	used := map[string]bool{}
+	for _, instance := range c.SioInstances {
+		err := validateSioName(instance.Name)
+		if err == nil && used[instance.Name] {
+			err = errors.New("the name is used more than once")
+		}
+		if err != nil {
+			color.Red("Sio instance %v: %v", instance.Name, err.Error())
+			continue
+		}
+		if _, ok := sio_client.Flavours[instance.Flavour]; instance.Flavour != "" && !ok {
+			color.Red("Sio instance %v: unknown flavour %v", instance.Name, instance.Flavour)
+		}
+		used[instance.Name] = true
+		instances = append(instances, instance)
+	}
+	c.SioInstances = instances
+}
+
+// SioInstance returns the instance called name.
+func (c *Config) SioInstance(name string) (SioInstance, bool) {
+	for _, instance := range c.SioInstances {
+		if instance.Name == name {
+			return instance, true
+		}
+	}
+	return SioInstance{}, false
+}
+
+// Root returns the folder of the problems of the site called name, empty when it has none.
+func (c *Config) Root(name string) string {
+	if instance, ok := c.SioInstance(name); ok {
+		return instance.Root
+	}
+	return c.FolderName[name+"-root"]
+}
+
+// Sites lists the names of the sites which can be used in the site specific settings.
+func (c *Config) Sites() []string {
+	sites := []string{"codeforces", "szkopul", "atcoder", "kattis", "domjudge"}
+	for _, instance := range c.SioInstances {
+		sites = append(sites, instance.Name)
+	}
+	return sites
+}
+
+func flavours() (ret []string) {
+	for name := range sio_client.Flavours {
+		ret = append(ret, name)
+	}
+	sort.Strings(ret)
+	return
+}
+
+func (c *Config) AddSioInstance() (err error) {
+	color.Cyan(`Add a Sio2 (OIOIOI) instance, e.g. the one of your school or university`)
+	instance := SioInstance{}
+	if err = survey.AskOne(&survey.Input{Message: `name (e.g. "sio-uwr"):`}, &instance.Name, survey.WithValidator(c.validateNewSioName)); err != nil {
+		return
+	}
+	color.Cyan(`Note: Don't forget the "http://" or "https://"`)
+	if err = survey.AskOne(&survey.Input{Message: `host:`}, &instance.Host, survey.WithValidator(survey.ComposeValidators(survey.Required, validateHost))); err != nil {
+		return
+	}
+	instance.Host = formatHost(instance.Host)
+	if err = survey.AskOne(&survey.Input{Message: `root path (absolute):`, Default: "~/st/" + instance.Name}, &instance.Root, survey.WithValidator(validateAbsolutePath)); err != nil {
+		return
+	}
+	if instance.Root, err = homedir.Expand(instance.Root); err != nil {
+		return
+	}
+	color.Cyan(`The flavour tells st how the pages of the instance look like, choose %v if unsure`, sio_client.DefaultFlavour)
+	if err = survey.AskOne(&survey.Select{Message: `flavour:`, Options: flavours(), Default: sio_client.DefaultFlavour}, &instance.Flavour); err != nil {
+		return
+	}
+	c.SioInstances = append(c.SioInstances, instance)
+	color.Green("Added %v, log in to it with `st config`", instance.Name)
+	return c.save()
+}
+
+func (c *Config) RemoveSioInstance() (err error) {
+	if len(c.SioInstances) == 0 {
+		color.Red("There is no Sio instance")
+		return
+	}
+	var names []string
+	for _, instance := range c.SioInstances {
+		names = append(names, fmt.Sprintf("%v (%v)", instance.Name, instance.Host))
+	}
+	index := 0
+	if err = survey.AskOne(&survey.Select{Message: `Remove:`, Options: names}, &index); err != nil {
+		return
+	}
+	c.SioInstances = append(c.SioInstances[:index], c.SioInstances[index+1:]...)
+	return c.save()
+}
@@ -130,7 +130,7 @@ func (c *Config) AddTemplate() (err error) {
 
 	c.Template = append(c.Template, CodeTemplate{
 		alias, langs[langID].K, path, suffix,
-		beforeScript, script, afterScript,
+		beforeScript, script, afterScript, false,
 	})
 	makeItDefault := true
 	prompt := &survey.Confirm{Message: `Make it default?`, Default: true}
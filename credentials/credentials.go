@@ -0,0 +1,134 @@
+// Package credentials keeps the passwords of the judges outside of the session files,
+// in the keyring of the system or in a vault file encrypted with a passphrase.
+package credentials
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/zalando/go-keyring"
+)
+
+const ErrorNotFound = "you have to configure your username and password by `st config`"
+
+// The stores which can be chosen in the configuration. Auto uses the keyring when
+// the system has one and the vault otherwise.
+const (
+	Auto    = "auto"
+	Keyring = "keyring"
+	Vault   = "vault"
+)
+
+// Store keeps a password for every user of every service (the name of a judge, e.g. "codeforces").
+// Get returns an error with ErrorNotFound when there is no password.
+type Store interface {
+	Get(service, user string) (string, error)
+	Set(service, user, password string) error
+	Delete(service, user string) error
+	Name() string
+}
+
+// Instance is the chosen store, it is set on the first use, as looking for the keyring can take a while.
+var Instance Store
+
+var storeName, vaultPath string
+
+// Init sets the store to use (Auto, Keyring or Vault) and the file of the vault.
+func Init(store, path string) {
+	Instance, storeName, vaultPath = nil, store, path
+}
+
+func current() Store {
+	if Instance != nil {
+		return Instance
+	}
+	switch {
+	case storeName == Keyring:
+		Instance = &KeyringStore{}
+	case storeName != Vault && keyringAvailable():
+		Instance = &KeyringStore{}
+	default:
+		Instance = &VaultStore{Path: vaultPath}
+	}
+	return Instance
+}
+
+func Get(service, user string) (string, error) {
+	return current().Get(service, user)
+}
+
+func Set(service, user, password string) error {
+	return current().Set(service, user, password)
+}
+
+func Delete(service, user string) error {
+	return current().Delete(service, user)
+}
+
+// keyringService prefixes the services, so that the entries of st are easy to find in the keyring.
+const keyringService = "sio-tool"
+
+// KeyringStore keeps the passwords in the keyring of the system (Secret Service on Linux,
+// Keychain on macOS and Credential Manager on Windows).
+type KeyringStore struct{}
+
+func keyringAvailable() bool {
+	_, err := keyring.Get(keyringService, "")
+	return err == nil || errors.Is(err, keyring.ErrNotFound)
+}
+
+func (s *KeyringStore) Name() string {
+	return "keyring"
+}
+
+func (s *KeyringStore) Get(service, user string) (string, error) {
+	password, err := keyring.Get(keyringService+":"+service, user)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", errors.New(ErrorNotFound)
+	}
+	return password, err
+}
+
+func (s *KeyringStore) Set(service, user, password string) error {
+	return keyring.Set(keyringService+":"+service, user, password)
+}
+
+func (s *KeyringStore) Delete(service, user string) error {
+	err := keyring.Delete(keyringService+":"+service, user)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// WriteFile writes a file only the user can read, e.g. a session file with cookies.
+// Unlike os.WriteFile it also fixes the permissions of an existing file.
+func WriteFile(path string, data []byte) (err error) {
+	if err = os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return
+	}
+	if err = os.WriteFile(path, data, 0600); err != nil {
+		return
+	}
+	return os.Chmod(path, 0600)
+}
+
+// Migrate moves a password kept by an older version of st in a session file to the store.
+// decrypt reads the old password, which is removed from the session by clear.
+func Migrate(service, user, old string, decrypt func(user, old string) (string, error), clear func()) {
+	if old == "" {
+		return
+	}
+	password, err := decrypt(user, old)
+	if err == nil {
+		err = Set(service, user, password)
+	}
+	if err != nil {
+		color.Red("Cannot move the password of %v to the %v: %v", service, current().Name(), err.Error())
+		return
+	}
+	color.Green("Moved the password of %v to the %v", service, current().Name())
+	clear()
+}
@@ -0,0 +1,64 @@
+package credentials
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVault(t *testing.T) {
+	t.Setenv(PassphraseEnv, "correct horse")
+	path := filepath.Join(t.TempDir(), "vault")
+	vault := &VaultStore{Path: path}
+
+	if _, err := vault.Get("codeforces", "tourist"); err == nil || err.Error() != ErrorNotFound {
+		t.Errorf("Expect %v, but found %v.", ErrorNotFound, err)
+	}
+	if err := vault.Set("codeforces", "tourist", "secret"); err != nil {
+		t.Fatal(err)
+	}
+	if err := vault.Set("sio-staszic", "tourist", "other"); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expect the vault readable only by the user, but found %v.", info.Mode().Perm())
+	}
+
+	// a new run derives the key from the passphrase again
+	if password, err := (&VaultStore{Path: path}).Get("codeforces", "tourist"); err != nil || password != "secret" {
+		t.Errorf("Expect the saved password, but found %q (%v).", password, err)
+	}
+	if err = vault.Delete("codeforces", "tourist"); err != nil {
+		t.Fatal(err)
+	}
+	if password, err := (&VaultStore{Path: path}).Get("sio-staszic", "tourist"); err != nil || password != "other" {
+		t.Errorf("Expect the other password to stay, but found %q (%v).", password, err)
+	}
+
+	t.Setenv(PassphraseEnv, "wrong")
+	if _, err = (&VaultStore{Path: path}).Get("sio-staszic", "tourist"); err == nil || err.Error() != ErrorWrongPassphrase {
+		t.Errorf("Expect %v, but found %v.", ErrorWrongPassphrase, err)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	t.Setenv(PassphraseEnv, "test")
+	Instance = &VaultStore{Path: filepath.Join(t.TempDir(), "vault")}
+	defer func() { Instance = nil }()
+
+	old := "encrypted"
+	decrypt := func(user, password string) (string, error) {
+		return user + ":" + password, nil
+	}
+	Migrate("szkopul", "user", old, decrypt, func() { old = "" })
+	if old != "" {
+		t.Errorf("Expect the old password to be removed.")
+	}
+	if password, err := Get("szkopul", "user"); err != nil || password != "user:encrypted" {
+		t.Errorf("Expect the migrated password, but found %q (%v).", password, err)
+	}
+}
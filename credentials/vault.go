@@ -0,0 +1,182 @@
+package credentials
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+	"golang.org/x/crypto/argon2"
+)
+
+const ErrorWrongPassphrase = "wrong passphrase of the vault"
+
+// PassphraseEnv can hold the passphrase of the vault, e.g. for scripts.
+const PassphraseEnv = "ST_VAULT_PASSPHRASE"
+
+// VaultStore keeps the passwords in a file encrypted with AES-GCM, using a key derived
+// with Argon2id from a passphrase, which is asked for once per run.
+type VaultStore struct {
+	Path string
+	key  []byte
+	salt []byte
+}
+
+// vaultFile is the content of the vault file, the parameters of Argon2id are saved
+// so that they can be changed without breaking older vaults.
+type vaultFile struct {
+	Salt    []byte `json:"salt"`
+	Time    uint32 `json:"time"`
+	Memory  uint32 `json:"memory"`
+	Threads uint8  `json:"threads"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"`
+}
+
+var defaultVault = vaultFile{Time: 1, Memory: 64 * 1024, Threads: 4}
+
+func (s *VaultStore) Name() string {
+	return "vault " + s.Path
+}
+
+func entry(service, user string) string {
+	return service + "/" + user
+}
+
+func (s *VaultStore) Get(service, user string) (string, error) {
+	passwords, err := s.load()
+	if err != nil {
+		return "", err
+	}
+	password, ok := passwords[entry(service, user)]
+	if !ok {
+		return "", errors.New(ErrorNotFound)
+	}
+	return password, nil
+}
+
+func (s *VaultStore) Set(service, user, password string) error {
+	passwords, err := s.load()
+	if err != nil {
+		return err
+	}
+	passwords[entry(service, user)] = password
+	return s.save(passwords)
+}
+
+func (s *VaultStore) Delete(service, user string) error {
+	passwords, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := passwords[entry(service, user)]; !ok {
+		return nil
+	}
+	delete(passwords, entry(service, user))
+	return s.save(passwords)
+}
+
+func (s *VaultStore) load() (map[string]string, error) {
+	passwords := map[string]string{}
+	data, err := os.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return passwords, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var file vaultFile
+	if err = json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	if s.key == nil {
+		passphrase, err := askPassphrase(false)
+		if err != nil {
+			return nil, err
+		}
+		s.salt = file.Salt
+		s.key = argon2.IDKey([]byte(passphrase), file.Salt, file.Time, file.Memory, file.Threads, 32)
+	}
+	gcm, err := newGCM(s.key)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := gcm.Open(nil, file.Nonce, file.Data, nil)
+	if err != nil {
+		s.key = nil
+		return nil, errors.New(ErrorWrongPassphrase)
+	}
+	return passwords, json.Unmarshal(plain, &passwords)
+}
+
+func (s *VaultStore) save(passwords map[string]string) (err error) {
+	file := defaultVault
+	if s.key == nil {
+		passphrase, err := askPassphrase(true)
+		if err != nil {
+			return err
+		}
+		s.salt = make([]byte, 16)
+		if _, err = io.ReadFull(rand.Reader, s.salt); err != nil {
+			return err
+		}
+		s.key = argon2.IDKey([]byte(passphrase), s.salt, file.Time, file.Memory, file.Threads, 32)
+	} else if data, err := os.ReadFile(s.Path); err == nil {
+		// keep the parameters the key was derived with
+		json.Unmarshal(data, &file)
+	}
+	file.Salt = s.salt
+	plain, err := json.Marshal(passwords)
+	if err != nil {
+		return
+	}
+	gcm, err := newGCM(s.key)
+	if err != nil {
+		return
+	}
+	file.Nonce = make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, file.Nonce); err != nil {
+		return
+	}
+	file.Data = gcm.Seal(nil, file.Nonce, plain, nil)
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return
+	}
+	return WriteFile(s.Path, data)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func askPassphrase(create bool) (passphrase string, err error) {
+	if passphrase = os.Getenv(PassphraseEnv); passphrase != "" {
+		return
+	}
+	if !create {
+		err = survey.AskOne(&survey.Password{Message: `vault passphrase:`}, &passphrase, survey.WithValidator(survey.Required))
+		return
+	}
+	color.Cyan("Choose a passphrase of the vault with your passwords, st asks for it when it needs to log in")
+	if err = survey.AskOne(&survey.Password{Message: `new vault passphrase:`}, &passphrase, survey.WithValidator(survey.Required)); err != nil {
+		return
+	}
+	repeated := ""
+	if err = survey.AskOne(&survey.Password{Message: `repeat the passphrase:`}, &repeated); err != nil {
+		return
+	}
+	if repeated != passphrase {
+		err = errors.New("the passphrases don't match")
+	}
+	return
+}
@@ -4,8 +4,11 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 )
 
+const TimeLayout = "2006-01-02 15:04:05"
+
 type Task struct {
 	ID             int
 	Name           string
@@ -28,6 +31,7 @@ func createTableIfNotExist(db *sql.DB) error {
 			link TEXT,
 			contest_id TEXT,
 			contest_stage_id TEXT,
+			created_at TEXT,
 			UNIQUE(name,source,path,shortname,link,contest_id,contest_stage_id)
 		);
   `
@@ -38,12 +42,25 @@ func createTableIfNotExist(db *sql.DB) error {
 	return nil
 }
 
+// migrateTasks adds the columns which were added to the tasks table after it was first released.
+func migrateTasks(db *sql.DB) error {
+	_, err := db.Exec(`ALTER TABLE tasks ADD COLUMN created_at TEXT`)
+	if err != nil && !strings.Contains(err.Error(), `duplicate column name`) {
+		return fmt.Errorf("failed to migrate table: %v", err)
+	}
+	return nil
+}
+
+func isMissingColumn(err error) bool {
+	return strings.Contains(err.Error(), `no such column`) || strings.Contains(err.Error(), `has no column named`)
+}
+
 func AddTask(db *sql.DB, t Task) error {
 	sqlStatement := `
-        INSERT INTO tasks(name, source, path, shortname, link, contest_id, contest_stage_id)
-        VALUES (?, ?, ?, ?, ?, ?, ?)
+        INSERT INTO tasks(name, source, path, shortname, link, contest_id, contest_stage_id, created_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?)
     `
-	_, err := db.Exec(sqlStatement, t.Name, t.Source, t.Path, t.ShortName, t.Link, t.ContestID, t.ContestStageID)
+	_, err := db.Exec(sqlStatement, t.Name, t.Source, t.Path, t.ShortName, t.Link, t.ContestID, t.ContestStageID, time.Now().Format(TimeLayout))
 	if err != nil {
 		if strings.Contains(err.Error(), `no such table: tasks`) {
 			err = createTableIfNotExist(db)
@@ -51,6 +68,12 @@ func AddTask(db *sql.DB, t Task) error {
 				return err
 			}
 			return AddTask(db, t)
+		} else if isMissingColumn(err) {
+			err = migrateTasks(db)
+			if err != nil {
+				return err
+			}
+			return AddTask(db, t)
 		} else if strings.Contains(err.Error(), `constraint failed: UNIQUE constraint failed`) {
 			return fmt.Errorf("this problem already exists in database")
 		}
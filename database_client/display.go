@@ -8,6 +8,7 @@ import (
 
 	"github.com/Arapak/sio-tool/util"
 
+	"github.com/fatih/color"
 	"github.com/k0kubun/go-ansi"
 	"github.com/olekukonko/tablewriter"
 )
@@ -31,11 +32,23 @@ func (t *Task) Display() {
 	_, _ = ansi.Printf("   stage: %v\n", t.ContestStageID)
 }
 
-func Display(tasks []Task) {
+func parsePoints(points int) string {
+	if points == NoPoints {
+		return ""
+	} else if points >= 100 {
+		return util.GreenString(fmt.Sprint(points))
+	} else if points > 0 {
+		return color.New(color.FgCyan).Sprint(points)
+	}
+	return util.RedString(fmt.Sprint(points))
+}
+
+// Display prints the tasks with the best score from best (which can be nil).
+func Display(tasks []Task, best map[int]int) {
 	var buf bytes.Buffer
 	output := io.Writer(&buf)
 	table := tablewriter.NewWriter(output)
-	table.SetHeader([]string{"#", "name", "source", "alias", "contest", "stage"})
+	table.SetHeader([]string{"#", "name", "source", "alias", "contest", "stage", "best"})
 	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
 	table.SetAlignment(tablewriter.ALIGN_CENTER)
 	table.SetCenterSeparator("|")
@@ -48,6 +61,41 @@ func Display(tasks []Task) {
 			util.LimitNumOfChars(t.ShortName, maxAliasLength),
 			t.ContestID,
 			util.LimitNumOfChars(t.ContestStageID, maxStageLength),
+			bestPoints(best, t.ID),
+		})
+	}
+	table.Render()
+	scanner := bufio.NewScanner(io.Reader(&buf))
+	for scanner.Scan() {
+		line := scanner.Text()
+		_, _ = ansi.Println(line)
+	}
+}
+
+func bestPoints(best map[int]int, taskID int) string {
+	if points, ok := best[taskID]; ok {
+		return parsePoints(points)
+	}
+	return ""
+}
+
+func DisplaySubmissions(submissions []Submission) {
+	var buf bytes.Buffer
+	output := io.Writer(&buf)
+	table := tablewriter.NewWriter(output)
+	table.SetHeader([]string{"judge", "#", "when", "lang", "status", "points"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetAlignment(tablewriter.ALIGN_CENTER)
+	table.SetCenterSeparator("|")
+	table.SetAutoWrapText(false)
+	for _, s := range submissions {
+		table.Append([]string{
+			s.Judge,
+			s.SubmissionID,
+			s.When,
+			s.Language,
+			s.Status,
+			parsePoints(s.Points),
 		})
 	}
 	table.Render()
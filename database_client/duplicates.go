@@ -0,0 +1,63 @@
+package database_client
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+)
+
+const ErrorSubmitCancelled = "submission cancelled"
+const ErrorNoSubmissionsLeft = "no submissions left for this problem"
+
+// UnknownLimit is passed as submissionsLeft when the judge doesn't limit the number of submissions.
+const UnknownLimit = -1
+
+// fewSubmissionsLeft is the number of remaining submissions at which st asks for a confirmation.
+const fewSubmissionsLeft = 3
+
+func confirm(message string) error {
+	submit := false
+	if err := survey.AskOne(&survey.Confirm{Message: message, Default: false}, &submit); err != nil {
+		return err
+	}
+	if !submit {
+		return errors.New(ErrorSubmitCancelled)
+	}
+	return nil
+}
+
+// CheckSubmission warns about a source that was already submitted to the same problem and about
+// the last few remaining submissions, and asks whether to submit anyway.
+// Only s.Judge, s.ContestID and s.ShortName are used to find previous submissions.
+func CheckSubmission(db *sql.DB, s Submission, sourcePath string, submissionsLeft int) error {
+	if submissionsLeft != UnknownLimit {
+		if submissionsLeft == 0 {
+			return errors.New(ErrorNoSubmissionsLeft)
+		}
+		fmt.Printf("Submissions left: %v\n", submissionsLeft)
+	}
+	if db != nil {
+		source, err := os.ReadFile(sourcePath)
+		if err != nil {
+			return err
+		}
+		s.SourceHash = SourceHash(source)
+		duplicates, err := FindDuplicates(db, s)
+		if err != nil {
+			return err
+		}
+		if len(duplicates) > 0 {
+			previous := duplicates[len(duplicates)-1]
+			color.Yellow("This code was already submitted as #%v (%v, %v)", previous.SubmissionID, previous.When, previous.Status)
+			return confirm("Submit the same code again?")
+		}
+	}
+	if submissionsLeft != UnknownLimit && submissionsLeft <= fewSubmissionsLeft {
+		return confirm(fmt.Sprintf("Only %v submissions left. Submit?", submissionsLeft))
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package database_client
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// TaskSummary is a task together with a summary of its submissions.
+type TaskSummary struct {
+	Task
+	CreatedAt      string
+	Submissions    int
+	BestPoints     int
+	FirstSubmitted string
+	FirstFullScore string
+}
+
+func TaskSummaries(db *sql.DB) ([]TaskSummary, error) {
+	var summaries []TaskSummary
+	sqlStatement := `
+	    SELECT t.id, t.name, t.source, t.path, COALESCE(t.shortname, ''), COALESCE(t.contest_id, ''), COALESCE(t.contest_stage_id, ''),
+	        COALESCE(t.created_at, ''), COUNT(s.id), COALESCE(MAX(s.points), -1),
+	        COALESCE(MIN(NULLIF(s.submitted_at, '')), ''),
+	        COALESCE(MIN(CASE WHEN s.points >= 100 THEN NULLIF(s.submitted_at, '') END), '')
+	    FROM tasks t
+	    LEFT JOIN submissions s ON s.task_id = t.id
+	    GROUP BY t.id
+	`
+	rows, err := db.Query(sqlStatement)
+	if err != nil {
+		if strings.Contains(err.Error(), `no such table`) {
+			if err = createSubmissionsTableIfNotExist(db); err != nil {
+				return nil, err
+			}
+			return TaskSummaries(db)
+		} else if isMissingColumn(err) {
+			if err = migrateTasks(db); err != nil {
+				return nil, err
+			}
+			return TaskSummaries(db)
+		}
+		return nil, fmt.Errorf("failed to summarize tasks: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var s TaskSummary
+		if err := rows.Scan(&s.ID, &s.Name, &s.Source, &s.Path, &s.ShortName, &s.ContestID, &s.ContestStageID,
+			&s.CreatedAt, &s.Submissions, &s.BestPoints, &s.FirstSubmitted, &s.FirstFullScore); err != nil {
+			return nil, fmt.Errorf("failed to scan task row: %v", err)
+		}
+		summaries = append(summaries, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read task rows: %v", err)
+	}
+	return summaries, nil
+}
+
+// SubmissionDays returns the distinct days (YYYY-MM-DD) on which any submission was made.
+func SubmissionDays(db *sql.DB) ([]string, error) {
+	var days []string
+	sqlStatement := `
+	    SELECT DISTINCT substr(submitted_at, 1, 10)
+	    FROM submissions
+	    WHERE submitted_at != ''
+	    ORDER BY 1
+	`
+	rows, err := db.Query(sqlStatement)
+	if err != nil {
+		if strings.Contains(err.Error(), `no such table: submissions`) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find submission days: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var day string
+		if err := rows.Scan(&day); err != nil {
+			return nil, fmt.Errorf("failed to scan submission row: %v", err)
+		}
+		days = append(days, day)
+	}
+	return days, rows.Err()
+}
@@ -0,0 +1,243 @@
+package database_client
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// NoPoints marks submissions whose score is unknown (not judged yet or not revealed).
+const NoPoints = -1
+
+type Submission struct {
+	ID           int
+	TaskID       int
+	Judge        string
+	SubmissionID string
+	ContestID    string
+	ShortName    string
+	When         string
+	Language     string
+	Status       string
+	Points       int
+	SourceHash   string
+	FilePath     string
+}
+
+func SourceHash(source []byte) string {
+	hash := sha256.Sum256(source)
+	return hex.EncodeToString(hash[:])
+}
+
+func createSubmissionsTableIfNotExist(db *sql.DB) error {
+	if err := createTableIfNotExist(db); err != nil {
+		return err
+	}
+	sqlStatement := `
+		CREATE TABLE IF NOT EXISTS submissions (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			task_id INTEGER REFERENCES tasks(id) ON DELETE SET NULL,
+			judge TEXT NOT NULL,
+			submission_id TEXT NOT NULL,
+			contest_id TEXT,
+			shortname TEXT,
+			submitted_at TEXT,
+			language TEXT,
+			status TEXT,
+			points INTEGER,
+			source_hash TEXT,
+			file_path TEXT,
+			UNIQUE(judge,submission_id)
+		);
+  `
+	_, err := db.Exec(sqlStatement)
+	if err != nil {
+		return fmt.Errorf("failed to create table: %v", err)
+	}
+	return nil
+}
+
+func nullIfZero(taskID int) interface{} {
+	if taskID == 0 {
+		return nil
+	}
+	return taskID
+}
+
+// AddSubmission saves a submission or updates the one already saved with the same judge and ID.
+// Empty fields of the new submission don't overwrite the saved values.
+func AddSubmission(db *sql.DB, s Submission) error {
+	sqlStatement := `
+        INSERT INTO submissions(task_id, judge, submission_id, contest_id, shortname, submitted_at, language, status, points, source_hash, file_path)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+        ON CONFLICT(judge, submission_id) DO UPDATE SET
+            task_id = COALESCE(excluded.task_id, task_id),
+            contest_id = COALESCE(NULLIF(excluded.contest_id, ''), contest_id),
+            shortname = COALESCE(NULLIF(excluded.shortname, ''), shortname),
+            submitted_at = COALESCE(NULLIF(excluded.submitted_at, ''), submitted_at),
+            language = COALESCE(NULLIF(excluded.language, ''), language),
+            status = COALESCE(NULLIF(excluded.status, ''), status),
+            points = excluded.points,
+            source_hash = COALESCE(NULLIF(excluded.source_hash, ''), source_hash),
+            file_path = COALESCE(NULLIF(excluded.file_path, ''), file_path)
+    `
+	_, err := db.Exec(sqlStatement, nullIfZero(s.TaskID), s.Judge, s.SubmissionID, s.ContestID, s.ShortName, s.When, s.Language, s.Status, s.Points, s.SourceHash, s.FilePath)
+	if err != nil {
+		if strings.Contains(err.Error(), `no such table: submissions`) {
+			err = createSubmissionsTableIfNotExist(db)
+			if err != nil {
+				return err
+			}
+			return AddSubmission(db, s)
+		}
+		return fmt.Errorf("failed to add submission to database: %v", err)
+	}
+	return nil
+}
+
+// FindTaskID returns the ID of the only task matching the given source, contest, stage and alias, or 0.
+func FindTaskID(db *sql.DB, t Task) (int, error) {
+	sqlStatement := `
+	    SELECT id
+	    FROM tasks
+	    WHERE LOWER(source) = LOWER(?)
+	    AND (? = '' OR LOWER(contest_id) = LOWER(?))
+	    AND (? = '' OR LOWER(contest_stage_id) = LOWER(?))
+	    AND LOWER(shortname) = LOWER(?)
+	    LIMIT 2
+	`
+	rows, err := db.Query(sqlStatement, t.Source, t.ContestID, t.ContestID, t.ContestStageID, t.ContestStageID, t.ShortName)
+	if err != nil {
+		if strings.Contains(err.Error(), `no such table: tasks`) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to find task in database: %v", err)
+	}
+	defer rows.Close()
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return 0, fmt.Errorf("failed to scan task row: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) != 1 {
+		return 0, rows.Err()
+	}
+	return ids[0], rows.Err()
+}
+
+func FindSubmissions(db *sql.DB, taskID int) ([]Submission, error) {
+	sqlStatement := `
+	    SELECT id, COALESCE(task_id, 0), judge, submission_id, COALESCE(contest_id, ''), COALESCE(shortname, ''),
+	        COALESCE(submitted_at, ''), COALESCE(language, ''), COALESCE(status, ''), COALESCE(points, -1),
+	        COALESCE(source_hash, ''), COALESCE(file_path, '')
+	    FROM submissions
+	    WHERE task_id = ?
+	    ORDER BY submitted_at
+	`
+	rows, err := db.Query(sqlStatement, taskID)
+	if err != nil {
+		if strings.Contains(err.Error(), `no such table: submissions`) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find submissions in database: %v", err)
+	}
+	defer rows.Close()
+	return scanSubmissions(rows)
+}
+
+func scanSubmissions(rows *sql.Rows) (submissions []Submission, err error) {
+	for rows.Next() {
+		var s Submission
+		if err := rows.Scan(&s.ID, &s.TaskID, &s.Judge, &s.SubmissionID, &s.ContestID, &s.ShortName, &s.When, &s.Language, &s.Status, &s.Points, &s.SourceHash, &s.FilePath); err != nil {
+			return nil, fmt.Errorf("failed to scan submission row: %v", err)
+		}
+		submissions = append(submissions, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read submission rows: %v", err)
+	}
+	return submissions, nil
+}
+
+// FindDuplicates returns the submissions to the same judge with the same source hash as s.
+// The contest and alias of s are compared only when they are known on both sides.
+func FindDuplicates(db *sql.DB, s Submission) ([]Submission, error) {
+	sqlStatement := `
+	    SELECT id, COALESCE(task_id, 0), judge, submission_id, COALESCE(contest_id, ''), COALESCE(shortname, ''),
+	        COALESCE(submitted_at, ''), COALESCE(language, ''), COALESCE(status, ''), COALESCE(points, -1),
+	        COALESCE(source_hash, ''), COALESCE(file_path, '')
+	    FROM submissions
+	    WHERE judge = ? AND source_hash = ?
+	    AND (? = '' OR COALESCE(contest_id, '') = '' OR LOWER(contest_id) = LOWER(?))
+	    AND (? = '' OR COALESCE(shortname, '') = '' OR LOWER(shortname) = LOWER(?))
+	    ORDER BY submitted_at
+	`
+	rows, err := db.Query(sqlStatement, s.Judge, s.SourceHash, s.ContestID, s.ContestID, s.ShortName, s.ShortName)
+	if err != nil {
+		if strings.Contains(err.Error(), `no such table: submissions`) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find submissions in database: %v", err)
+	}
+	defer rows.Close()
+	return scanSubmissions(rows)
+}
+
+// BestPoints returns the best score of every task with at least one scored submission.
+func BestPoints(db *sql.DB) (map[int]int, error) {
+	best := make(map[int]int)
+	sqlStatement := `
+	    SELECT task_id, MAX(points)
+	    FROM submissions
+	    WHERE task_id IS NOT NULL AND points >= 0
+	    GROUP BY task_id
+	`
+	rows, err := db.Query(sqlStatement)
+	if err != nil {
+		if strings.Contains(err.Error(), `no such table: submissions`) {
+			return best, nil
+		}
+		return nil, fmt.Errorf("failed to find best scores in database: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var taskID, points int
+		if err := rows.Scan(&taskID, &points); err != nil {
+			return nil, fmt.Errorf("failed to scan submission row: %v", err)
+		}
+		best[taskID] = points
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read submission rows: %v", err)
+	}
+	return best, nil
+}
+
+// LinkSubmissions links saved submissions which have no task yet to the matching tasks.
+// A submission is only linked when exactly one task matches it.
+func LinkSubmissions(db *sql.DB, judge, source string) error {
+	sqlStatement := `
+	    WITH matches AS (
+	        SELECT submissions.id AS submission, MIN(tasks.id) AS task, COUNT(*) AS count
+	        FROM submissions JOIN tasks
+	        ON LOWER(tasks.source) = LOWER(?)
+	        AND (submissions.contest_id = '' OR LOWER(tasks.contest_id) = LOWER(submissions.contest_id))
+	        AND LOWER(tasks.shortname) = LOWER(submissions.shortname)
+	        WHERE submissions.task_id IS NULL AND submissions.judge = ?
+	        GROUP BY submissions.id
+	    )
+	    UPDATE submissions
+	    SET task_id = (SELECT task FROM matches WHERE submission = submissions.id)
+	    WHERE id IN (SELECT submission FROM matches WHERE count = 1)
+	`
+	_, err := db.Exec(sqlStatement, source, judge)
+	if err != nil && !strings.Contains(err.Error(), `no such table`) {
+		return fmt.Errorf("failed to link submissions to tasks: %v", err)
+	}
+	return nil
+}
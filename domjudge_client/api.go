@@ -0,0 +1,177 @@
+package domjudge_client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/Arapak/sio-tool/util"
+)
+
+const ErrorNoHost = "you have to set the DOMjudge host by `st config`"
+const ErrorNotFound = "not found"
+
+type apiUser struct {
+	ID       json.Number `json:"id"`
+	Username string      `json:"username"`
+	Name     string      `json:"name"`
+	TeamID   json.Number `json:"team_id"`
+}
+
+type apiContest struct {
+	ID        string `json:"id"`
+	ShortName string `json:"shortname"`
+	Name      string `json:"name"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+}
+
+type apiProblem struct {
+	ID        string  `json:"id"`
+	Label     string  `json:"label"`
+	Name      string  `json:"name"`
+	Ordinal   int     `json:"ordinal"`
+	TimeLimit float64 `json:"time_limit"`
+}
+
+type apiLanguage struct {
+	ID                 string   `json:"id"`
+	Name               string   `json:"name"`
+	Extensions         []string `json:"extensions"`
+	EntryPointRequired bool     `json:"entry_point_required"`
+}
+
+type apiSubmission struct {
+	ID         string `json:"id"`
+	ProblemID  string `json:"problem_id"`
+	LanguageID string `json:"language_id"`
+	TeamID     string `json:"team_id"`
+	Time       string `json:"time"`
+}
+
+type apiJudgement struct {
+	ID              string  `json:"id"`
+	SubmissionID    string  `json:"submission_id"`
+	JudgementTypeID *string `json:"judgement_type_id"`
+	MaxRunTime      float64 `json:"max_run_time"`
+	Valid           *bool   `json:"valid"`
+}
+
+func (c *DomjudgeClient) apiURL(path string) (string, error) {
+	if c.host == "" {
+		return "", errors.New(ErrorNoHost)
+	}
+	return c.host + "/api/v4" + path, nil
+}
+
+// do sends the request with the credentials of the user and returns the body of a successful response.
+func (c *DomjudgeClient) do(req *http.Request) (body []byte, err error) {
+	if c.Username != "" {
+		password, err := c.DecryptPassword()
+		if err != nil {
+			return nil, err
+		}
+		req.SetBasicAuth(c.Username, password)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	body, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return
+	}
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return nil, errors.New(ErrorNotLogged)
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, errors.New(ErrorNotFound)
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return nil, &util.RateLimitError{}
+	case resp.StatusCode >= 300:
+		var apiError struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &apiError) == nil && apiError.Message != "" {
+			return nil, errors.New(apiError.Message)
+		}
+		return nil, fmt.Errorf("%v: %v", req.URL.Path, resp.Status)
+	}
+	return
+}
+
+func (c *DomjudgeClient) getBody(path string) ([]byte, error) {
+	URL, err := c.apiURL(path)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("GET", URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+// get decodes the JSON returned by the API for path into v.
+func (c *DomjudgeClient) get(path string, v interface{}) error {
+	body, err := c.getBody(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+func (c *DomjudgeClient) post(path, contentType string, data io.Reader, v interface{}) error {
+	URL, err := c.apiURL(path)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", URL, data)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	body, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+// contest returns the ID of the contest of info, or of the only active contest when info has no contest.
+func (c *DomjudgeClient) contest(info Info) (string, error) {
+	if info.ContestID != "" {
+		return info.ContestID, nil
+	}
+	var contests []apiContest
+	if err := c.get("/contests?onlyActive=true", &contests); err != nil {
+		return "", err
+	}
+	if len(contests) != 1 {
+		var names []string
+		for _, contest := range contests {
+			names = append(names, contest.ID)
+		}
+		return "", fmt.Errorf("%v, the active contests are: %v", ErrorNeedContestID, strings.Join(names, ", "))
+	}
+	return contests[0].ID, nil
+}
+
+// problems returns the problems of the contest ordered as on the scoreboard.
+func (c *DomjudgeClient) problems(contestID string) (problems []apiProblem, err error) {
+	err = c.get(fmt.Sprintf("/contests/%v/problems", contestID), &problems)
+	return
+}
+
+func findProblem(problems []apiProblem, label string) (apiProblem, bool) {
+	for _, problem := range problems {
+		if strings.EqualFold(problem.Label, label) {
+			return problem, true
+		}
+	}
+	return apiProblem{}, false
+}
@@ -0,0 +1,82 @@
+package domjudge_client
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/Arapak/sio-tool/credentials"
+	"github.com/Arapak/sio-tool/site"
+	"github.com/Arapak/sio-tool/util"
+
+	"github.com/fatih/color"
+)
+
+// DomjudgeClient uses the REST API of a DOMjudge host. The API uses basic authentication,
+// so there are no cookies to keep.
+type DomjudgeClient struct {
+	Username       string `json:"handle"`
+	TeamID         string `json:"team_id"`
+	LastSubmission *Info  `json:"last_submission"`
+	host           string
+	path           string
+	client         *http.Client
+}
+
+var Instance *DomjudgeClient
+
+func Init(path, host, proxy string) {
+	c := &DomjudgeClient{LastSubmission: nil, path: path, host: host, client: nil}
+	if err := c.load(); err != nil {
+		color.Red(err.Error())
+		color.Green("Create a new session in %v", path)
+	}
+	Proxy := http.ProxyFromEnvironment
+	if len(proxy) > 0 {
+		proxyURL, err := url.Parse(proxy)
+		if err != nil {
+			color.Red(err.Error())
+			color.Green("Use default proxy from environment")
+		} else {
+			Proxy = http.ProxyURL(proxyURL)
+		}
+	}
+	c.client = &http.Client{Transport: util.NewTransport(Proxy)}
+	if err := c.save(); err != nil {
+		color.Red(err.Error())
+	}
+	Instance = c
+	site.Register(c.Site())
+}
+
+func (c *DomjudgeClient) load() (err error) {
+	file, err := os.Open(c.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return
+	}
+	defer file.Close()
+
+	bytes, err := io.ReadAll(file)
+
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(bytes, c)
+}
+
+func (c *DomjudgeClient) save() (err error) {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err == nil {
+		err = credentials.WriteFile(c.path, data)
+	}
+	if err != nil {
+		color.Red("Cannot save session to %v\n%v", c.path, err.Error())
+	}
+	return
+}
@@ -0,0 +1,224 @@
+package domjudge_client
+
+import (
+	"archive/zip"
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Arapak/sio-tool/credentials"
+
+	_ "modernc.org/sqlite"
+)
+
+func samplesZip(t *testing.T) []byte {
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for name, content := range map[string]string{"1.in": "1 2\n", "1.ans": "3\n", "2.in": "2 2\n", "2.ans": "4\n"} {
+		file, err := writer.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = file.Write([]byte(content))
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// standIn serves the part of the DOMjudge API the client uses, for the single active contest "demo".
+func standIn(t *testing.T) *httptest.Server {
+	zipped := samplesZip(t)
+	judged := false
+	responses := map[string]interface{}{
+		"/api/v4/user":                   map[string]interface{}{"id": 2, "username": "team1", "team_id": "1"},
+		"/api/v4/contests":               []map[string]string{{"id": "demo", "shortname": "demo"}},
+		"/api/v4/contests/demo/problems": []map[string]interface{}{{"id": "hello", "label": "A", "name": "Hello", "time_limit": 1}, {"id": "sum", "label": "B", "name": "Sum", "time_limit": 2}},
+		"/api/v4/contests/demo/languages": []map[string]interface{}{
+			{"id": "cpp", "name": "C++", "extensions": []string{"cpp", "cc"}},
+			{"id": "java", "name": "Java", "extensions": []string{"java"}, "entry_point_required": true},
+		},
+		"/api/v4/contests/demo/teams": []map[string]string{{"id": "1", "name": "team1", "display_name": "Team One"}, {"id": "2", "name": "team2"}},
+		"/api/v4/contests/demo/scoreboard": map[string]interface{}{"rows": []map[string]interface{}{
+			{"rank": 1, "team_id": "2", "score": map[string]int{"num_solved": 2, "total_time": 50}, "problems": []map[string]interface{}{
+				{"label": "A", "num_judged": 1, "solved": true, "time": 10}, {"label": "B", "num_judged": 2, "solved": true, "time": 20},
+			}},
+			{"rank": 2, "team_id": "1", "score": map[string]int{"num_solved": 1, "total_time": 30}, "problems": []map[string]interface{}{
+				{"label": "B", "num_judged": 1, "solved": true, "time": 30},
+			}},
+		}},
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "team1" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/v4/contests/demo/problems/sum/samples.zip":
+			_, _ = w.Write(zipped)
+			return
+		case "/api/v4/contests/demo/submissions":
+			if r.Method == "POST" {
+				if r.FormValue("problem") != "sum" || r.FormValue("language") != "cpp" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				judged = true
+				_ = json.NewEncoder(w).Encode(map[string]string{"id": "7"})
+				return
+			}
+			submissions := []map[string]string{{"id": "3", "problem_id": "hello", "language_id": "cpp", "team_id": "1"}}
+			if judged {
+				submissions = append(submissions, map[string]string{"id": "7", "problem_id": "sum", "language_id": "cpp", "team_id": "1"})
+			}
+			_ = json.NewEncoder(w).Encode(submissions)
+			return
+		case "/api/v4/contests/demo/judgements":
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"id": "1", "submission_id": "3", "judgement_type_id": "WA"},
+				{"id": "2", "submission_id": "7", "judgement_type_id": "AC", "max_run_time": 0.05},
+			})
+			return
+		}
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+}
+
+func testClient(t *testing.T, host string) *DomjudgeClient {
+	t.Setenv(credentials.PassphraseEnv, "test")
+	credentials.Instance = &credentials.VaultStore{Path: filepath.Join(t.TempDir(), "vault")}
+	if err := credentials.Set("domjudge", "team1", "secret"); err != nil {
+		t.Fatal(err)
+	}
+	return &DomjudgeClient{
+		Username: "team1",
+		host:     host,
+		path:     filepath.Join(t.TempDir(), "domjudge_session"),
+		client:   &http.Client{},
+	}
+}
+
+func TestDomjudgeClient(t *testing.T) {
+	server := standIn(t)
+	defer server.Close()
+	c := testClient(t, server.URL)
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "tasks.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err = c.Login(); err != nil {
+		t.Fatal(err)
+	}
+	if c.TeamID != "1" {
+		t.Errorf("Expect team 1, but found %v.", c.TeamID)
+	}
+
+	root := t.TempDir()
+	problems, paths, err := c.Parse(Info{RootPath: root}, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 2 || problems[0] != "a" || problems[1] != "b" {
+		t.Errorf("Expect problems [a b], but found %v.", problems)
+	}
+	if paths[1] != filepath.Join(root, "demo", "b") {
+		t.Errorf("Expect %v, but found %v.", filepath.Join(root, "demo", "b"), paths[1])
+	}
+	if data, _ := os.ReadFile(filepath.Join(paths[1], "out2.txt")); string(data) != "4\n" {
+		t.Errorf("Expect 4, but found %q.", data)
+	}
+	if _, err = os.Stat(filepath.Join(paths[0], "in1.txt")); !os.IsNotExist(err) {
+		t.Errorf("Expect no samples of a problem without samples.zip.")
+	}
+
+	source := filepath.Join(paths[1], "b.cpp")
+	if err = os.WriteFile(source, []byte("int main() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Submit(Info{ProblemID: "b", RootPath: root}, source, db); err != nil {
+		t.Fatal(err)
+	}
+	if c.LastSubmission == nil || c.LastSubmission.SubmissionID != "7" {
+		t.Errorf("Expect the last submission 7, but found %v.", c.LastSubmission)
+	}
+
+	statis, _, err := c.Statis(Info{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if statis[0].State != "rejected" || statis[1].State != "accepted" {
+		t.Errorf("Expect rejected A and accepted B, but found %v.", statis)
+	}
+
+	scoreboard, _, err := c.GetScoreboard(Info{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scoreboard.Rows) != 2 || !scoreboard.Rows[1].Current || scoreboard.Rows[1].Team != "Team One" {
+		t.Fatalf("Expect Team One second, but found %v.", scoreboard.Rows)
+	}
+	if scoreboard.Rows[0].Team != "team2" || scoreboard.Rows[0].Scores[1] != "+2 (20)" || scoreboard.Rows[1].Scores[0] != "" {
+		t.Errorf("Unexpected scores %v.", scoreboard.Rows)
+	}
+}
+
+func TestDomjudgeNotLogged(t *testing.T) {
+	server := standIn(t)
+	defer server.Close()
+	c := testClient(t, server.URL)
+	if err := credentials.Set("domjudge", "team1", "wrong"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Login(); err == nil || err.Error() != ErrorNotLogged {
+		t.Errorf("Expect %v, but found %v.", ErrorNotLogged, err)
+	}
+}
+
+func TestDomjudgeCheckSession(t *testing.T) {
+	server := standIn(t)
+	defer server.Close()
+	c := testClient(t, server.URL)
+	if err := c.CheckSession(); err != nil {
+		t.Errorf("Expect a valid session, but found %v.", err)
+	}
+	if err := credentials.Set("domjudge", "team1", "wrong"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.CheckSession(); !c.Site().NotLogged(err) {
+		t.Errorf("Expect an expired session, but found %v.", err)
+	}
+}
+
+func TestDomjudgeChooseLanguage(t *testing.T) {
+	languages := []apiLanguage{
+		{ID: "cpp", Extensions: []string{"cpp", "cc"}},
+		{ID: "java", Extensions: []string{"java"}, EntryPointRequired: true},
+		{ID: "python3", Extensions: []string{"py"}, EntryPointRequired: true},
+	}
+	for _, test := range []struct{ path, language, entryPoint string }{
+		{"a.cc", "cpp", ""},
+		{"Main.java", "java", "Main"},
+		{"sol.py", "python3", "sol.py"},
+	} {
+		language, entryPoint, err := chooseLanguage(languages, test.path)
+		if err != nil || language.ID != test.language || entryPoint != test.entryPoint {
+			t.Errorf("%v: expect %v %v, but found %v %v (%v).", test.path, test.language, test.entryPoint, language.ID, entryPoint, err)
+		}
+	}
+	if _, _, err := chooseLanguage(languages, "a.rs"); err == nil {
+		t.Errorf("Expect an error for a.rs.")
+	}
+}
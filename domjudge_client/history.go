@@ -0,0 +1,41 @@
+package domjudge_client
+
+import (
+	"database/sql"
+	"os"
+	"time"
+
+	"github.com/Arapak/sio-tool/database_client"
+	"github.com/fatih/color"
+)
+
+const judgeName = "domjudge"
+
+// recordSubmission saves a submission made with st, together with the hash of its source, in the database.
+func (c *DomjudgeClient) recordSubmission(db *sql.DB, info Info, s Submission, sourcePath string) {
+	if db == nil {
+		return
+	}
+	submission := database_client.Submission{
+		Judge:        judgeName,
+		SubmissionID: s.ParseID(),
+		ShortName:    info.ProblemID,
+		ContestID:    info.ContestID,
+		When:         time.Now().Format("2006-01-02 15:04"),
+		Status:       s.PlainStatus(),
+		Points:       database_client.NoPoints,
+		FilePath:     sourcePath,
+	}
+	if s.Accepted() {
+		submission.Points = 100
+	} else if s.End() {
+		submission.Points = 0
+	}
+	if source, err := os.ReadFile(sourcePath); err == nil {
+		submission.SourceHash = database_client.SourceHash(source)
+	}
+	submission.TaskID, _ = database_client.FindTaskID(db, database_client.Task{Source: "domjudge", ShortName: submission.ShortName, ContestID: submission.ContestID})
+	if err := database_client.AddSubmission(db, submission); err != nil {
+		color.Red(err.Error())
+	}
+}
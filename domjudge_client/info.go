@@ -0,0 +1,61 @@
+package domjudge_client
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+)
+
+type Info struct {
+	ContestID    string `json:"contest_id"`
+	ProblemID    string `json:"problem_id"`
+	SubmissionID string `json:"submission_id"`
+	RootPath     string
+}
+
+const ErrorNeedContestID = "you have to specify the Contest ID"
+const ErrorNeedProblemID = "you have to specify the Problem ID"
+const ErrorNeedSubmissionID = "you have to specify the Submission ID"
+
+func (info *Info) Hint() string {
+	text := "DOMJUDGE"
+	if info.ContestID != "" {
+		text = text + ", contest " + info.ContestID
+	}
+	if info.ProblemID != "" {
+		text = text + ", problem " + info.ProblemID
+	}
+	if info.SubmissionID != "" {
+		text = text + ", submission " + info.SubmissionID
+	}
+	return text
+}
+
+func (info *Info) Path() string {
+	path := info.RootPath
+	if info.ContestID != "" {
+		path = filepath.Join(path, info.ContestID)
+		if info.ProblemID != "" {
+			path = filepath.Join(path, info.ProblemID)
+		}
+	}
+	return path
+}
+
+// The team interface of DOMjudge shows the active contest chosen in the browser, so the pages
+// don't depend on the contest of info.
+
+func (info *Info) OpenURL(host string) (string, error) {
+	return host + "/team/problems", nil
+}
+
+func (info *Info) StandingsURL(host string) (string, error) {
+	return host + "/team/scoreboard", nil
+}
+
+func (info *Info) SubmissionURL(host string) (string, error) {
+	if info.SubmissionID == "" {
+		return "", errors.New(ErrorNeedSubmissionID)
+	}
+	return fmt.Sprintf(host+"/team/submission/%v", info.SubmissionID), nil
+}
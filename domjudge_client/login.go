@@ -0,0 +1,73 @@
+package domjudge_client
+
+import (
+	"errors"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/Arapak/sio-tool/credentials"
+	"github.com/Arapak/sio-tool/util"
+
+	"github.com/fatih/color"
+)
+
+const ErrorNotLogged = "not logged in"
+
+func (c *DomjudgeClient) Login() (err error) {
+	color.Cyan("Login %v...\n", c.Username)
+
+	if _, err = c.DecryptPassword(); err != nil {
+		return
+	}
+
+	var user apiUser
+	if err = c.get("/user", &user); err != nil {
+		return
+	}
+	if user.Username == "" {
+		return errors.New(ErrorNotLogged)
+	}
+
+	c.TeamID = user.TeamID.String()
+	color.Green("Succeed!!")
+	color.Green("Welcome %v~", user.Username)
+	return c.save()
+}
+
+// CheckSession checks that the API still accepts the saved credentials.
+func (c *DomjudgeClient) CheckSession() (err error) {
+	var user apiUser
+	if err = c.get("/user", &user); err != nil {
+		return
+	}
+	if user.Username == "" {
+		return errors.New(ErrorNotLogged)
+	}
+	return
+}
+func (c *DomjudgeClient) DecryptPassword() (string, error) {
+	if len(c.Username) == 0 {
+		return "", errors.New(credentials.ErrorNotFound)
+	}
+	return credentials.Get("domjudge", c.Username)
+}
+
+func (c *DomjudgeClient) ConfigLogin() (err error) {
+	if c.Username != "" {
+		color.Green("Current user: %v", c.Username)
+	}
+	color.Cyan("Configure username and password of your team")
+
+	username := ""
+	util.GetValue("username:", &username, true)
+
+	password := ""
+	if err = survey.AskOne(&survey.Password{Message: `password:`}, &password); err != nil {
+		return
+	}
+
+	c.Username = username
+	if err = credentials.Set("domjudge", username, password); err != nil {
+		return
+	}
+	return c.Login()
+}
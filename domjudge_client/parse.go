@@ -0,0 +1,107 @@
+package domjudge_client
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Arapak/sio-tool/database_client"
+	"github.com/Arapak/sio-tool/util"
+
+	"github.com/fatih/color"
+	"github.com/k0kubun/go-ansi"
+)
+
+func (c *DomjudgeClient) getSamples(contestID string, problem apiProblem) (input, output [][]byte, err error) {
+	body, err := c.getBody(fmt.Sprintf("/contests/%v/problems/%v/samples.zip", contestID, problem.ID))
+	if err != nil {
+		// a problem without samples has no samples archive
+		if err.Error() == ErrorNotFound {
+			return nil, nil, nil
+		}
+		return
+	}
+	return util.SamplesFromZip(body)
+}
+
+func (c *DomjudgeClient) parseProblem(info Info, problem apiProblem, db *sql.DB) (path string, err error) {
+	input, output, err := c.getSamples(info.ContestID, problem)
+	if err != nil {
+		return
+	}
+	info.ProblemID = strings.ToLower(problem.Label)
+	path = info.Path()
+	if err = os.MkdirAll(path, os.ModePerm); err != nil {
+		return
+	}
+	for i := 0; i < len(input); i++ {
+		fileIn := filepath.Join(path, fmt.Sprintf("in%v.txt", i+1))
+		fileOut := filepath.Join(path, fmt.Sprintf("out%v.txt", i+1))
+		if e := os.WriteFile(fileIn, input[i], 0644); e != nil {
+			color.Red(e.Error())
+		}
+		if e := os.WriteFile(fileOut, output[i], 0644); e != nil {
+			color.Red(e.Error())
+		}
+	}
+	color.Green("Parsed %v. %v with %v samples.", problem.Label, problem.Name, len(input))
+	task := database_client.Task{
+		Name:      problem.Name,
+		Source:    "domjudge",
+		Path:      path,
+		ShortName: info.ProblemID,
+		ContestID: info.ContestID,
+		Link:      c.host + "/team/problems",
+	}
+	if err = database_client.AddTask(db, task); err != nil {
+		color.Red(err.Error())
+	}
+	return
+}
+
+// Parse downloads the samples of the problem of info, or of every problem of the contest when
+// info has no problem.
+func (c *DomjudgeClient) Parse(info Info, db *sql.DB) (problems []string, paths []string, err error) {
+	color.Cyan("Parse " + info.Hint())
+
+	start := time.Now()
+	var perf util.Performance
+
+	perf.StartFetching()
+
+	if info.ContestID, err = c.contest(info); err != nil {
+		return
+	}
+	contestProblems, err := c.problems(info.ContestID)
+	if err != nil {
+		return
+	}
+
+	perf.StopFetching()
+
+	if info.ProblemID != "" {
+		problem, ok := findProblem(contestProblems, info.ProblemID)
+		if !ok {
+			return nil, nil, errors.New("cannot find problem " + info.ProblemID)
+		}
+		contestProblems = []apiProblem{problem}
+	}
+
+	_, _ = ansi.Printf(color.CyanString("The problems will be saved to %v\n"), color.GreenString(info.Path()))
+	for _, problem := range contestProblems {
+		path, err := c.parseProblem(info, problem, db)
+		if err != nil {
+			color.Red("Failed %v. Error: %v", problem.Label, err.Error())
+			continue
+		}
+		problems = append(problems, strings.ToLower(problem.Label))
+		paths = append(paths, path)
+	}
+	fmt.Printf("Fetching: (%v)\n", perf.Parse())
+	fmt.Printf("Total: %s\n", time.Since(start).Round(time.Millisecond))
+	return
+}
@@ -0,0 +1,17 @@
+package domjudge_client
+
+import (
+	"errors"
+)
+
+const ErrorDomjudgeIsUnavailable = "DOMjudge is unavailable (check the host and your internet connection)"
+
+func (c *DomjudgeClient) Ping() (err error) {
+	if c.host == "" {
+		return errors.New(ErrorNoHost)
+	}
+	if _, err = c.getBody("/version"); err != nil && err.Error() != ErrorNotLogged {
+		return errors.New(ErrorDomjudgeIsUnavailable)
+	}
+	return nil
+}
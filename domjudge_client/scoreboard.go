@@ -0,0 +1,116 @@
+package domjudge_client
+
+import (
+	"fmt"
+
+	"github.com/Arapak/sio-tool/util"
+)
+
+type ScoreboardRow struct {
+	Rank    int
+	Team    string
+	Solved  int
+	Time    int
+	Scores  []string
+	Current bool
+}
+
+type Scoreboard struct {
+	Problems []string
+	Rows     []ScoreboardRow
+}
+
+type apiScoreboard struct {
+	Rows []struct {
+		Rank   int    `json:"rank"`
+		TeamID string `json:"team_id"`
+		Score  struct {
+			NumSolved int `json:"num_solved"`
+			TotalTime int `json:"total_time"`
+		} `json:"score"`
+		Problems []struct {
+			Label      string `json:"label"`
+			NumJudged  int    `json:"num_judged"`
+			NumPending int    `json:"num_pending"`
+			Solved     bool   `json:"solved"`
+			Time       int    `json:"time"`
+		} `json:"problems"`
+	} `json:"rows"`
+}
+
+type apiTeam struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+}
+
+// scoreCell shows the tries on the problem the way the scoreboard of DOMjudge does,
+// with the minute of the accepted submission.
+func scoreCell(solved bool, judged, pending, time int) string {
+	switch {
+	case solved:
+		return fmt.Sprintf("+%v (%v)", judged, time)
+	case pending > 0:
+		return fmt.Sprintf("?%v", judged+pending)
+	case judged > 0:
+		return fmt.Sprintf("-%v", judged)
+	}
+	return ""
+}
+
+func (c *DomjudgeClient) GetScoreboard(info Info) (scoreboard Scoreboard, perf util.Performance, err error) {
+	perf.StartFetching()
+
+	if info.ContestID, err = c.contest(info); err != nil {
+		return
+	}
+	problems, err := c.problems(info.ContestID)
+	if err != nil {
+		return
+	}
+	var board apiScoreboard
+	if err = c.get(fmt.Sprintf("/contests/%v/scoreboard", info.ContestID), &board); err != nil {
+		return
+	}
+	var teams []apiTeam
+	if err = c.get(fmt.Sprintf("/contests/%v/teams", info.ContestID), &teams); err != nil {
+		return
+	}
+
+	perf.StopFetching()
+	perf.StartParsing()
+
+	names := make(map[string]string)
+	for _, team := range teams {
+		names[team.ID] = team.DisplayName
+		if names[team.ID] == "" {
+			names[team.ID] = team.Name
+		}
+	}
+	for _, problem := range problems {
+		scoreboard.Problems = append(scoreboard.Problems, problem.Label)
+	}
+	for _, row := range board.Rows {
+		scores := make(map[string]string)
+		for _, problem := range row.Problems {
+			scores[problem.Label] = scoreCell(problem.Solved, problem.NumJudged, problem.NumPending, problem.Time)
+		}
+		scoreboardRow := ScoreboardRow{
+			Rank:    row.Rank,
+			Team:    names[row.TeamID],
+			Solved:  row.Score.NumSolved,
+			Time:    row.Score.TotalTime,
+			Current: row.TeamID == c.TeamID,
+		}
+		if scoreboardRow.Team == "" {
+			scoreboardRow.Team = row.TeamID
+		}
+		for _, label := range scoreboard.Problems {
+			scoreboardRow.Scores = append(scoreboardRow.Scores, scores[label])
+		}
+		scoreboard.Rows = append(scoreboard.Rows, scoreboardRow)
+	}
+
+	perf.StopParsing()
+	return
+}
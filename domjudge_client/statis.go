@@ -0,0 +1,59 @@
+package domjudge_client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Arapak/sio-tool/util"
+)
+
+type StatisInfo struct {
+	Label     string
+	Name      string
+	TimeLimit string
+	State     string
+}
+
+// Statis lists the problems of the contest, marking the ones the team has solved or tried.
+func (c *DomjudgeClient) Statis(info Info) (problems []StatisInfo, perf util.Performance, err error) {
+	perf.StartFetching()
+
+	if info.ContestID, err = c.contest(info); err != nil {
+		return
+	}
+	contestProblems, err := c.problems(info.ContestID)
+	if err != nil {
+		return
+	}
+	info.ProblemID = ""
+	info.SubmissionID = ""
+	submissions, err := c.getSubmissions(info, -1)
+	if err != nil && err.Error() != ErrorNoSubmissions {
+		return
+	}
+	err = nil
+
+	perf.StopFetching()
+	perf.StartParsing()
+
+	state := make(map[string]string)
+	for _, submission := range submissions {
+		if submission.Accepted() {
+			state[submission.problem] = "accepted"
+		} else if submission.End() && state[submission.problem] == "" {
+			state[submission.problem] = "rejected"
+		}
+	}
+	for _, problem := range contestProblems {
+		label := strings.ToLower(problem.Label)
+		problems = append(problems, StatisInfo{
+			Label:     problem.Label,
+			Name:      problem.Name,
+			TimeLimit: fmt.Sprintf("%v s", problem.TimeLimit),
+			State:     state[label],
+		})
+	}
+
+	perf.StopParsing()
+	return
+}
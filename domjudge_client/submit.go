@@ -0,0 +1,123 @@
+package domjudge_client
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Arapak/sio-tool/account"
+	"github.com/Arapak/sio-tool/database_client"
+
+	"github.com/fatih/color"
+)
+
+// chooseLanguage returns the language of the contest whose extensions contain the extension of
+// the source, with the entry point for the languages which require one.
+func chooseLanguage(languages []apiLanguage, sourcePath string) (language apiLanguage, entryPoint string, err error) {
+	ext := strings.TrimPrefix(filepath.Ext(sourcePath), ".")
+	for _, lang := range languages {
+		for _, extension := range lang.Extensions {
+			if extension != ext {
+				continue
+			}
+			if lang.EntryPointRequired {
+				entryPoint = strings.TrimSuffix(filepath.Base(sourcePath), filepath.Ext(sourcePath))
+				if ext == "py" {
+					entryPoint = filepath.Base(sourcePath)
+				} else if ext == "kt" {
+					entryPoint = strings.ToUpper(entryPoint[:1]) + entryPoint[1:] + "Kt"
+				}
+			}
+			return lang, entryPoint, nil
+		}
+	}
+	return language, "", fmt.Errorf("cannot find the DOMjudge language of %v", filepath.Base(sourcePath))
+}
+
+func (c *DomjudgeClient) Submit(info Info, sourcePath string, db *sql.DB) (err error) {
+	color.Cyan("Submit " + info.Hint())
+
+	if info.ProblemID == "" {
+		return errors.New(ErrorNeedProblemID)
+	}
+	if info.ContestID, err = c.contest(info); err != nil {
+		return
+	}
+
+	fmt.Printf("Current user: %v\n", account.Label(c.path, c.Username))
+
+	check := database_client.Submission{Judge: judgeName, ContestID: info.ContestID, ShortName: info.ProblemID}
+	if err = database_client.CheckSubmission(db, check, sourcePath, database_client.UnknownLimit); err != nil {
+		return
+	}
+
+	problems, err := c.problems(info.ContestID)
+	if err != nil {
+		return
+	}
+	problem, ok := findProblem(problems, info.ProblemID)
+	if !ok {
+		return errors.New("cannot find problem " + info.ProblemID)
+	}
+	var languages []apiLanguage
+	if err = c.get(fmt.Sprintf("/contests/%v/languages", info.ContestID), &languages); err != nil {
+		return
+	}
+	language, entryPoint, err := chooseLanguage(languages, sourcePath)
+	if err != nil {
+		return
+	}
+
+	sourceFile, err := os.Open(sourcePath)
+	if err != nil {
+		return err
+	}
+	defer sourceFile.Close()
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	fields := map[string]string{
+		"problem":  problem.ID,
+		"language": language.ID,
+	}
+	if entryPoint != "" {
+		fields["entry_point"] = entryPoint
+	}
+	for key, value := range fields {
+		if err = writer.WriteField(key, value); err != nil {
+			return
+		}
+	}
+	part, err := writer.CreateFormFile("code[]", filepath.Base(sourcePath))
+	if err != nil {
+		return
+	}
+	if _, err = io.Copy(part, sourceFile); err != nil {
+		return
+	}
+	if err = writer.Close(); err != nil {
+		return
+	}
+
+	var submission apiSubmission
+	if err = c.post(fmt.Sprintf("/contests/%v/submissions", info.ContestID), writer.FormDataContentType(), body, &submission); err != nil {
+		return
+	}
+	color.Green("Submitted")
+
+	info.SubmissionID = submission.ID
+	submissions, err := c.WatchSubmission(info, 1, true)
+	if err != nil {
+		return
+	}
+
+	c.LastSubmission = &info
+	c.recordSubmission(db, info, submissions[0], sourcePath)
+	return c.save()
+}
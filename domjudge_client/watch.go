@@ -0,0 +1,283 @@
+package domjudge_client
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Arapak/sio-tool/util"
+
+	"github.com/fatih/color"
+	"github.com/k0kubun/go-ansi"
+	"github.com/olekukonko/tablewriter"
+)
+
+const ErrorNoSubmissions = "cannot find any submission"
+
+// verdicts are the names of the judgement types of the Contest API.
+var verdicts = map[string]string{
+	"AC":  "Accepted",
+	"WA":  "Wrong Answer",
+	"TLE": "Time Limit Exceeded",
+	"RTE": "Run Time Error",
+	"MLE": "Memory Limit Exceeded",
+	"OLE": "Output Limit Exceeded",
+	"CE":  "Compile Error",
+	"NO":  "No Output",
+}
+
+type Submission struct {
+	id      string
+	problem string
+	lang    string
+	when    string
+	verdict string
+	time    float64
+	end     bool
+}
+
+func (s *Submission) ParseID() string {
+	return s.id
+}
+
+func (s *Submission) Name() string {
+	return s.problem
+}
+
+// End reports whether the submission has been judged.
+func (s *Submission) End() bool {
+	return s.end
+}
+
+func (s *Submission) Accepted() bool {
+	return s.verdict == "AC"
+}
+
+// PlainStatus returns the status without colors.
+func (s *Submission) PlainStatus() string {
+	if !s.end {
+		return "Pending"
+	}
+	if name, ok := verdicts[s.verdict]; ok {
+		return name
+	}
+	return s.verdict
+}
+
+func (s *Submission) ParseStatus() string {
+	if !s.End() {
+		return color.New(color.FgWhite).Sprint(s.PlainStatus())
+	} else if s.Accepted() {
+		return color.New(color.FgGreen).Sprint(s.PlainStatus())
+	}
+	return color.New(color.FgRed).Sprint(s.PlainStatus())
+}
+
+func (s *Submission) ParseTime() string {
+	if !s.end {
+		return ""
+	}
+	return fmt.Sprintf("%v ms", int(s.time*1000))
+}
+
+func refreshLine(n int, maxWidth int) {
+	for i := 0; i < n; i++ {
+		_, _ = ansi.Printf("%v\n", strings.Repeat(" ", maxWidth))
+	}
+	ansi.CursorUp(n)
+}
+
+func updateLine(line string, maxWidth *int) string {
+	*maxWidth = len(line)
+	return line
+}
+
+func (s *Submission) display(first bool, maxWidth *int) {
+	if !first {
+		ansi.CursorUp(6)
+	}
+	_, _ = ansi.Printf("      #: %v\n", s.ParseID())
+	_, _ = ansi.Printf("   when: %v\n", s.when)
+	_, _ = ansi.Printf("   prob: %v\n", s.problem)
+	_, _ = ansi.Printf("   lang: %v\n", s.lang)
+	refreshLine(1, *maxWidth)
+	_, _ = ansi.Printf(updateLine(fmt.Sprintf(" status: %v\n", s.ParseStatus()), maxWidth))
+	_, _ = ansi.Printf("   time: %v\n", s.ParseTime())
+}
+
+func display(submissions []Submission, first bool, maxWidth *int, line bool) {
+	if line {
+		submissions[0].display(first, maxWidth)
+		return
+	}
+	var buf bytes.Buffer
+	output := io.Writer(&buf)
+	table := tablewriter.NewWriter(output)
+	table.SetHeader([]string{"#", "when", "problem", "lang", "status", "time"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetAlignment(tablewriter.ALIGN_CENTER)
+	table.SetCenterSeparator("|")
+	table.SetAutoWrapText(false)
+	for _, sub := range submissions {
+		table.Append([]string{
+			sub.ParseID(),
+			sub.when,
+			sub.problem,
+			sub.lang,
+			sub.ParseStatus(),
+			sub.ParseTime(),
+		})
+	}
+	table.Render()
+
+	if !first {
+		ansi.CursorUp(len(submissions) + 2)
+	}
+	refreshLine(len(submissions)+2, *maxWidth)
+
+	scanner := bufio.NewScanner(io.Reader(&buf))
+	for scanner.Scan() {
+		line := scanner.Text()
+		*maxWidth = len(line)
+		_, _ = ansi.Println(line)
+	}
+}
+
+func parseWhen(raw string) string {
+	tm, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return raw
+	}
+	return tm.In(time.Local).Format("2006-01-02 15:04")
+}
+
+// lessID orders the numeric IDs of DOMjudge by value and the other ones as strings.
+func lessID(a, b string) bool {
+	x, errA := strconv.ParseUint(a, 10, 64)
+	y, errB := strconv.ParseUint(b, 10, 64)
+	if errA == nil && errB == nil {
+		return x < y
+	}
+	return a < b
+}
+
+// getSubmissions returns the newest n submissions of the team (all of them when n is negative)
+// with the verdicts of their valid judgements.
+func (c *DomjudgeClient) getSubmissions(info Info, n int) (submissions []Submission, err error) {
+	problems, err := c.problems(info.ContestID)
+	if err != nil {
+		return
+	}
+	labels := make(map[string]string)
+	for _, problem := range problems {
+		labels[problem.ID] = strings.ToLower(problem.Label)
+	}
+
+	var apiSubmissions []apiSubmission
+	if err = c.get(fmt.Sprintf("/contests/%v/submissions", info.ContestID), &apiSubmissions); err != nil {
+		return
+	}
+	var judgements []apiJudgement
+	if err = c.get(fmt.Sprintf("/contests/%v/judgements", info.ContestID), &judgements); err != nil {
+		return
+	}
+	verdict := make(map[string]apiJudgement)
+	for _, judgement := range judgements {
+		if judgement.Valid != nil && !*judgement.Valid {
+			continue
+		}
+		if previous, ok := verdict[judgement.SubmissionID]; !ok || lessID(previous.ID, judgement.ID) {
+			verdict[judgement.SubmissionID] = judgement
+		}
+	}
+
+	sort.Slice(apiSubmissions, func(i, j int) bool {
+		return lessID(apiSubmissions[j].ID, apiSubmissions[i].ID)
+	})
+	for _, s := range apiSubmissions {
+		if c.TeamID != "" && s.TeamID != c.TeamID {
+			continue
+		}
+		if info.SubmissionID != "" && s.ID != info.SubmissionID {
+			continue
+		}
+		if info.ProblemID != "" && labels[s.ProblemID] != strings.ToLower(info.ProblemID) {
+			continue
+		}
+		submission := Submission{
+			id:      s.ID,
+			problem: labels[s.ProblemID],
+			lang:    s.LanguageID,
+			when:    parseWhen(s.Time),
+		}
+		if judgement, ok := verdict[s.ID]; ok && judgement.JudgementTypeID != nil {
+			submission.verdict = *judgement.JudgementTypeID
+			submission.time = judgement.MaxRunTime
+			submission.end = true
+		}
+		submissions = append(submissions, submission)
+		if n >= 0 && len(submissions) >= n {
+			break
+		}
+	}
+	if len(submissions) < 1 {
+		return nil, errors.New(ErrorNoSubmissions)
+	}
+	return
+}
+
+func (c *DomjudgeClient) WatchSubmission(info Info, n int, line bool) (submissions []Submission, err error) {
+	if info.ContestID, err = c.contest(info); err != nil {
+		return
+	}
+
+	maxWidth := 0
+	first := true
+	poller := util.NewPoller()
+	previous := ""
+	for {
+		submissions, err = c.getSubmissions(info, n)
+		if err != nil {
+			if poller.Backoff(err) {
+				if err = poller.Wait(); err != nil {
+					return
+				}
+				continue
+			}
+			return
+		}
+		display(submissions, first, &maxWidth, line)
+		first = false
+		endCount := 0
+		for _, submission := range submissions {
+			if submission.end {
+				endCount++
+			}
+		}
+		if endCount == len(submissions) {
+			return
+		}
+		if state := statusSignature(submissions); state != previous {
+			previous = state
+			poller.Reset()
+		}
+		if err = poller.Wait(); err != nil {
+			return
+		}
+	}
+}
+
+// statusSignature changes whenever the status of any of the submissions changes.
+func statusSignature(submissions []Submission) string {
+	var signature strings.Builder
+	for _, s := range submissions {
+		signature.WriteString(fmt.Sprint(s.id, s.verdict, ";"))
+	}
+	return signature.String()
+}
@@ -0,0 +1,65 @@
+package kattis_client
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+)
+
+type Info struct {
+	ProblemID    string `json:"problem_id"`
+	SubmissionID string `json:"submission_id"`
+	RootPath     string
+}
+
+const ErrorNeedProblemID = "you have to specify the Problem ID"
+const ErrorNeedSubmissionID = "you have to specify the Submission ID"
+
+func (info *Info) Hint() string {
+	text := "KATTIS"
+	if info.ProblemID != "" {
+		text = text + ", problem " + info.ProblemID
+	}
+	if info.SubmissionID != "" {
+		text = text + ", submission " + info.SubmissionID
+	}
+	return text
+}
+
+func (info *Info) Path() string {
+	path := info.RootPath
+	if info.ProblemID != "" {
+		path = filepath.Join(path, info.ProblemID)
+	}
+	return path
+}
+
+func (info *Info) ProblemURL(host string) (string, error) {
+	if info.ProblemID == "" {
+		return "", errors.New(ErrorNeedProblemID)
+	}
+	return fmt.Sprintf(host+"/problems/%v", info.ProblemID), nil
+}
+
+func (info *Info) SamplesURL(host string) (string, error) {
+	URL, err := info.ProblemURL(host)
+	if err != nil {
+		return "", err
+	}
+	return URL + "/file/statement/samples.zip", nil
+}
+
+// SubmissionURL returns the page of the submission, submissionsURL comes from .kattisrc.
+func (info *Info) SubmissionURL(submissionsURL string) (string, error) {
+	if info.SubmissionID == "" {
+		return "", errors.New(ErrorNeedSubmissionID)
+	}
+	return fmt.Sprintf(submissionsURL+"/%v", info.SubmissionID), nil
+}
+
+func (info *Info) OpenURL(host string) (string, error) {
+	if info.ProblemID == "" {
+		return host + "/problems", nil
+	}
+	return info.ProblemURL(host)
+}
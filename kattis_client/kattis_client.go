@@ -0,0 +1,96 @@
+package kattis_client
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/Arapak/sio-tool/cookiejar"
+	"github.com/Arapak/sio-tool/credentials"
+	"github.com/Arapak/sio-tool/site"
+	"github.com/Arapak/sio-tool/util"
+
+	"github.com/fatih/color"
+)
+
+// KattisClient uses the credentials and the URLs of a .kattisrc file, the session file keeps only the cookies.
+type KattisClient struct {
+	Jar            *cookiejar.Jar `json:"cookies"`
+	LastSubmission *Info          `json:"last_submission"`
+	rc             RC
+	rcPath         string
+	rcErr          error
+	path           string
+	client         *http.Client
+}
+
+var Instance *KattisClient
+
+func Init(path, rcPath, proxy string) {
+	jar, _ := cookiejar.New(nil)
+	c := &KattisClient{Jar: jar, LastSubmission: nil, path: path, rcPath: rcPath, client: nil}
+	if err := c.load(); err != nil {
+		color.Red(err.Error())
+		color.Green("Create a new session in %v", path)
+	}
+	// .kattisrc is optional, the error is reported only when Kattis is used
+	c.rc, c.rcErr = LoadRC(rcPath)
+	Proxy := http.ProxyFromEnvironment
+	if len(proxy) > 0 {
+		proxyURL, err := url.Parse(proxy)
+		if err != nil {
+			color.Red(err.Error())
+			color.Green("Use default proxy from environment")
+		} else {
+			Proxy = http.ProxyURL(proxyURL)
+		}
+	}
+	c.client = &http.Client{Jar: c.Jar, Transport: util.NewTransport(Proxy)}
+	if err := c.save(); err != nil {
+		color.Red(err.Error())
+	}
+	Instance = c
+	site.Register(c.Site())
+}
+
+// Host returns the address of the judge from .kattisrc, empty when there is no .kattisrc.
+func (c *KattisClient) Host() string {
+	return c.rc.Host()
+}
+
+// Username returns the username from .kattisrc.
+func (c *KattisClient) Username() string {
+	return c.rc.Username
+}
+
+func (c *KattisClient) load() (err error) {
+	file, err := os.Open(c.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return
+	}
+	defer file.Close()
+
+	bytes, err := io.ReadAll(file)
+
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(bytes, c)
+}
+
+func (c *KattisClient) save() (err error) {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err == nil {
+		err = credentials.WriteFile(c.path, data)
+	}
+	if err != nil {
+		color.Red("Cannot save session to %v\n%v", c.path, err.Error())
+	}
+	return
+}
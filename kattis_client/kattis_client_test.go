@@ -0,0 +1,203 @@
+package kattis_client
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/Arapak/sio-tool/replay/replaytest"
+	"github.com/Arapak/sio-tool/util"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+// host is the judge the fixtures are recorded from, ST_RECORD_FIXTURES=1 records them again
+// with the session and the .kattisrc of st. The expectations are read from the recorded pages.
+const host = "https://open.kattis.com"
+
+// submissions are submissions of the user of the fixtures, one judged and one still running.
+// Change them to submissions of your own to record the fixtures again.
+var submissions = []string{"12345678", "12345679"}
+
+func init() {
+	replaytest.Session = func() (*http.Client, string, error) {
+		path, _ := homedir.Expand("~/.st/kattis_session")
+		rc, _ := homedir.Expand("~/.kattisrc")
+		Init(path, rc, "")
+		if err := Instance.Login(); err != nil {
+			return nil, "", err
+		}
+		return Instance.client, Instance.rc.Username, nil
+	}
+}
+
+func testClient(client *http.Client) *KattisClient {
+	return &KattisClient{
+		rc:     RC{Hostname: "open.kattis.com", SubmissionsURL: host + "/submissions"},
+		client: client,
+	}
+}
+
+func TestFindName(t *testing.T) {
+	body := replaytest.AnonymousFixture(t, host+"/problems/hello")
+	want := replaytest.Select(t, body, ".book-page-heading", "")
+	if name, err := findName(body); err != nil || name != want {
+		t.Errorf("Expect %q, but found %q (%v).", want, name, err)
+	}
+}
+
+// zipSamples returns the samples in the archive of a problem, the inputs are the files
+// ending with .in and the outputs the files with the same name ending with .ans.
+func zipSamples(t *testing.T, data []byte) (input, output []string) {
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{}
+	var names []string
+	for _, f := range r.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		_, err = buf.ReadFrom(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[f.Name] = buf.String()
+		if strings.HasSuffix(f.Name, ".in") {
+			names = append(names, strings.TrimSuffix(f.Name, ".in"))
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if answer, ok := files[name+".ans"]; ok {
+			input = append(input, files[name+".in"])
+			output = append(output, answer)
+		}
+	}
+	return
+}
+
+func TestGetSamples(t *testing.T) {
+	c := testClient(replaytest.AnonymousClient())
+	samples, err := c.getSamples(Info{ProblemID: "hello"})
+	if err != nil || samples == nil {
+		t.Fatalf("Expect the samples of hello, but found %v.", err)
+	}
+	input, output, err := util.SamplesFromZip(samples)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantInput, wantOutput := zipSamples(t, samples)
+	if len(wantInput) == 0 || len(input) != len(wantInput) || len(output) != len(wantOutput) {
+		t.Fatalf("Expect %q %q, but found %q %q.", wantInput, wantOutput, input, output)
+	}
+	for i := range wantInput {
+		if strings.TrimSpace(string(input[i])) != strings.TrimSpace(wantInput[i]) || strings.TrimSpace(string(output[i])) != strings.TrimSpace(wantOutput[i]) {
+			t.Errorf("Sample %v: expect %q %q, but found %q %q.", i+1, wantInput[i], wantOutput[i], input[i], output[i])
+		}
+	}
+
+	if samples, err = c.getSamples(Info{ProblemID: "nosamples"}); err != nil || samples != nil {
+		t.Errorf("Expect no samples, but found %v (%v).", len(samples), err)
+	}
+}
+
+func TestGetSubmission(t *testing.T) {
+	c := testClient(replaytest.FixtureClient(t))
+	for _, id := range submissions {
+		s, err := c.getSubmission(Info{ProblemID: "hello", SubmissionID: id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var want struct {
+			StatusID      int `json:"status_id"`
+			TestcaseIndex int `json:"testcase_index"`
+		}
+		if err = json.Unmarshal(replaytest.Fixture(t, host+"/submissions/"+id+"?json"), &want); err != nil {
+			t.Fatal(err)
+		}
+		// Kattis numbers the statuses of the submissions still being judged up to 5 (running)
+		running := want.StatusID <= 5
+		status, known := statuses[want.StatusID]
+		if !known || !strings.HasPrefix(s.PlainStatus(), status) || s.End() == running {
+			t.Errorf("%v: expect status %v (%v), but found %v.", id, want.StatusID, status, s.PlainStatus())
+		}
+		if running && want.TestcaseIndex > 0 && !strings.HasSuffix(s.PlainStatus(), fmt.Sprintf("test %v", want.TestcaseIndex)) {
+			t.Errorf("%v: expect the test %v, but found %v.", id, want.TestcaseIndex, s.PlainStatus())
+		}
+	}
+}
+
+func TestLoadRC(t *testing.T) {
+	tests := []struct {
+		name string
+		rc   string
+		want RC
+		err  bool
+	}{
+		{"password", "[user]\nusername: st_user\npassword: secret\n\n[kattis]\nhostname: open.kattis.com\nloginurl: https://open.kattis.com/login\nsubmissionurl: https://open.kattis.com/submit\nsubmissionsurl: https://open.kattis.com/submissions\n",
+			RC{Username: "st_user", Password: "secret", Hostname: "open.kattis.com", LoginURL: "https://open.kattis.com/login", SubmissionURL: "https://open.kattis.com/submit", SubmissionsURL: "https://open.kattis.com/submissions"}, false},
+		{"token and derived URLs", "# downloaded from the judge\n[User]\nUsername = st_user\nToken = 0123abcd\n[Kattis]\nHostname = itu.kattis.com/\n",
+			RC{Username: "st_user", Token: "0123abcd", Hostname: "itu.kattis.com/", LoginURL: "https://itu.kattis.com/login", SubmissionURL: "https://itu.kattis.com/submit", SubmissionsURL: "https://itu.kattis.com/submissions"}, false},
+		{"no credentials", "[user]\nusername: st_user\n[kattis]\nhostname: open.kattis.com\n", RC{}, true},
+		{"no hostname", "[user]\nusername: st_user\ntoken: 0123abcd\n", RC{}, true},
+	}
+	for _, test := range tests {
+		path := filepath.Join(t.TempDir(), ".kattisrc")
+		if err := os.WriteFile(path, []byte(test.rc), 0600); err != nil {
+			t.Fatal(err)
+		}
+		rc, err := LoadRC(path)
+		if (err != nil) != test.err || (!test.err && rc != test.want) {
+			t.Errorf("%v: expect %+v (error %v), but found %+v (%v).", test.name, test.want, test.err, rc, err)
+		}
+	}
+	path := filepath.Join(t.TempDir(), ".kattisrc")
+	if _, err := LoadRC(path); err == nil || err.Error() != fmt.Sprintf(ErrorNoKattisRC, path) {
+		t.Errorf("Expect %q, but found %v.", fmt.Sprintf(ErrorNoKattisRC, path), err)
+	}
+}
+
+func TestSubmitResponse(t *testing.T) {
+	tests := []struct {
+		status int
+		body   string
+		id     string
+		err    string
+	}{
+		{http.StatusOK, "Submission received.  Submission ID: 12345678.\n", "12345678", ""},
+		{http.StatusOK, "Problem 'hellp' does not exist\n", "", "Problem 'hellp' does not exist"},
+		{http.StatusForbidden, "Please log in", "", ErrorNotLogged},
+		{http.StatusUnauthorized, "", "", ErrorNotLogged},
+		{http.StatusInternalServerError, "Internal Server Error", "", "500 Internal Server Error"},
+	}
+	for _, test := range tests {
+		resp := &http.Response{StatusCode: test.status, Status: fmt.Sprintf("%v %v", test.status, http.StatusText(test.status)), Body: io.NopCloser(strings.NewReader(test.body))}
+		body, err := checkResponse(resp)
+		id := ""
+		if err == nil {
+			id, err = findSubmissionID(body)
+		}
+		if id != test.id || (err == nil) != (test.err == "") || (err != nil && err.Error() != test.err) {
+			t.Errorf("%v %q: expect %q %q, but found %q %v.", test.status, test.body, test.id, test.err, id, err)
+		}
+	}
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Body: io.NopCloser(strings.NewReader(""))}
+	var rateLimit *util.RateLimitError
+	if _, err := checkResponse(resp); !errors.As(err, &rateLimit) {
+		t.Errorf("Expect a rate limit, but found %v.", err)
+	}
+}
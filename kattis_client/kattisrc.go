@@ -0,0 +1,94 @@
+package kattis_client
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// RC is the content of a .kattisrc file, which Kattis judges offer for download
+// (e.g. https://open.kattis.com/download/kattisrc).
+type RC struct {
+	Username       string
+	Password       string
+	Token          string
+	Hostname       string
+	LoginURL       string
+	SubmissionURL  string
+	SubmissionsURL string
+}
+
+const ErrorNoKattisRC = "cannot read %v, download your .kattisrc from the judge (e.g. https://open.kattis.com/download/kattisrc)"
+
+// parseINI reads the values of an INI file as "section.key". Both "key: value" and "key=value" are accepted.
+func parseINI(path string) (values map[string]string, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	values = map[string]string{}
+	section := ""
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.ToLower(strings.TrimSpace(line[1 : len(line)-1]))
+			continue
+		}
+		p := strings.IndexAny(line, ":=")
+		if p == -1 {
+			continue
+		}
+		key := strings.ToLower(strings.TrimSpace(line[:p]))
+		values[section+"."+key] = strings.TrimSpace(line[p+1:])
+	}
+	return values, scanner.Err()
+}
+
+// LoadRC reads a .kattisrc file. The URLs which are missing are derived from the hostname.
+func LoadRC(path string) (rc RC, err error) {
+	values, err := parseINI(path)
+	if err != nil {
+		return rc, fmt.Errorf(ErrorNoKattisRC, path)
+	}
+	rc = RC{
+		Username:       values["user.username"],
+		Password:       values["user.password"],
+		Token:          values["user.token"],
+		Hostname:       values["kattis.hostname"],
+		LoginURL:       values["kattis.loginurl"],
+		SubmissionURL:  values["kattis.submissionurl"],
+		SubmissionsURL: values["kattis.submissionsurl"],
+	}
+	if rc.Username == "" || (rc.Password == "" && rc.Token == "") {
+		return rc, fmt.Errorf("%v has no username and password or token", path)
+	}
+	if rc.Hostname == "" {
+		return rc, fmt.Errorf("%v has no hostname", path)
+	}
+	host := rc.Host()
+	if rc.LoginURL == "" {
+		rc.LoginURL = host + "/login"
+	}
+	if rc.SubmissionURL == "" {
+		rc.SubmissionURL = host + "/submit"
+	}
+	if rc.SubmissionsURL == "" {
+		rc.SubmissionsURL = host + "/submissions"
+	}
+	return
+}
+
+// Host returns the address of the judge, e.g. "https://open.kattis.com".
+func (rc *RC) Host() string {
+	if rc.Hostname == "" {
+		return ""
+	}
+	return "https://" + strings.TrimSuffix(rc.Hostname, "/")
+}
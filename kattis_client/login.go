@@ -0,0 +1,96 @@
+package kattis_client
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Arapak/sio-tool/cookiejar"
+	"github.com/Arapak/sio-tool/site"
+	"github.com/Arapak/sio-tool/util"
+
+	"github.com/fatih/color"
+)
+
+const ErrorNotLogged = "not logged in"
+
+// checkResponse turns the responses Kattis sends to scripts without a valid session into ErrorNotLogged.
+func checkResponse(resp *http.Response) (body []byte, err error) {
+	body, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, &util.RateLimitError{}
+	}
+	if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusUnauthorized {
+		return nil, errors.New(ErrorNotLogged)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New(resp.Status)
+	}
+	return
+}
+
+func (c *KattisClient) Login() (err error) {
+	if c.rcErr != nil {
+		return c.rcErr
+	}
+	color.Cyan("Login %v...\n", c.rc.Username)
+
+	values := url.Values{
+		"user":   {c.rc.Username},
+		"script": {"true"},
+	}
+	if c.rc.Password != "" {
+		values.Set("password", c.rc.Password)
+	} else {
+		values.Set("token", c.rc.Token)
+	}
+
+	jar, _ := cookiejar.New(nil)
+
+	c.client.Jar = jar
+	resp, err := c.client.PostForm(c.rc.LoginURL, values)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return
+	}
+	if resp.StatusCode != http.StatusOK {
+		msg := strings.TrimSpace(string(body))
+		if msg == "" {
+			msg = resp.Status
+		}
+		return errors.New(msg)
+	}
+
+	c.Jar = jar
+	color.Green("Succeed!!")
+	color.Green("Welcome %v~", c.rc.Username)
+	return c.save()
+}
+
+// CheckSession can't tell if the session is valid, as Kattis shows scripts only
+// single submissions. st logs in again when Kattis refuses a request.
+func (c *KattisClient) CheckSession() (err error) {
+	if c.rcErr != nil {
+		return c.rcErr
+	}
+	return errors.New(site.ErrorNotSupported)
+}
+
+func (c *KattisClient) ConfigLogin() (err error) {
+	color.Cyan("Kattis uses the username, the token and the URLs from %v", c.rcPath)
+	color.Cyan("Download your .kattisrc from the judge (e.g. https://open.kattis.com/download/kattisrc) and save it there")
+	if c.rc, c.rcErr = LoadRC(c.rcPath); c.rcErr != nil {
+		return c.rcErr
+	}
+	color.Green("Current user: %v (%v)", c.rc.Username, c.Host())
+	return c.Login()
+}
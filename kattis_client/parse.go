@@ -0,0 +1,118 @@
+package kattis_client
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Arapak/sio-tool/database_client"
+	"github.com/Arapak/sio-tool/util"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/fatih/color"
+	"github.com/k0kubun/go-ansi"
+)
+
+func findName(body []byte) (string, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(doc.Find("h1").First().Text()), nil
+}
+
+func (c *KattisClient) getSamples(info Info) ([]byte, error) {
+	URL, err := info.SamplesURL(c.Host())
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Get(URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	return checkResponse(resp)
+}
+
+func (c *KattisClient) Parse(info Info, db *sql.DB) (problems []string, paths []string, err error) {
+	color.Cyan("Parse " + info.Hint())
+
+	if c.rcErr != nil {
+		return nil, nil, c.rcErr
+	}
+	start := time.Now()
+	var perf util.Performance
+
+	URL, err := info.ProblemURL(c.Host())
+	if err != nil {
+		return
+	}
+
+	perf.StartFetching()
+
+	body, err := util.GetBody(c.client, URL)
+	if err != nil {
+		return
+	}
+	samples, err := c.getSamples(info)
+	if err != nil {
+		return
+	}
+
+	perf.StopFetching()
+	perf.StartParsing()
+
+	name, err := findName(body)
+	if err != nil {
+		return
+	}
+	var input, output [][]byte
+	if samples != nil {
+		if input, output, err = util.SamplesFromZip(samples); err != nil {
+			return
+		}
+	}
+
+	perf.StopParsing()
+
+	problemPath := info.Path()
+	_, _ = ansi.Printf(color.CyanString("The problem will be saved to %v\n"), color.GreenString(problemPath))
+	if err = os.MkdirAll(problemPath, os.ModePerm); err != nil {
+		return
+	}
+	for i := 0; i < len(input); i++ {
+		fileIn := filepath.Join(problemPath, fmt.Sprintf("in%v.txt", i+1))
+		fileOut := filepath.Join(problemPath, fmt.Sprintf("out%v.txt", i+1))
+		if e := os.WriteFile(fileIn, input[i], 0644); e != nil {
+			color.Red(e.Error())
+		}
+		if e := os.WriteFile(fileOut, output[i], 0644); e != nil {
+			color.Red(e.Error())
+		}
+	}
+	if samples == nil {
+		color.Yellow("The problem has no samples to download.")
+	}
+	color.Green("Parsed %v. %v with %v samples.", info.ProblemID, name, len(input))
+	task := database_client.Task{
+		Name:      name,
+		Source:    "kattis",
+		Path:      problemPath,
+		ShortName: info.ProblemID,
+		Link:      URL,
+	}
+	if err = database_client.AddTask(db, task); err != nil {
+		color.Red(err.Error())
+	}
+	fmt.Printf("Parsing: (%v)\n", perf.Parse())
+	fmt.Printf("Total: %s\n", time.Since(start).Round(time.Millisecond))
+	return []string{info.ProblemID}, []string{problemPath}, nil
+}
@@ -0,0 +1,20 @@
+package kattis_client
+
+import (
+	"errors"
+
+	"github.com/Arapak/sio-tool/util"
+)
+
+const ErrorKattisIsUnavailable = "kattis is unavailable (check your internet connection)"
+
+func (c *KattisClient) Ping() (err error) {
+	if c.rcErr != nil {
+		return c.rcErr
+	}
+	_, err = util.GetBody(c.client, c.Host())
+	if err != nil {
+		return errors.New(ErrorKattisIsUnavailable)
+	}
+	return
+}
@@ -0,0 +1,94 @@
+package kattis_client
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/Arapak/sio-tool/site"
+	"github.com/Arapak/sio-tool/util"
+)
+
+// judge implements site.Site for the Kattis judge of .kattisrc.
+type judge struct {
+	site.Judge
+	c *KattisClient
+}
+
+func (c *KattisClient) Site() site.Site {
+	return judge{site.Judge{
+		Session:        c,
+		ID:             "kattis",
+		HostOf:         func() string { return c.Host() },
+		UsernameOf:     func() string { return c.Username() },
+		ErrorNotLogged: ErrorNotLogged,
+	}, c}
+}
+
+func kattisInfo(info site.Info) (*Info, error) {
+	if i, ok := info.(*Info); ok {
+		return i, nil
+	}
+	return nil, errors.New(site.ErrorWrongInfo)
+}
+
+func (j judge) Parse(info site.Info, db *sql.DB) (paths []string, err error) {
+	i, err := kattisInfo(info)
+	if err != nil {
+		return
+	}
+	_, paths, err = j.c.Parse(*i, db)
+	return
+}
+
+func (j judge) Submit(info site.Info, options site.SubmitOptions, sourcePath string, db *sql.DB) error {
+	i, err := kattisInfo(info)
+	if err != nil {
+		return err
+	}
+	if options.Kind != "" || options.User != "" {
+		return errors.New(site.ErrorNotSupported)
+	}
+	return j.c.Submit(*i, options.Lang, sourcePath, db)
+}
+
+// Watch watches a single submission, Kattis doesn't list the submissions to scripts.
+func (j judge) Watch(info site.Info, n int, line bool) error {
+	i, err := kattisInfo(info)
+	if err != nil {
+		return err
+	}
+	_, err = j.c.WatchSubmission(*i, line)
+	return err
+}
+
+func (j judge) Statis(info site.Info) (header []string, problems []site.Problem, perf util.Performance, err error) {
+	err = errors.New(site.ErrorNotSupported)
+	return
+}
+
+func (j judge) OpenURL(info site.Info) (string, error) {
+	i, err := kattisInfo(info)
+	if err != nil {
+		return "", err
+	}
+	return i.OpenURL(j.c.Host())
+}
+
+func (j judge) StandingsURL(info site.Info) (string, error) {
+	return "", errors.New(site.ErrorNotSupported)
+}
+
+func (j judge) SubmissionURL(info site.Info) (string, error) {
+	i, err := kattisInfo(info)
+	if err != nil {
+		return "", err
+	}
+	if i.SubmissionID == "" && j.c.LastSubmission != nil {
+		i = j.c.LastSubmission
+	}
+	return i.SubmissionURL(j.c.rc.SubmissionsURL)
+}
+
+func (j judge) Race(info site.Info) ([]string, error) {
+	return nil, errors.New(site.ErrorNotSupported)
+}
@@ -0,0 +1,144 @@
+package kattis_client
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Arapak/sio-tool/account"
+	"github.com/Arapak/sio-tool/database_client"
+	"github.com/Arapak/sio-tool/util"
+
+	"github.com/fatih/color"
+)
+
+// kattisLanguages are the names Kattis uses for the languages of the templates.
+var kattisLanguages = map[string]string{
+	"C++":    "C++",
+	"C":      "C",
+	"Pascal": "Pascal",
+	"Python": "Python 3",
+	"Java":   "Java",
+	"Rust":   "Rust",
+}
+
+func chooseLanguage(lang, sourcePath string) (language, mainClass string, err error) {
+	family := util.LanguageFamily(lang, sourcePath)
+	language, ok := kattisLanguages[family]
+	if !ok {
+		return "", "", fmt.Errorf("cannot find the Kattis language of %v", filepath.Base(sourcePath))
+	}
+	switch language {
+	case "Java":
+		mainClass = strings.TrimSuffix(filepath.Base(sourcePath), filepath.Ext(sourcePath))
+	case "Python 3":
+		mainClass = filepath.Base(sourcePath)
+	}
+	return
+}
+
+var submissionIDReg = regexp.MustCompile(`Submission ID: (\d+)`)
+
+// findSubmissionID reads the ID from the answer of the judge to a submission,
+// any other answer is the reason why the submission was rejected.
+func findSubmissionID(body []byte) (string, error) {
+	id := submissionIDReg.FindSubmatch(body)
+	if id == nil {
+		return "", errors.New(strings.TrimSpace(string(body)))
+	}
+	return string(id[1]), nil
+}
+
+func (c *KattisClient) Submit(info Info, lang, sourcePath string, db *sql.DB) (err error) {
+	color.Cyan("Submit " + info.Hint())
+
+	if c.rcErr != nil {
+		return c.rcErr
+	}
+	if info.ProblemID == "" {
+		return errors.New(ErrorNeedProblemID)
+	}
+
+	fmt.Printf("Current user: %v\n", account.Label(c.path, c.rc.Username))
+
+	check := database_client.Submission{Judge: judgeName, ShortName: info.ProblemID}
+	if err = database_client.CheckSubmission(db, check, sourcePath, database_client.UnknownLimit); err != nil {
+		return
+	}
+
+	language, mainClass, err := chooseLanguage(lang, sourcePath)
+	if err != nil {
+		return
+	}
+
+	sourceFile, err := os.Open(sourcePath)
+	if err != nil {
+		return err
+	}
+	defer sourceFile.Close()
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for key, value := range map[string]string{
+		"submit":     "true",
+		"submit_ctr": "2",
+		"language":   language,
+		"mainclass":  mainClass,
+		"problem":    info.ProblemID,
+		"tag":        "",
+		"script":     "true",
+	} {
+		if err = writer.WriteField(key, value); err != nil {
+			return
+		}
+	}
+	part, err := writer.CreateFormFile("sub_file[]", filepath.Base(sourcePath))
+	if err != nil {
+		return
+	}
+	if _, err = io.Copy(part, sourceFile); err != nil {
+		return
+	}
+	if err = writer.Close(); err != nil {
+		return
+	}
+
+	req, err := http.NewRequest("POST", c.rc.SubmissionURL, body)
+	if err != nil {
+		return
+	}
+	req.Header.Add("Content-Type", writer.FormDataContentType())
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := checkResponse(resp)
+	if err != nil {
+		return
+	}
+
+	if info.SubmissionID, err = findSubmissionID(responseBody); err != nil {
+		return
+	}
+	color.Green("Submitted")
+
+	submission, err := c.WatchSubmission(info, true)
+	if err != nil {
+		return
+	}
+
+	c.LastSubmission = &info
+	c.recordSubmission(db, info, submission, sourcePath)
+	return c.save()
+}
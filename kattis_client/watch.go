@@ -0,0 +1,162 @@
+package kattis_client
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Arapak/sio-tool/util"
+
+	"github.com/fatih/color"
+	"github.com/k0kubun/go-ansi"
+)
+
+const acceptedStatus = 16
+
+// statuses are the names of the status IDs Kattis sends to scripts.
+var statuses = map[int]string{
+	0:  "New",
+	1:  "New",
+	2:  "Waiting for compile",
+	3:  "Compiling",
+	4:  "Waiting for run",
+	5:  "Running",
+	6:  "Judge Error",
+	7:  "Submission Error",
+	8:  "Compile Error",
+	9:  "Run Time Error",
+	10: "Memory Limit Exceeded",
+	11: "Output Limit Exceeded",
+	12: "Time Limit Exceeded",
+	13: "Illegal Function",
+	14: "Wrong Answer",
+	16: "Accepted",
+}
+
+type Submission struct {
+	id       string
+	problem  string
+	statusID int
+	testcase int
+}
+
+func (s *Submission) ParseID() string {
+	return s.id
+}
+
+func (s *Submission) Name() string {
+	return s.problem
+}
+
+// End reports whether the submission has been judged.
+func (s *Submission) End() bool {
+	return s.statusID > 5
+}
+
+func (s *Submission) Accepted() bool {
+	return s.statusID == acceptedStatus
+}
+
+// PlainStatus returns the status without colors.
+func (s *Submission) PlainStatus() string {
+	status, ok := statuses[s.statusID]
+	if !ok {
+		status = fmt.Sprintf("Unknown status %v", s.statusID)
+	}
+	if s.statusID == 5 && s.testcase > 0 {
+		status = fmt.Sprintf("%v on test %v", status, s.testcase)
+	}
+	return status
+}
+
+func (s *Submission) ParseStatus() string {
+	if !s.End() {
+		return color.New(color.FgWhite).Sprint(s.PlainStatus())
+	} else if s.Accepted() {
+		return color.New(color.FgGreen).Sprint(s.PlainStatus())
+	}
+	return color.New(color.FgRed).Sprint(s.PlainStatus())
+}
+
+func (s *Submission) display(first bool, maxWidth *int) {
+	if !first {
+		ansi.CursorUp(3)
+	}
+	_, _ = ansi.Printf("      #: %v\n", s.ParseID())
+	_, _ = ansi.Printf("   prob: %v\n", s.problem)
+	_, _ = ansi.Printf("%v\n", strings.Repeat(" ", *maxWidth))
+	ansi.CursorUp(1)
+	line := fmt.Sprintf(" status: %v", s.ParseStatus())
+	*maxWidth = len(line)
+	_, _ = ansi.Printf("%v\n", line)
+}
+
+func (c *KattisClient) getSubmission(info Info) (submission Submission, err error) {
+	URL, err := info.SubmissionURL(c.rc.SubmissionsURL)
+	if err != nil {
+		return
+	}
+	resp, err := c.client.Get(URL + "?json")
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	body, err := checkResponse(resp)
+	if err != nil {
+		return
+	}
+	var status struct {
+		StatusID      int `json:"status_id"`
+		TestcaseIndex int `json:"testcase_index"`
+	}
+	if err = json.Unmarshal(body, &status); err != nil {
+		return
+	}
+	return Submission{
+		id:       info.SubmissionID,
+		problem:  info.ProblemID,
+		statusID: status.StatusID,
+		testcase: status.TestcaseIndex,
+	}, nil
+}
+
+// WatchSubmission shows the status of the submission until it is judged. Kattis tells scripts
+// only about single submissions, so the last submission is watched when info has no submission.
+func (c *KattisClient) WatchSubmission(info Info, line bool) (submission Submission, err error) {
+	if c.rcErr != nil {
+		return submission, c.rcErr
+	}
+	if info.SubmissionID == "" && c.LastSubmission != nil {
+		info = *c.LastSubmission
+	}
+	color.Cyan("Watch " + info.Hint())
+
+	maxWidth := 0
+	first := true
+	poller := util.NewPoller()
+	previous := ""
+	for {
+		submission, err = c.getSubmission(info)
+		if err != nil {
+			if poller.Backoff(err) {
+				if err = poller.Wait(); err != nil {
+					return
+				}
+				continue
+			}
+			return
+		}
+		submission.display(first, &maxWidth)
+		first = false
+		if submission.End() {
+			return
+		}
+		if state := submission.PlainStatus(); state != previous {
+			previous = state
+			poller.Reset()
+		}
+		if err = poller.Wait(); err != nil {
+			return
+		}
+	}
+}
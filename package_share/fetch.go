@@ -0,0 +1,74 @@
+package package_share
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Arapak/sio-tool/util"
+)
+
+const ErrorNoSharedPackages = "no shared packages found for this task"
+
+func FetchIndex(client *http.Client, host string) (index Index, err error) {
+	body, err := util.GetBody(client, strings.TrimSuffix(host, "/")+IndexURL)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &index)
+	return
+}
+
+func downloadFile(client *http.Client, URL, path string) (err error) {
+	resp, err := client.Get(URL)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetching %v failed: %v", URL, resp.Status)
+	}
+	err = os.MkdirAll(filepath.Dir(path), os.ModePerm)
+	if err != nil {
+		return
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	_, err = io.Copy(file, resp.Body)
+	return
+}
+
+// Fetch downloads every package shared for the given task, nextDestination
+// is called once per package and should return a free folder for it.
+func Fetch(client *http.Client, host, task string, nextDestination func() string) (fetched []Package, err error) {
+	index, err := FetchIndex(client, host)
+	if err != nil {
+		return
+	}
+	packages := index.Find(task)
+	if len(packages) == 0 {
+		return nil, errors.New(ErrorNoSharedPackages)
+	}
+	for _, p := range packages {
+		destination := nextDestination()
+		for _, file := range p.Files {
+			path := filepath.Join(destination, filepath.FromSlash(file))
+			if !strings.HasPrefix(path, filepath.Clean(destination)+string(os.PathSeparator)) {
+				return fetched, fmt.Errorf("invalid file name in package %v: %v", p.Name, file)
+			}
+			if err = downloadFile(client, p.FileURL(host, file), path); err != nil {
+				return
+			}
+		}
+		fetched = append(fetched, p)
+	}
+	return
+}
@@ -0,0 +1,90 @@
+package package_share
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// taskDepth is the number of path elements between the packages root and a
+// task folder (source/contest/round/alias), it matches Info.PackagePath().
+const taskDepth = 4
+
+const IndexURL = "/index.json"
+const PackagesURL = "/packages/"
+
+type Package struct {
+	Task  string   `json:"task"`
+	Name  string   `json:"name"`
+	Files []string `json:"files"`
+}
+
+type Index struct {
+	Packages []Package `json:"packages"`
+}
+
+// FileURL returns the URL of a file of the package, every path element is escaped
+// as rounds and files may contain spaces, "#" or "?".
+func (p *Package) FileURL(host, file string) string {
+	var elements []string
+	for _, element := range append(splitPath(p.Task), append([]string{p.Name}, splitPath(file)...)...) {
+		elements = append(elements, url.PathEscape(element))
+	}
+	return strings.TrimSuffix(host, "/") + PackagesURL + strings.Join(elements, "/")
+}
+
+func splitPath(path string) []string {
+	return strings.Split(filepath.ToSlash(path), "/")
+}
+
+func BuildIndex(root string) (index Index, err error) {
+	packages := map[string]*Package{}
+	var order []string
+	err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		parts := splitPath(rel)
+		if len(parts) <= taskDepth+1 {
+			return nil
+		}
+		task := strings.Join(parts[:taskDepth], "/")
+		name := parts[taskDepth]
+		key := task + "/" + name
+		p, ok := packages[key]
+		if !ok {
+			p = &Package{Task: task, Name: name}
+			packages[key] = p
+			order = append(order, key)
+		}
+		p.Files = append(p.Files, strings.Join(parts[taskDepth+1:], "/"))
+		return nil
+	})
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	for _, key := range order {
+		index.Packages = append(index.Packages, *packages[key])
+	}
+	return
+}
+
+func (index *Index) Find(task string) (ret []Package) {
+	for _, p := range index.Packages {
+		if p.Task == task {
+			ret = append(ret, p)
+		}
+	}
+	return
+}
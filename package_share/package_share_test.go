@@ -0,0 +1,62 @@
+package package_share
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestServeAndFetch(t *testing.T) {
+	root := t.TempDir()
+	task := "sio-staszic/contest/Runda #1?/abc"
+	files := map[string]string{
+		"0/in/abc1.in":   "1 2\n",
+		"0/out/abc1.out": "3\n",
+		"1/abc 2%.in":    "5 5\n",
+		"1/abc 2%.out":   "10\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(task), filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	server := httptest.NewServer(Handler(root))
+	defer server.Close()
+
+	destination := t.TempDir()
+	// destinations are the folders handed out, the i-th one is the folder of the i-th fetched package
+	var destinations []string
+	fetched, err := Fetch(server.Client(), server.URL, task, func() string {
+		path := filepath.Join(destination, "package"+strconv.Itoa(len(destinations)+1))
+		destinations = append(destinations, path)
+		return path
+	})
+	if err != nil {
+		t.Fatalf("Fetch returned an error: %v", err)
+	}
+	if len(fetched) != 2 || len(destinations) != len(fetched) {
+		t.Fatalf("Expect 2 packages in 2 folders, but found %v in %v.", len(fetched), len(destinations))
+	}
+	for i, p := range fetched {
+		for _, file := range p.Files {
+			content, err := os.ReadFile(filepath.Join(destinations[i], filepath.FromSlash(file)))
+			if err != nil {
+				t.Fatalf("Fetched file is missing: %v", err)
+			}
+			if expect := files[p.Name+"/"+file]; string(content) != expect {
+				t.Errorf("Expect %q, but found %q.", expect, string(content))
+			}
+		}
+	}
+
+	if _, err = Fetch(server.Client(), server.URL, "sio-staszic/contest/Runda #1?/xyz", func() string { return destination }); err == nil || err.Error() != ErrorNoSharedPackages {
+		t.Errorf("Expect %q error, but found %v.", ErrorNoSharedPackages, err)
+	}
+}
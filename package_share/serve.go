@@ -0,0 +1,33 @@
+package package_share
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/fatih/color"
+)
+
+func indexHandler(root string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		index, err := BuildIndex(root)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(index)
+	}
+}
+
+func Handler(root string) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(IndexURL, indexHandler(root))
+	mux.Handle(PackagesURL, http.StripPrefix(PackagesURL, http.FileServer(http.Dir(root))))
+	return mux
+}
+
+func Serve(root, addr string) error {
+	color.Cyan("Serving packages from %v", root)
+	color.Green("Index available at http://%v%v", addr, IndexURL)
+	return http.ListenAndServe(addr, Handler(root))
+}
@@ -0,0 +1,129 @@
+// Package replay records the responses of the judges into fixture files and plays them back,
+// so the scraping code can be tested offline against real pages.
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// RecordEnv and ReplayEnv name the directories st records the responses to and replays them from.
+const RecordEnv = "ST_RECORD"
+const ReplayEnv = "ST_REPLAY"
+
+// RecordFixturesEnv makes the tests fetch the live pages and record them as their fixtures again.
+const RecordFixturesEnv = "ST_RECORD_FIXTURES"
+
+// recordedHeaders are the headers kept in the fixtures, cookies are left out on purpose.
+var recordedHeaders = []string{"Content-Type", "Location", "Retry-After"}
+
+// Transport plays the responses back from the fixtures in Dir, or, when Record is set,
+// sends the requests with Base and saves the responses to Dir.
+type Transport struct {
+	Dir    string
+	Record bool
+	Base   http.RoundTripper
+}
+
+var unsafeChars = regexp.MustCompile(`[^\w.\-/]`)
+
+// FixturePath returns the file of the response to req: the host and the path of the URL,
+// with the method and a hash of the query and of the form appended when there are any.
+func (t *Transport) FixturePath(req *http.Request) string {
+	name := strings.Trim(req.URL.Path, "/")
+	if name == "" {
+		name = "index"
+	}
+	name = unsafeChars.ReplaceAllString(req.URL.Host+"/"+name, "_")
+	name = strings.ReplaceAll(name, "..", "_")
+	if req.Method != "GET" {
+		name += "." + req.Method
+	}
+	if key := req.URL.RawQuery + requestBody(req); key != "" {
+		hash := sha256.Sum256([]byte(key))
+		name += "_" + hex.EncodeToString(hash[:4])
+	}
+	return filepath.Join(t.Dir, filepath.FromSlash(name)+".http")
+}
+
+// requestBody returns the body of a form, which tells the requests to the same URL apart.
+// The bodies of the other requests (e.g. files being submitted) are left out.
+func requestBody(req *http.Request) string {
+	if req.GetBody == nil || req.Header.Get("Content-Type") != "application/x-www-form-urlencoded" {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	data, _ := io.ReadAll(body)
+	return "\n" + string(data)
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := t.FixturePath(req)
+	if !t.Record {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("no fixture for %v %v (record it with %v): %v", req.Method, req.URL, RecordEnv, err)
+		}
+		return http.ReadResponse(bufio.NewReader(bytes.NewReader(data)), req)
+	}
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err = save(path, resp, body); err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+func save(path string, resp *http.Response, body []byte) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "HTTP/1.1 %v\r\n", resp.Status)
+	for _, key := range recordedHeaders {
+		for _, value := range resp.Header.Values(key) {
+			fmt.Fprintf(&buf, "%v: %v\r\n", key, value)
+		}
+	}
+	fmt.Fprintf(&buf, "Content-Length: %v\r\n\r\n", len(body))
+	buf.Write(body)
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// Wrap returns base, or a Transport recording to or replaying from the directory
+// set in ST_RECORD or ST_REPLAY. Every client wraps its transport with it.
+func Wrap(base http.RoundTripper) http.RoundTripper {
+	if dir := os.Getenv(ReplayEnv); dir != "" {
+		return &Transport{Dir: dir, Base: base}
+	}
+	if dir := os.Getenv(RecordEnv); dir != "" {
+		return &Transport{Dir: dir, Record: true, Base: base}
+	}
+	return base
+}
@@ -0,0 +1,53 @@
+package replay
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestRecordReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "secret"})
+		fmt.Fprintf(w, "%v %v %v", r.Method, r.URL.Path, r.Form.Get("q"))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	requests := []func(c *http.Client) (*http.Response, error){
+		func(c *http.Client) (*http.Response, error) { return c.Get(server.URL + "/a") },
+		func(c *http.Client) (*http.Response, error) { return c.Get(server.URL + "/a?q=1") },
+		func(c *http.Client) (*http.Response, error) {
+			return c.PostForm(server.URL+"/a", url.Values{"q": {"2"}})
+		},
+	}
+	want := []string{"GET /a ", "GET /a 1", "POST /a 2"}
+
+	for _, record := range []bool{true, false} {
+		c := &http.Client{Transport: &Transport{Dir: dir, Record: record}}
+		for i, request := range requests {
+			resp, err := request(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if string(body) != want[i] {
+				t.Errorf("Expect %q, but found %q (record %v).", want[i], body, record)
+			}
+			if !record && len(resp.Cookies()) != 0 {
+				t.Errorf("Expect no cookies in the fixtures, but found %v.", resp.Cookies())
+			}
+		}
+	}
+
+	c := &http.Client{Transport: &Transport{Dir: dir}}
+	if _, err := c.Get(server.URL + "/b"); err == nil || !strings.Contains(err.Error(), "no fixture") {
+		t.Errorf("Expect a missing fixture, but found %v.", err)
+	}
+}
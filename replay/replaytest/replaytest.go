@@ -0,0 +1,158 @@
+// Package replaytest holds the helpers the tests of the clients read their fixtures with,
+// recorded and replayed by the replay transport.
+package replaytest
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Arapak/sio-tool/replay"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// FixturesDir is the folder with the fixtures of a client, relative to the folder of its tests.
+// The pages of a logged user are kept in it and the pages of a visitor in its subfolder "anonymous".
+const FixturesDir = "assets/fixtures"
+
+// userFile keeps the name of the user the fixtures were recorded as.
+const userFile = "user"
+
+// Session is set by the tests of a client to record its fixtures as a logged user. It returns
+// the client of the session st uses, logged in again with the stored credentials when the
+// session has expired, and the name of the user.
+var Session func() (client *http.Client, user string, err error)
+
+var recorder struct {
+	sync.Mutex
+	client *http.Client
+}
+
+// Recording tells if the tests record their fixtures again (ST_RECORD_FIXTURES is set).
+func Recording() bool {
+	return os.Getenv(replay.RecordFixturesEnv) != ""
+}
+
+// FixtureClient returns the client the tests read the pages of a logged user with. It replays
+// FixturesDir, or, when ST_RECORD_FIXTURES is set, records it again with the client of Session.
+func FixtureClient(t testing.TB) *http.Client {
+	if !Recording() {
+		return &http.Client{Transport: &replay.Transport{Dir: FixturesDir}}
+	}
+	recorder.Lock()
+	defer recorder.Unlock()
+	if recorder.client == nil {
+		if Session == nil {
+			t.Fatal("the tests don't set replaytest.Session, so they cannot log in to record the fixtures")
+		}
+		client, user, err := Session()
+		if err != nil {
+			t.Fatalf("cannot log in to record the fixtures: %v", err)
+		}
+		if err = os.MkdirAll(FixturesDir, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err = os.WriteFile(filepath.Join(FixturesDir, userFile), []byte(user+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		recording := *client
+		recording.Transport = &replay.Transport{Dir: FixturesDir, Record: true, Base: client.Transport}
+		recorder.client = &recording
+	}
+	return recorder.client
+}
+
+// AnonymousClient returns the client the tests read the pages of a visitor who isn't logged in with,
+// e.g. the login page. It records them without any cookies when ST_RECORD_FIXTURES is set.
+func AnonymousClient() *http.Client {
+	return &http.Client{Transport: &replay.Transport{Dir: filepath.Join(FixturesDir, "anonymous"), Record: Recording()}}
+}
+
+func read(t testing.TB, client *http.Client, URL string) []byte {
+	t.Helper()
+	resp, err := client.Get(URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+// Fixture returns the page at URL as a logged user sees it.
+func Fixture(t testing.TB, URL string) []byte {
+	t.Helper()
+	return read(t, FixtureClient(t), URL)
+}
+
+// AnonymousFixture returns the page at URL as a visitor who isn't logged in sees it.
+func AnonymousFixture(t testing.TB, URL string) []byte {
+	t.Helper()
+	return read(t, AnonymousClient(), URL)
+}
+
+// User returns the user the fixtures were recorded as.
+func User(t testing.TB) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(FixturesDir, userFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// Document parses a page, so that the tests can read what they expect from it.
+func Document(t testing.TB, body []byte) *goquery.Document {
+	t.Helper()
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+// Select returns the attribute attr, or the text when attr is empty, of the first element
+// of the page matching selector, or an empty string when there is no such element.
+func Select(t testing.TB, body []byte, selector, attr string) string {
+	t.Helper()
+	s := Document(t, body).Find(selector).First()
+	if attr == "" {
+		return strings.TrimSpace(s.Text())
+	}
+	value, _ := s.Attr(attr)
+	return value
+}
+
+// StringTest checks that Find finds Want in Page. An empty Want means that the page
+// has nothing to find and Find has to return an error.
+type StringTest struct {
+	Name string
+	Page []byte
+	Find func([]byte) (string, error)
+	Want string
+}
+
+// TestStrings runs the tests of the functions finding a string in a page.
+func TestStrings(t *testing.T, tests []StringTest) {
+	t.Helper()
+	for _, test := range tests {
+		got, err := test.Find(test.Page)
+		switch {
+		case test.Want == "" && err == nil:
+			t.Errorf("%v: expect nothing, but found %q.", test.Name, got)
+		case test.Want != "" && err != nil:
+			t.Errorf("%v: %v", test.Name, err)
+		case got != test.Want:
+			t.Errorf("%v: expect %q, but found %q.", test.Name, test.Want, got)
+		}
+	}
+}
@@ -0,0 +1,331 @@
+package sio_client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/Arapak/sio-tool/util"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/fatih/color"
+)
+
+const ErrorRoundNotFound = "round not found"
+const ErrorFormNotFound = "form not found"
+const ErrorRejudgeNotFound = "the problem has no rejudge link, you have to be an admin of the contest"
+
+type ProblemInstance struct {
+	ID               string
+	Name             string
+	Alias            string
+	Round            string
+	SubmissionsLimit string
+	// Rejudge is the href of the rejudge link in the actions column of the problem list.
+	Rejudge string
+}
+
+// formValues collects the values the browser would send when submitting the form.
+func formValues(form *goquery.Selection) url.Values {
+	values := url.Values{}
+	form.Find("input").Each(func(_ int, s *goquery.Selection) {
+		name, ok := s.Attr("name")
+		if !ok || name == "" {
+			return
+		}
+		switch strings.ToLower(s.AttrOr("type", "text")) {
+		case "submit", "button", "file", "image", "reset":
+			return
+		case "checkbox", "radio":
+			if _, checked := s.Attr("checked"); !checked {
+				return
+			}
+			values.Add(name, s.AttrOr("value", "on"))
+		default:
+			values.Add(name, s.AttrOr("value", ""))
+		}
+	})
+	form.Find("select").Each(func(_ int, s *goquery.Selection) {
+		name, ok := s.Attr("name")
+		if !ok || name == "" {
+			return
+		}
+		selected := s.Find("option[selected]")
+		if selected.Length() == 0 {
+			if _, multiple := s.Attr("multiple"); multiple {
+				return
+			}
+			selected = s.Find("option").First()
+		}
+		selected.Each(func(_ int, option *goquery.Selection) {
+			values.Add(name, option.AttrOr("value", strings.TrimSpace(option.Text())))
+		})
+	})
+	form.Find("textarea").Each(func(_ int, s *goquery.Selection) {
+		name, ok := s.Attr("name")
+		if !ok || name == "" {
+			return
+		}
+		values.Add(name, s.Text())
+	})
+	return values
+}
+
+type selectOption struct {
+	Value string
+	Text  string
+}
+
+func selectOptions(doc *goquery.Document, selector string) (options []selectOption) {
+	doc.Find(selector).First().Find("option").Each(func(_ int, s *goquery.Selection) {
+		options = append(options, selectOption{Value: s.AttrOr("value", ""), Text: strings.TrimSpace(s.Text())})
+	})
+	return
+}
+
+func getFormErrors(body []byte) error {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(body)))
+	if err != nil {
+		return err
+	}
+	var messages []string
+	doc.Find(".errornote, .errorlist li").Each(func(_ int, s *goquery.Selection) {
+		if text := strings.TrimSpace(s.Text()); text != "" {
+			messages = append(messages, text)
+		}
+	})
+	if len(messages) > 0 {
+		return errors.New(strings.Join(messages, "; "))
+	}
+	return nil
+}
+
+// postForm sends the form and returns the page it answers with. An error is returned when the
+// session expired or the post was refused, so that the form errors are never read from such a page.
+func (c *SioClient) postForm(URL, referer string, values url.Values) (body []byte, err error) {
+	req, err := http.NewRequest("POST", URL, strings.NewReader(values.Encode()))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Referer", referer)
+	req.Header.Set("Origin", c.host)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("%v: %v", URL, resp.Status)
+	}
+	if body, err = io.ReadAll(resp.Body); err != nil {
+		return
+	}
+	if isLoginPage, _ := regexp.Match(LoginPageRegExp, body); isLoginPage {
+		return nil, errors.New(ErrorNotLogged)
+	}
+	return
+}
+
+func (c *SioClient) getChangeForm(URL string) (doc *goquery.Document, values url.Values, err error) {
+	body, err := util.GetBody(c.client, URL)
+	if err != nil {
+		return
+	}
+	if _, err = findUsername(body); err != nil {
+		return
+	}
+	doc, err = goquery.NewDocumentFromReader(strings.NewReader(string(body)))
+	if err != nil {
+		return
+	}
+	form := doc.Find("form#probleminstance_form")
+	if form.Length() == 0 {
+		form = doc.Find("#content-main form").First()
+	}
+	if form.Length() == 0 {
+		err = errors.New(ErrorFormNotFound)
+		return
+	}
+	values = formValues(form)
+	return
+}
+
+func (c *SioClient) problemInstanceDetails(info Info, p *ProblemInstance) (err error) {
+	URL, err := info.ProblemInstanceChangeURL(c.host, p.ID)
+	if err != nil {
+		return
+	}
+	_, values, err := c.getChangeForm(URL)
+	if err != nil {
+		return
+	}
+	p.SubmissionsLimit = values.Get("submissions_limit")
+	return
+}
+
+func (c *SioClient) ListProblemInstances(info Info) (instances []ProblemInstance, perf util.Performance, err error) {
+	packages, perf, err := c.FindAllPackages(info)
+	if err != nil {
+		return
+	}
+	for _, p := range packages {
+		if info.ProblemAlias != "" && p.Alias != info.ProblemAlias {
+			continue
+		}
+		if info.Round != "" && p.Round != info.Round && clearString(p.Round) != clearString(info.Round) {
+			continue
+		}
+		instances = append(instances, ProblemInstance{ID: p.ProblemId, Name: p.Name, Alias: p.Alias, Round: p.Round})
+	}
+
+	perf.StartFetching()
+	const numberOfWorkers = 10
+	wg := sync.WaitGroup{}
+	wg.Add(numberOfWorkers)
+	mu := sync.Mutex{}
+	index := 0
+	for i := 1; i <= numberOfWorkers; i++ {
+		go func(workerID int) {
+			defer wg.Done()
+			for {
+				mu.Lock()
+				if index >= len(instances) {
+					mu.Unlock()
+					return
+				}
+				current := index
+				index++
+				mu.Unlock()
+				if e := c.problemInstanceDetails(info, &instances[current]); e != nil {
+					mu.Lock()
+					color.Red("Couldn't fetch details of %v: %v", instances[current].Alias, e.Error())
+					mu.Unlock()
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	perf.StopFetching()
+	return
+}
+
+func (c *SioClient) findProblemInstance(info Info) (instance ProblemInstance, err error) {
+	if info.ProblemAlias == "" {
+		err = errors.New(ErrorNeedProblemAlias)
+		return
+	}
+	packages, _, err := c.FindAllPackages(info)
+	if err != nil {
+		return
+	}
+	for _, p := range packages {
+		if p.Alias == info.ProblemAlias {
+			return ProblemInstance{ID: p.ProblemId, Name: p.Name, Alias: p.Alias, Round: p.Round, Rejudge: p.Rejudge}, nil
+		}
+	}
+	err = errors.New(ErrorProblemNotFound)
+	return
+}
+
+func (c *SioClient) changeProblemInstance(info Info, change func(doc *goquery.Document, values url.Values) error) (instance ProblemInstance, err error) {
+	instance, err = c.findProblemInstance(info)
+	if err != nil {
+		return
+	}
+	URL, err := info.ProblemInstanceChangeURL(c.host, instance.ID)
+	if err != nil {
+		return
+	}
+	doc, values, err := c.getChangeForm(URL)
+	if err != nil {
+		return
+	}
+	if err = change(doc, values); err != nil {
+		return
+	}
+	values.Set("_save", "Save")
+	body, err := c.postForm(URL, URL, values)
+	if err != nil {
+		return
+	}
+	err = getFormErrors(body)
+	return
+}
+
+func (c *SioClient) MoveProblemInstance(info Info, round string) (err error) {
+	instance, err := c.changeProblemInstance(info, func(doc *goquery.Document, values url.Values) error {
+		for _, option := range selectOptions(doc, "select#id_round") {
+			if option.Value == "" {
+				continue
+			}
+			if option.Text == round || clearString(option.Text) == clearString(round) {
+				values.Set("round", option.Value)
+				return nil
+			}
+		}
+		return errors.New(ErrorRoundNotFound)
+	})
+	if err == nil {
+		color.Green("Moved %v (%v) from %v to %v", instance.Name, instance.Alias, instance.Round, round)
+	}
+	return
+}
+
+func (c *SioClient) RenameProblemInstance(info Info, shortName string) (err error) {
+	instance, err := c.changeProblemInstance(info, func(doc *goquery.Document, values url.Values) error {
+		values.Set("short_name", shortName)
+		return nil
+	})
+	if err == nil {
+		color.Green("Changed short name of %v from %v to %v", instance.Name, instance.Alias, shortName)
+	}
+	return
+}
+
+func (c *SioClient) RejudgeProblemInstance(info Info) (err error) {
+	instance, err := c.findProblemInstance(info)
+	if err != nil {
+		return
+	}
+	if instance.Rejudge == "" {
+		err = errors.New(ErrorRejudgeNotFound)
+		return
+	}
+	URL := c.host + instance.Rejudge
+	body, err := util.GetBody(c.client, URL)
+	if err != nil {
+		return
+	}
+	if _, err = findUsername(body); err != nil {
+		return
+	}
+	csrf, err := findCsrf(body)
+	if err != nil {
+		return
+	}
+	values := url.Values{}
+	values.Set("csrfmiddlewaretoken", csrf)
+	body, err = c.postForm(URL, URL, values)
+	if err != nil {
+		return
+	}
+	if err = getFormErrors(body); err != nil {
+		return
+	}
+	color.Green("Requested rejudge of all submissions for %v (%v)", instance.Name, instance.Alias)
+	return
+}
+
+func (info *Info) ProblemInstanceChangeURL(host, problemInstanceID string) (string, error) {
+	URL, err := info.ProblemInstanceURL(host)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%v/%v/change/", URL, problemInstanceID), nil
+}
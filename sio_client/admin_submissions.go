@@ -0,0 +1,309 @@
+package sio_client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Arapak/sio-tool/util"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/fatih/color"
+)
+
+const ErrorNoSubmissionsSelected = "no submissions match the given filters"
+
+const adminDateLayout = "2006-01-02 15:04:05"
+
+var problemAliasRegExp = regexp.MustCompile(`\(([^()]+)\)\s*$`)
+
+type AdminSubmission struct {
+	ID       string    `json:"id"`
+	User     string    `json:"user"`
+	FullName string    `json:"full_name"`
+	Date     string    `json:"date"`
+	When     time.Time `json:"-"`
+	Problem  string    `json:"problem"`
+	Alias    string    `json:"alias"`
+	Status   string    `json:"status"`
+	Score    string    `json:"score"`
+}
+
+type SubmissionFilter struct {
+	User   string
+	Alias  string
+	Status string
+	Since  time.Time
+	Until  time.Time
+}
+
+func (f SubmissionFilter) Empty() bool {
+	return f.User == "" && f.Alias == "" && f.Status == "" && f.Since.IsZero() && f.Until.IsZero()
+}
+
+func (f SubmissionFilter) Match(s AdminSubmission) bool {
+	if f.User != "" && !strings.EqualFold(s.User, f.User) {
+		return false
+	}
+	if f.Alias != "" && !strings.EqualFold(s.Alias, f.Alias) {
+		return false
+	}
+	if f.Status != "" && !strings.Contains(strings.ToLower(s.Status), strings.ToLower(f.Status)) {
+		return false
+	}
+	if !f.Since.IsZero() || !f.Until.IsZero() {
+		if s.When.IsZero() {
+			return false
+		}
+		if !f.Since.IsZero() && s.When.Before(f.Since) {
+			return false
+		}
+		if !f.Until.IsZero() && s.When.After(f.Until) {
+			return false
+		}
+	}
+	return true
+}
+
+func problemAlias(problem string) string {
+	if alias := problemAliasRegExp.FindStringSubmatch(problem); alias != nil {
+		return strings.TrimSpace(alias[1])
+	}
+	return clearString(problem)
+}
+
+func fieldText(row *goquery.Selection, field string) string {
+	return strings.TrimSpace(row.Find(".field-" + field).First().Text())
+}
+
+func findAdminSubmissions(doc *goquery.Document) (submissions []AdminSubmission) {
+	doc.Find("#result_list tbody tr").Each(func(_ int, row *goquery.Selection) {
+		id := row.Find("input.action-select").AttrOr("value", "")
+		if id == "" {
+			id = fieldText(row, "id")
+		}
+		s := AdminSubmission{
+			ID:       id,
+			User:     fieldText(row, "user_login"),
+			FullName: fieldText(row, "user_full_name"),
+			Date:     fieldText(row, "date"),
+			Problem:  fieldText(row, "problem_instance_display"),
+			Status:   fieldText(row, "status_display"),
+			Score:    fieldText(row, "score_display"),
+		}
+		s.Alias = problemAlias(s.Problem)
+		if when, err := time.ParseInLocation(adminDateLayout, s.Date, time.Local); err == nil {
+			s.When = when
+		}
+		submissions = append(submissions, s)
+	})
+	return
+}
+
+// findPages returns the values of the "p" query parameter of all pages of a changelist.
+// Depending on the Django version, pages are numbered from 0 or from 1.
+func findPages(doc *goquery.Document) (pages []string) {
+	offset, last := -1, 0
+	doc.Find(".paginator a, .paginator span.this-page").Each(func(_ int, s *goquery.Selection) {
+		number, err := strconv.Atoi(strings.TrimSpace(s.Text()))
+		if err != nil {
+			return
+		}
+		if number > last {
+			last = number
+		}
+		href, ok := s.Attr("href")
+		if !ok {
+			return
+		}
+		URL, err := url.Parse(href)
+		if err != nil {
+			return
+		}
+		if p, err := strconv.Atoi(URL.Query().Get("p")); err == nil {
+			offset = number - p
+		}
+	})
+	if offset < 0 {
+		return
+	}
+	for number := 1; number <= last; number++ {
+		pages = append(pages, strconv.Itoa(number-offset))
+	}
+	return
+}
+
+func (c *SioClient) getAdminSubmissionsPage(URL string) (doc *goquery.Document, err error) {
+	body, err := util.GetBody(c.client, URL)
+	if err != nil {
+		return
+	}
+	if _, err = findUsername(body); err != nil {
+		return
+	}
+	return goquery.NewDocumentFromReader(strings.NewReader(string(body)))
+}
+
+func (c *SioClient) AdminSubmissions(info Info, filter SubmissionFilter) (submissions []AdminSubmission, perf util.Performance, err error) {
+	perf.StartFetching()
+	URL, err := info.AdminSubmissionsURL(c.host)
+	if err != nil {
+		return
+	}
+	query := url.Values{}
+	if filter.User != "" {
+		query.Set("q", filter.User)
+	}
+	doc, err := c.getAdminSubmissionsPage(URL + "?" + query.Encode())
+	if err != nil {
+		return
+	}
+	pages := []*goquery.Document{doc}
+	for _, p := range findPages(doc) {
+		query.Set("p", p)
+		if doc, err = c.getAdminSubmissionsPage(URL + "?" + query.Encode()); err != nil {
+			return
+		}
+		pages = append(pages, doc)
+	}
+	perf.StopFetching()
+	perf.StartParsing()
+
+	seen := make(map[string]bool)
+	for _, page := range pages {
+		for _, s := range findAdminSubmissions(page) {
+			if seen[s.ID] || !filter.Match(s) {
+				continue
+			}
+			seen[s.ID] = true
+			submissions = append(submissions, s)
+		}
+	}
+	perf.StopParsing()
+	return
+}
+
+func sourceFilename(resp *http.Response, submissionID string) string {
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil {
+		if name := filepath.Base(params["filename"]); name != "." && name != "/" && name != "" {
+			return fmt.Sprintf("%v_%v", submissionID, name)
+		}
+	}
+	return submissionID + ".txt"
+}
+
+func (c *SioClient) downloadSource(info Info, s AdminSubmission, rootPath string) (path string, err error) {
+	URL, err := info.SubmissionDownloadURL(c.host, s.ID)
+	if err != nil {
+		return
+	}
+	resp, err := c.client.Get(URL)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%v: %v", s.ID, resp.Status)
+	}
+	folder := filepath.Join(rootPath, clearString(s.User), strings.ToLower(s.Alias))
+	if err = os.MkdirAll(folder, os.ModePerm); err != nil {
+		return
+	}
+	path = filepath.Join(folder, sourceFilename(resp, s.ID))
+	file, err := os.Create(path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	_, err = io.Copy(file, resp.Body)
+	return
+}
+
+// DownloadSources saves the source files of submissions into rootPath/<user>/<problem>/.
+func (c *SioClient) DownloadSources(info Info, submissions []AdminSubmission, rootPath string) (perf util.Performance, err error) {
+	perf.StartFetching()
+	const numberOfWorkers = 10
+	wg := sync.WaitGroup{}
+	wg.Add(numberOfWorkers)
+	mu := sync.Mutex{}
+	index, failed := 0, 0
+	for i := 1; i <= numberOfWorkers; i++ {
+		go func() {
+			defer wg.Done()
+			for {
+				mu.Lock()
+				if index >= len(submissions) {
+					mu.Unlock()
+					return
+				}
+				s := submissions[index]
+				index++
+				mu.Unlock()
+
+				path, e := c.downloadSource(info, s, rootPath)
+				mu.Lock()
+				if e != nil {
+					failed++
+					color.Red("Couldn't download submission %v: %v", s.ID, e.Error())
+				} else {
+					color.Green("Downloaded %v", path)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	perf.StopFetching()
+	if failed > 0 {
+		err = fmt.Errorf("failed to download %v of %v submissions", failed, len(submissions))
+	}
+	return
+}
+
+// RejudgeSubmissions requests a rejudge of the given submissions with the admin "rejudge" action.
+func (c *SioClient) RejudgeSubmissions(info Info, submissions []AdminSubmission) (err error) {
+	if len(submissions) == 0 {
+		return errors.New(ErrorNoSubmissionsSelected)
+	}
+	URL, err := info.AdminSubmissionsURL(c.host)
+	if err != nil {
+		return
+	}
+	body, err := util.GetBody(c.client, URL)
+	if err != nil {
+		return
+	}
+	if _, err = findUsername(body); err != nil {
+		return
+	}
+	csrf, err := findCsrf(body)
+	if err != nil {
+		return
+	}
+	values := url.Values{}
+	values.Set("csrfmiddlewaretoken", csrf)
+	values.Set("action", "rejudge_action")
+	values.Set("index", "0")
+	values.Set("select_across", "0")
+	for _, s := range submissions {
+		values.Add("_selected_action", s.ID)
+	}
+	body, err = c.postForm(URL, URL, values)
+	if err != nil {
+		return
+	}
+	if err = getFormErrors(body); err != nil {
+		return
+	}
+	color.Green("Requested rejudge of %v submissions", len(submissions))
+	return
+}
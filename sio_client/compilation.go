@@ -0,0 +1,32 @@
+package sio_client
+
+import (
+	"github.com/Arapak/sio-tool/sio_submissions"
+	"github.com/Arapak/sio-tool/util"
+)
+
+func (c *SioClient) GetCompilationLog(info Info) (compilerLog string, err error) {
+	URL, err := info.SubmissionURL(c.host, false)
+	if err != nil {
+		return
+	}
+	body, err := util.GetBody(c.client, URL)
+	if err != nil {
+		return
+	}
+	if _, err = findUsername(body); err != nil {
+		return
+	}
+	return sio_submissions.ParseCompilationLog(body)
+}
+
+// PrintCompilationLog prints the compiler output of a submission with references to
+// the submitted file mapped to sourcePath (if it is not empty).
+func (c *SioClient) PrintCompilationLog(info Info, sourcePath string) (err error) {
+	compilerLog, err := c.GetCompilationLog(info)
+	if err != nil {
+		return
+	}
+	util.PrintCompilationLog(compilerLog, sourcePath)
+	return
+}
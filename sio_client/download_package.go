@@ -2,30 +2,38 @@ package sio_client
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/Arapak/sio-tool/util"
 	"github.com/PuerkitoBio/goquery"
 	"github.com/fatih/color"
+	"github.com/k0kubun/go-ansi"
 )
 
 const ErrorNoFileAttached = "no file attached"
 const ErrorProblemIdNotFound = "problem id not found"
 const ErrorReuploadIdNotFound = "reupload id not found"
+const ErrorSomeDownloadsFailed = "some packages couldn't be downloaded"
 
 type PackageInfo struct {
 	Name       string
 	Alias      string
+	Round      string
 	Package    string
 	ReuploadId string
 	ProblemId  string
+	Rejudge    string
 }
 
 var ProblemIdRegStr = regexp.MustCompile(`/c/\S+/admin/contests/probleminstance/(\d+)/change/`)
@@ -41,6 +49,7 @@ func findPackages(body []byte) (packages []PackageInfo, err error) {
 		info := PackageInfo{}
 		info.Name = strings.TrimSpace(s.Find(".field-name_link a").Last().Text())
 		info.Alias = strings.TrimSpace(s.Find(".field-short_name_link a").First().Text())
+		info.Round = strings.TrimSpace(s.Find(".field-round").First().Text())
 		info.Package, _ = s.Find(".field-package a").First().Attr("href")
 		var actions string
 		actions, err = goquery.OuterHtml(s.Find(".field-actions_field").First())
@@ -56,30 +65,12 @@ func findPackages(body []byte) (packages []PackageInfo, err error) {
 			return
 		}
 		info.ReuploadId = match[1]
+		info.Rejudge, _ = s.Find(`.field-actions_field a[href*="rejudge"]`).First().Attr("href")
 		packages = append(packages, info)
 	})
 	return
 }
 
-func (c *SioClient) DownloadPackage(p PackageInfo, rootPath string) (err error) {
-	resp, err := c.client.Get(c.host + p.Package)
-	if err != nil {
-		return
-	}
-	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return
-	}
-	match := AttachmentRegStr.FindStringSubmatch(resp.Header.Get("Content-Disposition"))
-	if match == nil {
-		return errors.New(ErrorNoFileAttached)
-	}
-	filename := match[1]
-	extension := path.Ext(filename)
-	return os.WriteFile(path.Join(rootPath, p.Name+extension), body, 0644)
-}
-
 func (c *SioClient) FindAllPackages(info Info) (packages []PackageInfo, perf util.Performance, err error) {
 	URL, err := info.ProblemInstanceURL(c.host)
 	if err != nil {
@@ -119,52 +110,411 @@ func (c *SioClient) FindAllPackages(info Info) (packages []PackageInfo, perf uti
 	return
 }
 
-func (c *SioClient) DownloadAllPackages(info Info, rootPath string) (perf util.Performance, err error) {
-	packages, perf, err := c.FindAllPackages(info)
+// PackageFilter limits downloaded packages to the given problem alias and/or round.
+type PackageFilter struct {
+	Alias string
+	Round string
+}
+
+func (f PackageFilter) Match(p PackageInfo) bool {
+	if f.Alias != "" && p.Alias != f.Alias && p.Name != f.Alias {
+		return false
+	}
+	if f.Round != "" && p.Round != f.Round && clearString(p.Round) != clearString(f.Round) {
+		return false
+	}
+	return true
+}
+
+type DownloadOptions struct {
+	Filter  PackageFilter
+	Retries int
+	Workers int
+}
+
+type DownloadResult struct {
+	Package PackageInfo
+	File    string
+	Size    int64
+	Skipped bool
+	Err     error
+}
+
+type DownloadSummary struct {
+	Results []DownloadResult
+}
+
+func (s *DownloadSummary) Failed() (ret []DownloadResult) {
+	for _, r := range s.Results {
+		if r.Err != nil {
+			ret = append(ret, r)
+		}
+	}
+	return
+}
+
+func (s *DownloadSummary) Display() {
+	downloaded, skipped := 0, 0
+	var size int64
+	for _, r := range s.Results {
+		if r.Err != nil {
+			continue
+		}
+		if r.Skipped {
+			skipped++
+		} else {
+			downloaded++
+			size += r.Size
+		}
+	}
+	color.Blue("----FINISHED----")
+	color.Green("Downloaded: %v (%v)", downloaded, formatSize(size))
+	color.Cyan("Up to date: %v", skipped)
+	failed := s.Failed()
+	if len(failed) == 0 {
+		return
+	}
+	color.Red("Failed: %v", len(failed))
+	for _, r := range failed {
+		color.Red("  %v: %v", r.Package.Name, r.Err.Error())
+	}
+}
+
+func formatSize(size int64) string {
+	if size > 1024*1024 {
+		return fmt.Sprintf("%.2f MB", float64(size)/1024.0/1024.0)
+	} else if size > 1024 {
+		return fmt.Sprintf("%.2f KB", float64(size)/1024.0)
+	}
+	return fmt.Sprintf("%v B", size)
+}
+
+// manifestName is a file kept next to the downloaded packages, it remembers
+// the size and ETag of every package so unchanged ones can be skipped.
+const manifestName = ".st-packages.json"
+
+type manifestEntry struct {
+	File string `json:"file"`
+	Size int64  `json:"size"`
+	ETag string `json:"etag"`
+}
+
+type manifest struct {
+	Packages map[string]manifestEntry `json:"packages"`
+	path     string
+	mu       sync.Mutex
+}
+
+func loadManifest(rootPath string) *manifest {
+	m := &manifest{Packages: map[string]manifestEntry{}, path: filepath.Join(rootPath, manifestName)}
+	data, err := os.ReadFile(m.path)
+	if err == nil {
+		_ = json.Unmarshal(data, m)
+	}
+	if m.Packages == nil {
+		m.Packages = map[string]manifestEntry{}
+	}
+	return m
+}
+
+func (m *manifest) get(name string) (entry manifestEntry, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok = m.Packages[name]
+	return
+}
+
+func (m *manifest) set(name string, entry manifestEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Packages[name] = entry
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(m.path, data, 0644)
+}
+
+type progress struct {
+	mu         sync.Mutex
+	total      int
+	finished   int
+	downloaded int64
+}
+
+func (p *progress) add(n int64) {
+	p.mu.Lock()
+	p.downloaded += n
+	p.print()
+	p.mu.Unlock()
+}
+
+func (p *progress) print() {
+	ansi.EraseInLine(2)
+	ansi.CursorHorizontalAbsolute(0)
+	_, _ = ansi.Printf("PACKAGES: %v/%v DOWNLOADED: %v", p.finished, p.total, formatSize(p.downloaded))
+}
+
+func (p *progress) message(f func()) {
+	p.mu.Lock()
+	ansi.EraseInLine(2)
+	ansi.CursorHorizontalAbsolute(0)
+	f()
+	p.print()
+	p.mu.Unlock()
+}
+
+type progressWriter struct {
+	p *progress
+}
+
+func (w progressWriter) Write(b []byte) (int, error) {
+	w.p.add(int64(len(b)))
+	return len(b), nil
+}
+
+func partPath(rootPath string, p PackageInfo) string {
+	return filepath.Join(rootPath, "."+p.Name+".part")
+}
+
+// partETagPath keeps the ETag of a partly downloaded package, so that the download can be resumed
+// only if the package didn't change. The manifest lists only complete packages.
+func partETagPath(part string) string {
+	return part + ".etag"
+}
+
+// transientError is a failed download which may succeed when tried again:
+// a network error, an error reading the body, an incomplete body or a server error (5xx or 429).
+type transientError struct {
+	err error
+}
+
+func (e transientError) Error() string {
+	return e.err.Error()
+}
+
+func (e transientError) Unwrap() error {
+	return e.err
+}
+
+// bodyReader keeps the error of reading the response, to tell it apart from an error
+// of writing the file (e.g. a full disk), which isn't worth retrying.
+type bodyReader struct {
+	io.Reader
+	err error
+}
+
+func (r *bodyReader) Read(b []byte) (n int, err error) {
+	n, err = r.Reader.Read(b)
+	if err != nil && err != io.EOF {
+		r.err = err
+	}
+	return
+}
+
+func retryable(err error) bool {
+	var transient transientError
+	return errors.As(err, &transient)
+}
+
+func (c *SioClient) downloadPackageOnce(p PackageInfo, rootPath string, m *manifest, prog *progress) (result DownloadResult, err error) {
+	result.Package = p
+	req, err := http.NewRequest("GET", c.host+p.Package, nil)
+	if err != nil {
+		return
+	}
+	// the download is retried by downloadPackage, which can also resume an incomplete body
+	req = util.WithoutRetries(req)
+	entry, known := m.get(p.Name)
+	existing := ""
+	if known && entry.File != "" {
+		existing = filepath.Join(rootPath, entry.File)
+		if stat, e := os.Stat(existing); e == nil && stat.Size() == entry.Size && entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+	}
+	part := partPath(rootPath, p)
+	var offset int64
+	partETag, _ := os.ReadFile(partETagPath(part))
+	if stat, e := os.Stat(part); e == nil && stat.Size() > 0 && len(partETag) > 0 {
+		offset = stat.Size()
+		req.Header.Set("Range", fmt.Sprintf("bytes=%v-", offset))
+		req.Header.Set("If-Range", string(partETag))
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		if !errors.Is(err, util.ErrorChallenge) && !errors.Is(err, util.ErrorMaintenance) {
+			err = transientError{err}
+		}
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		result.File = entry.File
+		result.Size = entry.Size
+		result.Skipped = true
+		return
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		err = fmt.Errorf("server responded with %v", resp.Status)
+		if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests {
+			err = transientError{err}
+		}
+		return
+	}
+	match := AttachmentRegStr.FindStringSubmatch(resp.Header.Get("Content-Disposition"))
+	if match == nil {
+		err = errors.New(ErrorNoFileAttached)
+		return
+	}
+	filename := p.Name + path.Ext(match[1])
+	etag := resp.Header.Get("ETag")
+	size := resp.ContentLength
+
+	if resp.StatusCode == http.StatusOK {
+		offset = 0
+		if existing != "" && etag != "" && etag == entry.ETag && filepath.Base(existing) == filename {
+			if stat, e := os.Stat(existing); e == nil && stat.Size() == size {
+				result.File = filename
+				result.Size = size
+				result.Skipped = true
+				return
+			}
+		}
+	} else if size >= 0 {
+		size += offset
+	}
+	if resp.StatusCode == http.StatusOK {
+		_ = os.Remove(partETagPath(part))
+		if etag != "" {
+			if err = os.WriteFile(partETagPath(part), []byte(etag), 0644); err != nil {
+				return
+			}
+		}
+	}
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if offset > 0 {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	file, err := os.OpenFile(part, flags, 0644)
 	if err != nil {
 		return
 	}
-	numberOfWorkers := 10
+	body := &bodyReader{Reader: resp.Body}
+	written, err := io.Copy(io.MultiWriter(file, progressWriter{prog}), body)
+	closeErr := file.Close()
+	if err != nil {
+		if body.err != nil {
+			err = transientError{err}
+		}
+		return
+	}
+	if closeErr != nil {
+		err = closeErr
+		return
+	}
+	written += offset
+	if size >= 0 && written != size {
+		err = transientError{fmt.Errorf("downloaded %v out of %v bytes", written, size)}
+		return
+	}
+	if err = os.Rename(part, filepath.Join(rootPath, filename)); err != nil {
+		return
+	}
+	_ = os.Remove(partETagPath(part))
+	result.File = filename
+	result.Size = written
+	err = m.set(p.Name, manifestEntry{File: filename, Size: written, ETag: etag})
+	return
+}
+
+// retryDelay is the wait before the first retry of a download, it doubles after each retry.
+var retryDelay = time.Second
+
+// downloadPackage downloads the package, retrying the failures which may pass when tried again.
+func (c *SioClient) downloadPackage(p PackageInfo, rootPath string, retries int, m *manifest, prog *progress) (result DownloadResult) {
+	delay := retryDelay
+	for attempt := 0; ; attempt++ {
+		var err error
+		result, err = c.downloadPackageOnce(p, rootPath, m, prog)
+		result.Err = err
+		if err == nil || attempt >= retries || !retryable(err) {
+			return
+		}
+		prog.message(func() {
+			color.Yellow("Downloading %v failed (%v), retrying in %v", p.Name, err.Error(), delay)
+		})
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+func (c *SioClient) DownloadAllPackages(info Info, rootPath string, options DownloadOptions) (summary DownloadSummary, perf util.Performance, err error) {
+	allPackages, perf, err := c.FindAllPackages(info)
+	if err != nil {
+		return
+	}
+	var packages []PackageInfo
+	for _, p := range allPackages {
+		if options.Filter.Match(p) {
+			packages = append(packages, p)
+		}
+	}
+	if len(packages) == 0 {
+		color.Red("no packages match given criteria")
+		return
+	}
+	numberOfWorkers := options.Workers
+	if numberOfWorkers <= 0 {
+		numberOfWorkers = 10
+	}
+
+	m := loadManifest(rootPath)
+	prog := &progress{total: len(packages)}
+	summary.Results = make([]DownloadResult, len(packages))
 
 	wg := sync.WaitGroup{}
 	wg.Add(numberOfWorkers)
 	mu := sync.Mutex{}
-
-	workerError := false
 	packageNumber := 0
 
 	for i := 1; i <= numberOfWorkers; i++ {
 		go func(workerID int) {
-			defer func() {
-				mu.Lock()
-				workerError = true
-				mu.Unlock()
-				wg.Done()
-			}()
+			defer wg.Done()
 			for {
 				mu.Lock()
-				if workerError || packageNumber >= len(packages) {
+				if packageNumber >= len(packages) {
 					mu.Unlock()
 					return
 				}
-				currentPackage := packages[packageNumber]
+				index := packageNumber
 				packageNumber++
 				mu.Unlock()
-				err = c.DownloadPackage(currentPackage, rootPath)
-				if err != nil {
-					mu.Lock()
-					color.Red(err.Error())
-					mu.Unlock()
-					return
-				}
-				mu.Lock()
-				color.Green("Downloaded package for task: %v", currentPackage.Name)
-				mu.Unlock()
+
+				result := c.downloadPackage(packages[index], rootPath, options.Retries, m, prog)
+				summary.Results[index] = result
+				prog.message(func() {
+					prog.finished++
+					if result.Err != nil {
+						color.Red("Failed to download package for task %v: %v", result.Package.Name, result.Err.Error())
+					} else if result.Skipped {
+						color.Cyan("Package for task %v is up to date", result.Package.Name)
+					} else {
+						color.Green("Downloaded package for task: %v (%v)", result.Package.Name, formatSize(result.Size))
+					}
+				})
 			}
 		}(i)
 	}
 	wg.Wait()
-	color.Blue("----FINISHED----")
+	fmt.Println()
+	summary.Display()
 
+	if len(summary.Failed()) > 0 {
+		err = errors.New(ErrorSomeDownloadsFailed)
+	}
 	return
 }
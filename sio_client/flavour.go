@@ -0,0 +1,55 @@
+package sio_client
+
+const DefaultFlavour = "oioioi"
+
+// Flavour describes how the pages of an instance differ between OIOIOI versions and deployments.
+type Flavour struct {
+	// LoginPath is the path of the login form. TwoStepLogin marks the form of newer OIOIOI
+	// versions with "auth-" field names.
+	LoginPath    string
+	TwoStepLogin bool
+	// ContestsPath is the path of the page listing the contests. ContestGroups marks lists
+	// where the rows without a class are the names of contest groups.
+	ContestsPath  string
+	ContestGroups bool
+	// PointsSelector selects the points of a problem in the problem list.
+	PointsSelector string
+	// RankingPath is the format of the ranking path of a contest, empty when there is no ranking.
+	RankingPath string
+	// OldSubmissions marks the submissions table of old OIOIOI versions.
+	OldSubmissions bool
+	// Extensions of the files the instance accepts when they can't be read from the submit form,
+	// nil means AcceptedExtensions.
+	Extensions map[string]struct{}
+}
+
+// Flavours are the known flavours, an instance without a flavour uses DefaultFlavour.
+var Flavours = map[string]Flavour{
+	"oioioi": {
+		LoginPath:      "/login/",
+		TwoStepLogin:   true,
+		PointsSelector: ".badge",
+		RankingPath:    "/c/%v/ranking/",
+	},
+	"staszic": {
+		LoginPath:      "/login/",
+		ContestGroups:  true,
+		PointsSelector: ".label",
+		RankingPath:    "/c/%v/r/",
+		OldSubmissions: true,
+	},
+	"mimuw": {
+		LoginPath:      "/c/oi30-1/login/",
+		TwoStepLogin:   true,
+		ContestsPath:   "/c/oi30-1/contest",
+		PointsSelector: ".label",
+		RankingPath:    "/c/%v/ranking/",
+		Extensions:     map[string]struct{}{"cpp": {}, "cc": {}, "c": {}, "pas": {}, "py": {}, "java": {}},
+	},
+	"talent": {
+		LoginPath:      "/login/",
+		TwoStepLogin:   true,
+		PointsSelector: ".badge",
+		Extensions:     map[string]struct{}{"cpp": {}, "cc": {}, "c": {}, "py": {}},
+	},
+}
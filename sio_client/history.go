@@ -0,0 +1,117 @@
+package sio_client
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+
+	"github.com/Arapak/sio-tool/database_client"
+	"github.com/Arapak/sio-tool/sio_submissions"
+	"github.com/Arapak/sio-tool/szkopul_client"
+	"github.com/fatih/color"
+)
+
+func (c *SioClient) toDatabaseSubmission(contest string, s sio_submissions.Submission) database_client.Submission {
+	points := int(s.Points)
+	if s.Points == sio_submissions.Inf {
+		points = database_client.NoPoints
+	}
+	return database_client.Submission{
+		Judge:        c.name,
+		SubmissionID: s.ParseID(),
+		ContestID:    contest,
+		ShortName:    s.ShortName,
+		When:         s.When,
+		Status:       s.PlainStatus(),
+		Points:       points,
+	}
+}
+
+// recordSubmission saves a submission made with st, together with the hash of its source, in the database.
+func (c *SioClient) recordSubmission(db *sql.DB, info Info, s sio_submissions.Submission, sourcePath string) {
+	if db == nil {
+		return
+	}
+	submission := c.toDatabaseSubmission(info.Contest, s)
+	if submission.ShortName == "" {
+		submission.ShortName = info.ProblemAlias
+	}
+	submission.FilePath = sourcePath
+	if source, err := os.ReadFile(sourcePath); err == nil {
+		submission.SourceHash = database_client.SourceHash(source)
+	}
+	submission.TaskID, _ = database_client.FindTaskID(db, database_client.Task{Source: "sio", ContestID: info.Contest, ShortName: submission.ShortName})
+	if err := database_client.AddSubmission(db, submission); err != nil {
+		color.Red(err.Error())
+	}
+}
+
+func (c *SioClient) submissionsPage(URL string) ([]sio_submissions.Submission, error) {
+	if c.flavour.OldSubmissions {
+		return c.getSubmissions(URL, -1)
+	}
+	return szkopul_client.GetSubmissions(c.client, URL, -1)
+}
+
+// RecentSubmissions returns the latest submissions of the current user in a contest.
+func (c *SioClient) RecentSubmissions(info Info) ([]sio_submissions.Submission, error) {
+	URL, err := info.MySubmissionURL(c.host)
+	if err != nil {
+		return nil, err
+	}
+	return c.submissionsPage(URL)
+}
+
+// AllSubmissions returns all submissions of the current user in a contest.
+func (c *SioClient) AllSubmissions(info Info) (submissions []sio_submissions.Submission, err error) {
+	URL, err := info.MySubmissionURL(c.host)
+	if err != nil {
+		return
+	}
+	return sio_submissions.CollectPages(func(page int) ([]sio_submissions.Submission, error) {
+		return c.submissionsPage(fmt.Sprintf("%v?page=%v", URL, page))
+	})
+}
+
+// ContestAliases returns the aliases of all contests of the instance.
+func (c *SioClient) ContestAliases() (aliases []string, err error) {
+	list, _, err := c.ListContests()
+	if err != nil {
+		return
+	}
+	for _, contest := range list {
+		if !contest.Subheader {
+			aliases = append(aliases, contest.Alias)
+		}
+	}
+	return
+}
+
+// Sync saves the submission history of the contest from info (or of all contests if none is given) in the database.
+func (c *SioClient) Sync(info Info, db *sql.DB) (synced int, err error) {
+	contests := []string{info.Contest}
+	if info.Contest == "" {
+		if contests, err = c.ContestAliases(); err != nil {
+			return
+		}
+	}
+	for _, contest := range contests {
+		info := Info{Contest: contest}
+		submissions, e := c.AllSubmissions(info)
+		if e != nil {
+			if e.Error() == ErrorNotLogged {
+				return synced, e
+			}
+			color.Red("%v: %v", contest, e.Error())
+			continue
+		}
+		for _, s := range submissions {
+			if err = database_client.AddSubmission(db, c.toDatabaseSubmission(contest, s)); err != nil {
+				return
+			}
+			synced++
+		}
+	}
+	err = database_client.LinkSubmissions(db, c.name, "sio")
+	return
+}
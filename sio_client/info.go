@@ -10,14 +10,6 @@ import (
 	"github.com/Arapak/sio-tool/database_client"
 )
 
-var AcceptedExtensions = map[string]struct{}{
-	"cpp": {},
-	"cc":  {},
-	"c":   {},
-	"pas": {},
-	"py":  {},
-}
-
 type Info struct {
 	Contest      string `json:"contest_id"`
 	ProblemID    string `json:"problem_id"`
@@ -34,7 +26,7 @@ const ErrorNeedRound = "you have to specify the round"
 
 const ErrorNeedSubmissionID = "you have to specify the Submission ID"
 
-const ErrorUnknownInstance = "unknown sio instance"
+const ErrorNoRanking = "this instance has no ranking"
 
 func (info *Info) Hint() string {
 	text := ""
@@ -190,13 +182,10 @@ func (info *Info) StandingsURL(cln *SioClient, host string) (string, error) {
 	if info.Contest == "" {
 		return "", errors.New(ErrorNeedContest)
 	}
-	if cln.instanceClient == Staszic {
-		return fmt.Sprintf(host+"/c/%v/r/", info.Contest), nil
-	} else if cln.instanceClient == Mimuw {
-		return fmt.Sprintf(host+"/c/%v/ranking/", info.Contest), nil
-	} else {
-		return "", errors.New(ErrorUnknownInstance)
+	if cln.flavour.RankingPath == "" {
+		return "", errors.New(ErrorNoRanking)
 	}
+	return fmt.Sprintf(host+cln.flavour.RankingPath, info.Contest), nil
 }
 
 func (info *Info) StatusURL(host string) (string, error) {
@@ -219,3 +208,17 @@ func (info *Info) OpenURL(host string) (string, error) {
 func (info *Info) ToTask() database_client.Task {
 	return database_client.Task{ShortName: info.ProblemAlias, Source: "sio", ContestID: info.Contest}
 }
+
+func (info *Info) AdminSubmissionsURL(host string) (string, error) {
+	if info.Contest == "" {
+		return "", errors.New(ErrorNeedContest)
+	}
+	return fmt.Sprintf(host+"/c/%v/admin/contests/submission/", info.Contest), nil
+}
+
+func (info *Info) SubmissionDownloadURL(host, submissionID string) (string, error) {
+	if info.Contest == "" {
+		return "", errors.New(ErrorNeedContest)
+	}
+	return fmt.Sprintf(host+"/c/%v/s/%v/download/", info.Contest, submissionID), nil
+}
@@ -0,0 +1,51 @@
+package sio_client
+
+import (
+	"strings"
+
+	"github.com/Arapak/sio-tool/util"
+	"github.com/PuerkitoBio/goquery"
+)
+
+// AcceptedExtensions are the extensions of files which can be submitted to any instance.
+var AcceptedExtensions = map[string]struct{}{
+	"cpp": {},
+	"cc":  {},
+	"c":   {},
+	"pas": {},
+	"py":  {},
+}
+
+// AcceptedExtensions returns the extensions of the languages of the submit form of the contest.
+// When the form can't be read or has no language select, it returns the extensions of the flavour
+// of the instance, or AcceptedExtensions.
+func (c *SioClient) AcceptedExtensions(info Info) map[string]struct{} {
+	if URL, err := info.SubmitURL(c.host); err == nil {
+		if body, err := util.GetBody(c.client, URL); err == nil {
+			if languages, err := findLanguages(body); err == nil && len(languages) > 0 {
+				if extensions := util.LanguageExtensions(languages); len(extensions) > 0 {
+					return extensions
+				}
+			}
+		}
+	}
+	if c.flavour.Extensions != nil {
+		return c.flavour.Extensions
+	}
+	return AcceptedExtensions
+}
+
+// findLanguages returns the options of the language select of the submit form.
+func findLanguages(body []byte) (languages []util.Language, err error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(body)))
+	if err != nil {
+		return
+	}
+	doc.Find(`select[name="prog_lang"] option`).Each(func(_ int, s *goquery.Selection) {
+		value, _ := s.Attr("value")
+		if value != "" {
+			languages = append(languages, util.Language{Value: value, Name: strings.TrimSpace(s.Text())})
+		}
+	})
+	return
+}
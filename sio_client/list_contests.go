@@ -1,7 +1,6 @@
 package sio_client
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/Arapak/sio-tool/util"
@@ -22,7 +21,7 @@ func (c *SioClient) findContests(body []byte) (ret []ContestInfo, err error) {
 	doc.Find("table tbody").First().Find("tr").Each(func(_ int, s *goquery.Selection) {
 		_, ok := s.Attr("class")
 		info := ContestInfo{}
-		if !ok && c.instanceClient == Staszic {
+		if !ok && c.flavour.ContestGroups {
 			info.Subheader = true
 			info.Name = strings.TrimSpace(s.Find("a").First().Text())
 		} else {
@@ -37,10 +36,7 @@ func (c *SioClient) findContests(body []byte) (ret []ContestInfo, err error) {
 
 func (c *SioClient) ListContests() (problems []ContestInfo, perf util.Performance, err error) {
 	perf.StartFetching()
-	URL := c.host
-	if c.instanceClient == Mimuw {
-		URL = fmt.Sprintf("%v/c/oi30-1/contest", c.host)
-	}
+	URL := c.host + c.flavour.ContestsPath
 	body, err := util.GetBody(c.client, URL)
 	if err != nil {
 		return
@@ -4,7 +4,6 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/md5"
-	"crypto/rand"
 	"encoding/hex"
 	"errors"
 	"io"
@@ -15,6 +14,7 @@ import (
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/Arapak/sio-tool/cookiejar"
+	"github.com/Arapak/sio-tool/credentials"
 	"github.com/Arapak/sio-tool/util"
 
 	"github.com/fatih/color"
@@ -50,6 +50,16 @@ func (c *SioClient) getCsrf(URL string) (csrf string, err error) {
 	return findCsrf(body)
 }
 
+// CheckSession checks that the saved session is still logged in.
+func (c *SioClient) CheckSession() (err error) {
+	body, err := util.GetBody(c.client, c.host+"/")
+	if err != nil {
+		return
+	}
+	_, err = findUsername(body)
+	return
+}
+
 func (c *SioClient) Login() (err error) {
 	color.Cyan("Login...\n")
 
@@ -67,22 +77,15 @@ func (c *SioClient) Login() (err error) {
 
 	form := url.Values{}
 	form.Add("csrfmiddlewaretoken", csrf)
-	var URL string
-	if c.instanceClient == Staszic {
-		form.Add("username", c.Username)
-		form.Add("password", password)
-		URL = c.host + "/login/"
-	} else if c.instanceClient == Mimuw {
-		form.Add("auth-username", c.Username)
-		form.Add("auth-password", password)
-		form.Add("login_view-current_step", "auth")
-		URL = c.host + "/c/oi30-1/login/"
-	} else if c.instanceClient == Talent {
+	if c.flavour.TwoStepLogin {
 		form.Add("auth-username", c.Username)
 		form.Add("auth-password", password)
 		form.Add("login_view-current_step", "auth")
-		URL = c.host + "/login/"
+	} else {
+		form.Add("username", c.Username)
+		form.Add("password", password)
 	}
+	URL := c.host + c.flavour.LoginPath
 
 	req, err := http.NewRequest("POST", URL, strings.NewReader(form.Encode()))
 	if err != nil {
@@ -122,24 +125,7 @@ func createHash(key string) []byte {
 	return hasher.Sum(nil)
 }
 
-func encrypt(handle, password string) (ret string, err error) {
-	block, err := aes.NewCipher(createHash("glhf" + handle + "233"))
-	if err != nil {
-		return
-	}
-	gcm, err := cipher.NewGCM(block)
-	if err != nil {
-		return
-	}
-	nonce := make([]byte, gcm.NonceSize())
-	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
-		return
-	}
-	text := gcm.Seal(nonce, nonce, []byte(password), nil)
-	ret = hex.EncodeToString(text)
-	return
-}
-
+// decrypt reads a password saved by older versions of st, see credentials.Migrate.
 func decrypt(handle, password string) (ret string, err error) {
 	data, err := hex.DecodeString(password)
 	if err != nil {
@@ -165,10 +151,10 @@ func decrypt(handle, password string) (ret string, err error) {
 }
 
 func (c *SioClient) DecryptPassword() (string, error) {
-	if len(c.Password) == 0 || len(c.Username) == 0 {
-		return "", errors.New("you have to configure your username and password by `st config`")
+	if len(c.Username) == 0 {
+		return "", errors.New(credentials.ErrorNotFound)
 	}
-	return decrypt(c.Username, c.Password)
+	return credentials.Get(c.name, c.Username)
 }
 
 func (c *SioClient) ConfigLogin() (err error) {
@@ -186,8 +172,7 @@ func (c *SioClient) ConfigLogin() (err error) {
 	}
 
 	c.Username = username
-	c.Password, err = encrypt(username, password)
-	if err != nil {
+	if err = credentials.Set(c.name, username, password); err != nil {
 		return
 	}
 	return c.Login()
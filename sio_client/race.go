@@ -1,12 +1,10 @@
 package sio_client
 
 import (
-	"fmt"
 	"math"
-	"time"
 
+	"github.com/Arapak/sio-tool/util"
 	"github.com/fatih/color"
-	"github.com/k0kubun/go-ansi"
 )
 
 func (c *SioClient) RaceContest(info Info) (round string, err error) {
@@ -21,15 +19,6 @@ func (c *SioClient) RaceContest(info Info) (round string, err error) {
 		return roundInfo.RoundName, nil
 	}
 	color.Green("Countdown: ")
-	for timeLeft > 0 {
-		h := timeLeft / 60 / 60
-		m := timeLeft/60 - h*60
-		s := timeLeft - h*60*60 - m*60
-		fmt.Printf("%02d:%02d:%02d\n", h, m, s)
-		ansi.CursorUp(1)
-		timeLeft--
-		time.Sleep(time.Second)
-	}
-	time.Sleep(900 * time.Millisecond)
+	util.Countdown(timeLeft)
 	return roundInfo.RoundName, nil
 }
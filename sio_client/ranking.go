@@ -0,0 +1,211 @@
+package sio_client
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Arapak/sio-tool/util"
+	"github.com/PuerkitoBio/goquery"
+)
+
+const ErrorRankingNotFound = "ranking not found"
+
+type RankingRow struct {
+	Place   string   `json:"place"`
+	User    string   `json:"user"`
+	Scores  []string `json:"scores"`
+	Total   string   `json:"total"`
+	Current bool     `json:"current"`
+	// Change is the number of places gained since the previous fetch (negative if lost).
+	// New marks users missing from the previous fetch.
+	Change int  `json:"change,omitempty"`
+	New    bool `json:"new,omitempty"`
+}
+
+type Ranking struct {
+	Contest  string       `json:"contest"`
+	Round    string       `json:"round"`
+	Problems []string     `json:"problems"`
+	Rows     []RankingRow `json:"rows"`
+	Fetched  time.Time    `json:"fetched"`
+}
+
+// Name returns the contest and the round of the ranking ("all" when it has no round)
+// in a form that can be used in a file name.
+func (r *Ranking) Name() string {
+	round := "all"
+	if r.Round != "" {
+		round = clearString(r.Round)
+	}
+	return clearString(r.Contest) + "_" + round
+}
+
+func cellText(s *goquery.Selection) string {
+	return strings.Join(strings.Fields(s.Text()), " ")
+}
+
+func findRankingTable(doc *goquery.Document) *goquery.Selection {
+	table := doc.Find("table.table-ranking").First()
+	if table.Length() == 0 {
+		table = doc.Find("#content table, main table").First()
+	}
+	return table
+}
+
+func findRanking(doc *goquery.Document, username string) (problems []string, rows []RankingRow, err error) {
+	table := findRankingTable(doc)
+	if table.Length() == 0 {
+		err = errors.New(ErrorRankingNotFound)
+		return
+	}
+	header := table.Find("thead tr").First().Find("th")
+	header.Each(func(i int, s *goquery.Selection) {
+		if i >= 2 && i < header.Length()-1 {
+			problems = append(problems, cellText(s))
+		}
+	})
+	table.Find("tbody tr").Each(func(_ int, tr *goquery.Selection) {
+		cells := tr.Find("td")
+		if cells.Length() < 3 {
+			return
+		}
+		row := RankingRow{
+			Place: cellText(cells.Eq(0)),
+			User:  cellText(cells.Eq(1)),
+			Total: cellText(cells.Last()),
+		}
+		for i := 2; i < cells.Length()-1; i++ {
+			row.Scores = append(row.Scores, cellText(cells.Eq(i)))
+		}
+		class := tr.AttrOr("class", "")
+		row.Current = strings.Contains(class, "success") || strings.Contains(class, "info") || row.User == username
+		rows = append(rows, row)
+	})
+	return
+}
+
+// findRankingPages returns the links to the remaining pages of a paginated ranking.
+func findRankingPages(doc *goquery.Document, URL string) (pages []string) {
+	last := 1
+	doc.Find(".pagination a").Each(func(_ int, s *goquery.Selection) {
+		href, err := url.Parse(s.AttrOr("href", ""))
+		if err != nil {
+			return
+		}
+		if page, err := strconv.Atoi(href.Query().Get("page")); err == nil && page > last {
+			last = page
+		}
+	})
+	base, err := url.Parse(URL)
+	if err != nil {
+		return
+	}
+	for page := 2; page <= last; page++ {
+		query := base.Query()
+		query.Set("page", strconv.Itoa(page))
+		base.RawQuery = query.Encode()
+		pages = append(pages, base.String())
+	}
+	return
+}
+
+// findRoundRankingURL finds the ranking of a round among the round links on the ranking page.
+func findRoundRankingURL(doc *goquery.Document, host, round string) (string, error) {
+	URL := ""
+	doc.Find("a[href*='/ranking/'], a[href*='/r/']").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		text := cellText(s)
+		if text == round || clearString(text) == clearString(round) {
+			URL = s.AttrOr("href", "")
+			return false
+		}
+		return true
+	})
+	if URL == "" {
+		return "", errors.New(ErrorRankingNotFound)
+	}
+	if strings.HasPrefix(URL, "/") {
+		URL = host + URL
+	}
+	return URL, nil
+}
+
+func (c *SioClient) getRankingPage(URL string) (doc *goquery.Document, err error) {
+	body, err := util.GetBody(c.client, URL)
+	if err != nil {
+		return
+	}
+	if _, err = findUsername(body); err != nil {
+		return
+	}
+	return goquery.NewDocumentFromReader(strings.NewReader(string(body)))
+}
+
+func (c *SioClient) GetRanking(info Info) (ranking Ranking, perf util.Performance, err error) {
+	perf.StartFetching()
+	URL, err := info.StandingsURL(c, c.host)
+	if err != nil {
+		return
+	}
+	doc, err := c.getRankingPage(URL)
+	if err != nil {
+		return
+	}
+	if info.Round != "" {
+		if URL, err = findRoundRankingURL(doc, c.host, info.Round); err != nil {
+			return
+		}
+		if doc, err = c.getRankingPage(URL); err != nil {
+			return
+		}
+	}
+	pages := []*goquery.Document{doc}
+	for _, pageURL := range findRankingPages(doc, URL) {
+		if doc, err = c.getRankingPage(pageURL); err != nil {
+			return
+		}
+		pages = append(pages, doc)
+	}
+	perf.StopFetching()
+	perf.StartParsing()
+
+	ranking = Ranking{Contest: info.Contest, Round: info.Round, Fetched: time.Now()}
+	for i, page := range pages {
+		problems, rows, e := findRanking(page, c.Username)
+		if e != nil {
+			err = e
+			return
+		}
+		if i == 0 {
+			ranking.Problems = problems
+		}
+		ranking.Rows = append(ranking.Rows, rows...)
+	}
+	perf.StopParsing()
+	return
+}
+
+// Diff compares the ranking with a previously fetched one and fills in the rank changes.
+// The change of a user whose previous or current place can't be read is unknown and left empty.
+func (r *Ranking) Diff(previous Ranking) {
+	places := make(map[string]int)
+	for _, row := range previous.Rows {
+		places[row.User] = 0
+		if place, err := strconv.Atoi(strings.TrimSuffix(row.Place, ".")); err == nil {
+			places[row.User] = place
+		}
+	}
+	for i := range r.Rows {
+		row := &r.Rows[i]
+		old, ok := places[row.User]
+		if !ok {
+			row.New = true
+			continue
+		}
+		if place, err := strconv.Atoi(strings.TrimSuffix(row.Place, ".")); err == nil && old != 0 {
+			row.Change = old - place
+		}
+	}
+}
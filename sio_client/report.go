@@ -0,0 +1,23 @@
+package sio_client
+
+import (
+	"github.com/Arapak/sio-tool/sio_submissions"
+	"github.com/Arapak/sio-tool/util"
+)
+
+func (c *SioClient) GetReport(info Info) (report sio_submissions.Report, err error) {
+	URL, err := info.SubmissionURL(c.host, false)
+	if err != nil {
+		return
+	}
+	body, err := util.GetBody(c.client, URL)
+	if err != nil {
+		return
+	}
+	if _, err = findUsername(body); err != nil {
+		return
+	}
+	report, err = sio_submissions.ParseReport(body)
+	report.SubmissionID = info.SubmissionID
+	return
+}
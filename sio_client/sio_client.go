@@ -6,43 +6,58 @@ import (
 	"net/http"
 	"net/url"
 	"os"
-	"path/filepath"
 
 	"github.com/Arapak/sio-tool/cookiejar"
+	"github.com/Arapak/sio-tool/credentials"
+	"github.com/Arapak/sio-tool/site"
+	"github.com/Arapak/sio-tool/util"
 
 	"github.com/fatih/color"
 )
 
-type SioInstanceClient int
-
-const (
-	Staszic SioInstanceClient = 0
-	Mimuw   SioInstanceClient = 1
-	Talent  SioInstanceClient = 2
-)
-
 type SioClient struct {
-	Jar            *cookiejar.Jar `json:"cookies"`
-	Username       string         `json:"handle"`
-	Password       string         `json:"password"`
-	LastSubmission *Info          `json:"last_submission"`
+	Jar      *cookiejar.Jar `json:"cookies"`
+	Username string         `json:"handle"`
+	// Password is the password encrypted by older versions of st, it is moved to the credential store.
+	Password       string `json:"password,omitempty"`
+	LastSubmission *Info  `json:"last_submission"`
 	host           string
 	path           string
 	client         *http.Client
-	instanceClient SioInstanceClient
+	name           string
+	flavour        Flavour
 }
 
-var StaszicInstance *SioClient
-var MimuwInstance *SioClient
-var TalentInstance *SioClient
+// Instances are the clients of the instances from the configuration.
+var Instances []*SioClient
+
+// Get returns the client of the instance called name, or nil if there is no such instance.
+func Get(name string) *SioClient {
+	for _, c := range Instances {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
 
-func Init(path, host, proxy string, instanceClient SioInstanceClient) {
+func Init(path, host, proxy, name, flavour string) {
 	jar, _ := cookiejar.New(nil)
-	c := &SioClient{Jar: jar, LastSubmission: nil, path: path, host: host, client: nil}
+	c := &SioClient{Jar: jar, LastSubmission: nil, path: path, host: host, client: nil, name: name}
+	if flavour == "" {
+		flavour = DefaultFlavour
+	}
+	if f, ok := Flavours[flavour]; ok {
+		c.flavour = f
+	} else {
+		color.Red("Unknown flavour %v of %v, using %v", flavour, name, DefaultFlavour)
+		c.flavour = Flavours[DefaultFlavour]
+	}
 	if err := c.load(); err != nil {
 		color.Red(err.Error())
 		color.Green("Create a new session in %v", path)
 	}
+	credentials.Migrate(c.name, c.Username, c.Password, decrypt, func() { c.Password = "" })
 	Proxy := http.ProxyFromEnvironment
 	if len(proxy) > 0 {
 		proxyURL, err := url.Parse(proxy)
@@ -53,18 +68,21 @@ func Init(path, host, proxy string, instanceClient SioInstanceClient) {
 			Proxy = http.ProxyURL(proxyURL)
 		}
 	}
-	c.client = &http.Client{Jar: c.Jar, Transport: &http.Transport{Proxy: Proxy}}
+	c.client = &http.Client{Jar: c.Jar, Transport: util.NewTransport(Proxy)}
 	if err := c.save(); err != nil {
 		color.Red(err.Error())
 	}
-	c.instanceClient = instanceClient
-	if instanceClient == Staszic {
-		StaszicInstance = c
-	} else if instanceClient == Mimuw {
-		MimuwInstance = c
-	} else if instanceClient == Talent {
-		TalentInstance = c
+	if old := Get(name); old != nil {
+		*old = *c
+	} else {
+		Instances = append(Instances, c)
 	}
+	site.Register(c.Site())
+}
+
+// Name of the instance, e.g. "sio-staszic".
+func (c *SioClient) Name() string {
+	return c.name
 }
 
 func (c *SioClient) load() (err error) {
@@ -89,10 +107,7 @@ func (c *SioClient) load() (err error) {
 func (c *SioClient) save() (err error) {
 	data, err := json.MarshalIndent(c, "", "  ")
 	if err == nil {
-		err = os.MkdirAll(filepath.Dir(c.path), os.ModePerm)
-		if err == nil {
-			err = os.WriteFile(c.path, data, 0644)
-		}
+		err = credentials.WriteFile(c.path, data)
 	}
 	if err != nil {
 		color.Red("Cannot save session to %v\n%v", c.path, err.Error())
@@ -0,0 +1,408 @@
+package sio_client
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Arapak/sio-tool/credentials"
+	"github.com/Arapak/sio-tool/replay/replaytest"
+	"github.com/Arapak/sio-tool/sio_submissions"
+	"github.com/Arapak/sio-tool/util"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/mitchellh/go-homedir"
+)
+
+// host is the instance the fixtures are recorded from, ST_RECORD_FIXTURES=1 records them again
+// with the session of "st config". The expectations are read from the recorded pages.
+const host = "https://sio2.staszic.waw.pl"
+
+// contest is a contest the user of the fixtures takes part in and administers.
+const contest = "kurs"
+
+func init() {
+	replaytest.Session = func() (*http.Client, string, error) {
+		vault, _ := homedir.Expand("~/.st/vault")
+		credentials.Init(credentials.Auto, vault)
+		path, _ := homedir.Expand("~/.st/staszic_session")
+		Init(path, host, "", "staszic", "staszic")
+		c := Instances[len(Instances)-1]
+		if err := c.CheckSession(); err != nil {
+			if err = c.Login(); err != nil {
+				return nil, "", err
+			}
+		}
+		return c.client, c.Username, nil
+	}
+}
+
+func fixture(t *testing.T, path string) []byte {
+	t.Helper()
+	return replaytest.Fixture(t, host+path)
+}
+
+func TestFindStrings(t *testing.T) {
+	login := replaytest.AnonymousFixture(t, host+"/login")
+	submit := fixture(t, "/c/"+contest+"/submit/")
+	csrf := `input[name="csrfmiddlewaretoken"]`
+	replaytest.TestStrings(t, []replaytest.StringTest{
+		{Name: "username", Page: fixture(t, "/c/"+contest+"/submissions/"), Find: findUsername, Want: replaytest.User(t)},
+		{Name: "not logged", Page: login, Find: findUsername},
+		{Name: "csrf", Page: login, Find: findCsrf, Want: replaytest.Select(t, login, csrf, "value")},
+		{Name: "submit csrf", Page: submit, Find: findCsrf, Want: replaytest.Select(t, submit, csrf, "value")},
+	})
+	if _, err := findUsername(login); err == nil || err.Error() != ErrorNotLogged {
+		t.Errorf("Expect %v, but found %v.", ErrorNotLogged, err)
+	}
+}
+
+func TestFindContests(t *testing.T) {
+	body := fixture(t, "/")
+	c := &SioClient{flavour: Flavours["staszic"]}
+	contests, err := c.findContests(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := replaytest.Document(t, body).Find("table tbody").First().Find("tr")
+	if len(contests) == 0 || len(contests) != rows.Length() {
+		t.Fatalf("Expect %v contests, but found %v.", rows.Length(), contests)
+	}
+	for i, info := range contests {
+		row := rows.Eq(i)
+		want := ContestInfo{Name: strings.TrimSpace(row.Find("a").First().Text()), Subheader: !row.HasClass("contest-row")}
+		if !want.Subheader {
+			want.Alias = strings.TrimSpace(row.Find("td").First().Text())
+		}
+		if info != want {
+			t.Errorf("Expect %+v, but found %+v.", want, info)
+		}
+	}
+}
+
+func TestFindProblems(t *testing.T) {
+	body := fixture(t, "/c/"+contest+"/p/?page=1")
+	problems, err := findProblems(body, Flavours["oioioi"].PointsSelector)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := replaytest.Document(t, body).Find(`table tbody tr`).Has(`a[href*="/p/"]`)
+	if len(problems) == 0 || len(problems) != rows.Length() {
+		t.Fatalf("Expect %v problems, but found %v.", rows.Length(), problems)
+	}
+	for i, problem := range problems {
+		row := rows.Eq(i)
+		link := row.Find(`a[href*="/p/"]`).First()
+		round := row.PrevAllFiltered(".problemlist-subheader").First()
+		want := StatisInfo{
+			ID:              strings.TrimPrefix(row.Find(".problem-limits").AttrOr("id", ""), "limits_"),
+			Name:            strings.TrimPrefix(strings.TrimSpace(link.Text()), "Zadanie "),
+			Alias:           path.Base(link.AttrOr("href", "")),
+			Round:           strings.Join(strings.Fields(round.Text()), " "),
+			Points:          strings.TrimSpace(row.Find("td").Last().Text()),
+			SubmissionsLeft: strings.TrimSpace(row.Find("td").Eq(2).Text()),
+		}
+		if problem != want {
+			t.Errorf("Expect %+v, but found %+v.", want, problem)
+		}
+	}
+}
+
+func TestParseSubmission(t *testing.T) {
+	body := fixture(t, "/c/"+contest+"/submissions/")
+	rows, err := findSubmission(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := replaytest.Document(t, body).Find("table.submission tbody tr")
+	if len(rows) == 0 || len(rows) != want.Length() {
+		t.Fatalf("Expect %v submissions, but found %v.", want.Length(), len(rows))
+	}
+	problem := regexp.MustCompile(`^(.*) \((\w+)\)$`)
+	for i, row := range rows {
+		s, err := parseSubmission(row)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tr := want.Eq(i)
+		link := tr.Find(`a[href*="/s/"]`).First()
+		names := problem.FindStringSubmatch(strings.TrimSpace(tr.Find(`td[id$="-problem-instance"]`).Text()))
+		status := tr.Find(`td[id$="-status"]`)
+		switch {
+		case names == nil:
+			t.Errorf("Cannot find the problem of submission %v.", s.Id)
+		case s.Id != sio_submissions.ToInt(path.Base(link.AttrOr("href", ""))),
+			s.When != strings.TrimSpace(link.Text()),
+			s.Name != names[1], s.ShortName != names[2],
+			s.Points != sio_submissions.ToInt(strings.TrimSpace(tr.Find(`td[id$="-score"]`).Text())),
+			s.Kind != strings.TrimSpace(tr.Find(`td[id$="-kind"]`).Text()),
+			!strings.HasSuffix(s.Status, strings.TrimSpace(status.Text())),
+			s.End == status.HasClass("submission--PENDING"):
+			t.Errorf("Submission %v doesn't match its row: %+v.", i+1, s)
+		}
+	}
+}
+
+func TestSubmitForm(t *testing.T) {
+	body := fixture(t, "/c/"+contest+"/submit/")
+	doc := replaytest.Document(t, body)
+	options := func(name string) *goquery.Selection {
+		return doc.Find(`select[name="` + name + `"] option`).FilterFunction(func(_ int, s *goquery.Selection) bool {
+			return s.AttrOr("value", "") != ""
+		})
+	}
+
+	languages, err := findLanguages(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := options("prog_lang")
+	if len(languages) == 0 || len(languages) != want.Length() {
+		t.Fatalf("Expect %v languages, but found %v.", want.Length(), languages)
+	}
+	for i, language := range languages {
+		if language.Value != want.Eq(i).AttrOr("value", "") || language.Name != strings.TrimSpace(want.Eq(i).Text()) {
+			t.Errorf("Expect %v, but found %+v.", want.Eq(i).Text(), language)
+		}
+	}
+
+	kinds, names, err := findKinds(body)
+	want = options("kind")
+	if err != nil || len(kinds) != want.Length() {
+		t.Fatalf("Expect %v kinds, but found %v (%v).", want.Length(), kinds, err)
+	}
+	for i := range kinds {
+		if kinds[i] != want.Eq(i).AttrOr("value", "") || names[i] != strings.TrimSpace(want.Eq(i).Text()) {
+			t.Errorf("Expect %v, but found %v %v.", want.Eq(i).Text(), kinds[i], names[i])
+		}
+	}
+
+	alias := regexp.MustCompile(`\((\w+)\)$`)
+	options("problem_instance_id").Each(func(_ int, s *goquery.Selection) {
+		found := alias.FindStringSubmatch(strings.TrimSpace(s.Text()))
+		if found == nil {
+			t.Errorf("Cannot find the alias of %v.", s.Text())
+			return
+		}
+		info := Info{ProblemAlias: found[1]}
+		if err = findProblemID(body, &info); err != nil || info.ProblemID != s.AttrOr("value", "") {
+			t.Errorf("%v: expect %v, but found %v (%v).", found[1], s.AttrOr("value", ""), info.ProblemID, err)
+		}
+	})
+	if canSubmitAsUser(body) != (doc.Find(`select[name="user"], input[name="user"]`).Length() > 0) {
+		t.Errorf("Expect the user field only when the form has one.")
+	}
+}
+
+func TestFindPackages(t *testing.T) {
+	body := fixture(t, "/c/"+contest+"/admin/contests/probleminstance")
+	packages, err := findPackages(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := replaytest.Document(t, body).Find("#result_list tbody tr")
+	if len(packages) == 0 || len(packages) != rows.Length() {
+		t.Fatalf("Expect %v packages, but found %v.", rows.Length(), packages)
+	}
+	instance := regexp.MustCompile(`/probleminstance/(\d+)/`)
+	for i, p := range packages {
+		row := rows.Eq(i)
+		actions := row.Find(".field-actions_field")
+		change := instance.FindStringSubmatch(actions.Find(`a[href*="/probleminstance/"]`).AttrOr("href", ""))
+		reupload := actions.Find(`a[href*="add_or_update"]`).AttrOr("href", "")
+		want := PackageInfo{
+			Name:       strings.TrimSpace(row.Find(".field-name_link").Text()),
+			Alias:      strings.TrimSpace(row.Find(".field-short_name_link").Text()),
+			Round:      strings.TrimSpace(row.Find(".field-round").Text()),
+			Package:    row.Find(".field-package a").AttrOr("href", ""),
+			ReuploadId: regexp.MustCompile(`problem=(\d+)`).FindStringSubmatch(reupload)[1],
+			Rejudge:    actions.Find(`a[href*="rejudge"]`).AttrOr("href", ""),
+		}
+		if change != nil {
+			want.ProblemId = change[1]
+		}
+		if p != want {
+			t.Errorf("Expect %+v, but found %+v.", want, p)
+		}
+	}
+}
+
+func TestFindRanking(t *testing.T) {
+	body := fixture(t, "/c/"+contest+"/ranking/")
+	doc := replaytest.Document(t, body)
+	user := replaytest.User(t)
+	problems, rows, err := findRanking(doc, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := doc.Find("table thead th")
+	if len(problems) != header.Length()-3 {
+		t.Errorf("Expect %v problems, but found %v.", header.Length()-3, problems)
+	}
+	for i, problem := range problems {
+		if want := strings.TrimSpace(header.Eq(i + 2).Text()); problem != want {
+			t.Errorf("Expect problem %v, but found %v.", want, problem)
+		}
+	}
+	want := doc.Find("table tbody tr")
+	if len(rows) == 0 || len(rows) != want.Length() {
+		t.Fatalf("Expect %v rows, but found %v.", want.Length(), rows)
+	}
+	current := 0
+	for i, row := range rows {
+		cells := want.Eq(i).Find("td")
+		ok := row.Place == strings.TrimSpace(cells.First().Text()) &&
+			row.User == strings.TrimSpace(cells.Eq(1).Text()) &&
+			row.Total == strings.TrimSpace(cells.Last().Text()) &&
+			len(row.Scores) == len(problems) &&
+			row.Current == (row.User == user)
+		for j, score := range row.Scores {
+			ok = ok && score == strings.TrimSpace(cells.Eq(j+2).Text())
+		}
+		if !ok {
+			t.Errorf("Row %v doesn't match the ranking: %+v.", i+1, row)
+		}
+		if row.Current {
+			current++
+		}
+	}
+	if current != 1 {
+		t.Errorf("Expect the row of %v once, but found it %v times.", user, current)
+	}
+	round := doc.Find(`a[href*="/ranking/"]`).Last()
+	URL, err := findRoundRankingURL(doc, host, strings.TrimSpace(round.Text()))
+	if err != nil || URL != host+round.AttrOr("href", "") {
+		t.Errorf("Expect the ranking of %v, but found %v (%v).", round.Text(), URL, err)
+	}
+}
+
+func TestCheckSession(t *testing.T) {
+	c := &SioClient{host: host, client: replaytest.FixtureClient(t), flavour: Flavours["staszic"]}
+	if err := c.CheckSession(); err != nil {
+		t.Errorf("Expect a valid session, but found %v.", err)
+	}
+	c.client = replaytest.AnonymousClient()
+	if err := c.CheckSession(); !c.Site().NotLogged(err) {
+		t.Errorf("Expect an expired session, but found %v.", err)
+	}
+}
+
+func TestRankingDiff(t *testing.T) {
+	previous := Ranking{Rows: []RankingRow{{Place: "1.", User: "a"}, {Place: "2.", User: "b"}, {Place: "", User: "c"}, {Place: "3.", User: "d"}}}
+	ranking := Ranking{Rows: []RankingRow{{Place: "1.", User: "b"}, {Place: "2.", User: "c"}, {Place: "3.", User: "a"}, {Place: "4.", User: "e"}, {Place: "-", User: "d"}}}
+	ranking.Diff(previous)
+	want := []RankingRow{{Place: "1.", User: "b", Change: 1}, {Place: "2.", User: "c"}, {Place: "3.", User: "a", Change: -2}, {Place: "4.", User: "e", New: true}, {Place: "-", User: "d"}}
+	for i, row := range ranking.Rows {
+		if row.Change != want[i].Change || row.New != want[i].New {
+			t.Errorf("%v: expect %+v, but found %+v.", row.User, want[i], row)
+		}
+	}
+}
+
+func TestDownloadPackage(t *testing.T) {
+	retryDelay = time.Millisecond
+	defer func() { retryDelay = time.Second }()
+	const content = "package"
+	tests := []struct {
+		name     string
+		statuses []int
+		attempts int
+		ok       bool
+	}{
+		{"downloaded", []int{http.StatusOK}, 1, true},
+		{"server error", []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusOK}, 3, true},
+		{"incomplete", []int{-1, http.StatusOK}, 2, true},
+		{"not found", []int{http.StatusNotFound}, 1, false},
+		{"forbidden", []int{http.StatusForbidden}, 1, false},
+		{"retries run out", []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusOK}, 3, false},
+	}
+	for _, test := range tests {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			status := test.statuses[attempts]
+			attempts++
+			w.Header().Set("ETag", `"1"`)
+			w.Header().Set("Content-Disposition", `attachment; filename="sum.zip"`)
+			if status == -1 {
+				w.Header().Set("Content-Length", fmt.Sprint(len(content)))
+				w.Write([]byte(content[:3]))
+				return
+			}
+			w.WriteHeader(status)
+			if status == http.StatusOK {
+				w.Write([]byte(content))
+			}
+		}))
+		root := t.TempDir()
+		// the shared transport retries the other requests, the attempts show that it doesn't retry these
+		c := &SioClient{host: server.URL, client: &http.Client{Transport: &util.Transport{Base: server.Client().Transport}}}
+		m := loadManifest(root)
+		p := PackageInfo{Name: "sum", Package: "/c/kurs/problems/problempackage/512/download/"}
+		result := c.downloadPackage(p, root, 2, m, &progress{total: 1})
+		server.Close()
+
+		if attempts != test.attempts || (result.Err == nil) != test.ok {
+			t.Errorf("%v: expect %v attempts (ok %v), but found %v (%v).", test.name, test.attempts, test.ok, attempts, result.Err)
+		}
+		entry, known := m.get(p.Name)
+		data, err := os.ReadFile(filepath.Join(root, "sum.zip"))
+		if test.ok && (string(data) != content || entry != (manifestEntry{File: "sum.zip", Size: int64(len(content)), ETag: `"1"`})) {
+			t.Errorf("%v: expect the package in the manifest, but found %q %+v.", test.name, data, entry)
+		}
+		if !test.ok && (known || err == nil) {
+			t.Errorf("%v: expect no package, but found %+v.", test.name, entry)
+		}
+	}
+}
+
+func TestPostForm(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		err    string
+	}{
+		{"saved", http.StatusOK, "<ul class=\"messagelist\"><li>Saved</li></ul>", ""},
+		{"session expired", http.StatusOK, "<h1>Log in</h1>", ErrorNotLogged},
+		{"refused", http.StatusForbidden, "<h1>CSRF verification failed</h1>", "403 Forbidden"},
+	}
+	for _, test := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(test.status)
+			w.Write([]byte(test.body))
+		}))
+		c := &SioClient{host: server.URL, client: server.Client()}
+		body, err := c.postForm(server.URL, server.URL, nil)
+		server.Close()
+
+		if test.err == "" && (err != nil || string(body) != test.body) {
+			t.Errorf("%v: expect the page, but found %q (%v).", test.name, body, err)
+		}
+		if test.err != "" && (err == nil || !strings.HasSuffix(err.Error(), test.err)) {
+			t.Errorf("%v: expect %q, but found %v.", test.name, test.err, err)
+		}
+	}
+}
+
+func TestRankingName(t *testing.T) {
+	tests := []struct {
+		ranking Ranking
+		want    string
+	}{
+		{Ranking{Contest: "kurs"}, "kurs_all"},
+		{Ranking{Contest: "kurs", Round: "Runda 1"}, "kurs_runda_1"},
+		{Ranking{Contest: "kurs", Round: "Runda 1/2: Łódź"}, "kurs_runda_12_lodz"},
+	}
+	for _, test := range tests {
+		if got := test.ranking.Name(); got != test.want {
+			t.Errorf("%q: expect %q, but found %q.", test.ranking.Round, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package sio_client
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/Arapak/sio-tool/site"
+	"github.com/Arapak/sio-tool/util"
+)
+
+// judge implements site.Site for one Sio instance.
+type judge struct {
+	site.Judge
+	c *SioClient
+}
+
+func (c *SioClient) Site() site.Site {
+	return judge{site.Judge{
+		Session:        c,
+		ID:             c.name,
+		HostOf:         func() string { return c.host },
+		UsernameOf:     func() string { return c.Username },
+		ErrorNotLogged: ErrorNotLogged,
+	}, c}
+}
+
+func sioInfo(info site.Info) (*Info, error) {
+	if i, ok := info.(*Info); ok {
+		return i, nil
+	}
+	return nil, errors.New(site.ErrorWrongInfo)
+}
+
+func (j judge) AcceptedExtensions(info site.Info) map[string]struct{} {
+	if i, err := sioInfo(info); err == nil {
+		return j.c.AcceptedExtensions(*i)
+	}
+	return j.c.AcceptedExtensions(Info{})
+}
+
+func (j judge) Parse(info site.Info, db *sql.DB) (paths []string, err error) {
+	i, err := sioInfo(info)
+	if err != nil {
+		return
+	}
+	_, paths, err = j.c.Parse(*i, db)
+	return
+}
+
+func (j judge) Submit(info site.Info, options site.SubmitOptions, sourcePath string, db *sql.DB) error {
+	i, err := sioInfo(info)
+	if err != nil {
+		return err
+	}
+	return j.c.Submit(*i, SubmitOptions(options), sourcePath, db)
+}
+
+func (j judge) Watch(info site.Info, n int, line bool) error {
+	i, err := sioInfo(info)
+	if err != nil {
+		return err
+	}
+	_, err = j.c.WatchSubmission(*i, n, line)
+	return err
+}
+
+func (j judge) Statis(info site.Info) (header []string, problems []site.Problem, perf util.Performance, err error) {
+	i, err := sioInfo(info)
+	if err != nil {
+		return
+	}
+	statis, perf, err := j.c.Statis(*i)
+	if err != nil {
+		return
+	}
+	header = []string{"round", "name", "alias", "points"}
+	for _, prob := range statis {
+		problems = append(problems, site.Problem{
+			Columns: []string{
+				util.LimitNumOfChars(prob.Round, 20),
+				util.LimitNumOfChars(prob.Name, 25),
+				prob.Alias,
+				prob.ParsePoint(),
+			},
+		})
+	}
+	return
+}
+
+func (j judge) OpenURL(info site.Info) (string, error) {
+	i, err := sioInfo(info)
+	if err != nil {
+		return "", err
+	}
+	return i.OpenURL(j.c.host)
+}
+
+func (j judge) StandingsURL(info site.Info) (string, error) {
+	i, err := sioInfo(info)
+	if err != nil {
+		return "", err
+	}
+	return i.StandingsURL(j.c, j.c.host)
+}
+
+func (j judge) SubmissionURL(info site.Info) (string, error) {
+	i, err := sioInfo(info)
+	if err != nil {
+		return "", err
+	}
+	if i.SubmissionID == "" && j.c.LastSubmission != nil {
+		i = j.c.LastSubmission
+	}
+	return i.SubmissionURL(j.c.host, false)
+}
+
+// Race sets the round of info to the round which has just started.
+func (j judge) Race(info site.Info) (urls []string, err error) {
+	i, err := sioInfo(info)
+	if err != nil {
+		return
+	}
+	if i.Round, err = j.c.RaceContest(*i); err != nil {
+		return
+	}
+	URL, err := i.ContestURL(j.c.host)
+	if err != nil {
+		return
+	}
+	return []string{URL}, nil
+}
@@ -19,6 +19,8 @@ type StatisInfo struct {
 	Alias  string
 	Round  string
 	Points string
+	// SubmissionsLeft is empty when the contest doesn't limit the number of submissions.
+	SubmissionsLeft string
 }
 
 const ErrorContestNotFound = "contest not found"
@@ -40,12 +42,19 @@ func (prob *StatisInfo) ParsePoint() string {
 	}
 }
 
-func findProblems(body []byte, curIns SioInstanceClient) (ret []StatisInfo, err error) {
+func findProblems(body []byte, pointsSelector string) (ret []StatisInfo, err error) {
 	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(body)))
 	if err != nil {
 		return
 	}
 	var round = "none"
+	submissionsLeftColumn := -1
+	doc.Find("table thead").First().Find("th").Each(func(i int, s *goquery.Selection) {
+		header := strings.ToLower(strings.TrimSpace(s.Text()))
+		if strings.Contains(header, "left") || strings.Contains(header, "pozosta") {
+			submissionsLeftColumn = i
+		}
+	})
 	doc.Find("table tbody").First().Find("tr").Each(func(_ int, s *goquery.Selection) {
 		class, _ := s.Attr("class")
 		if strings.Contains(class, "problemlist-subheader") {
@@ -57,11 +66,9 @@ func findProblems(body []byte, curIns SioInstanceClient) (ret []StatisInfo, err
 		info.Name = strings.TrimPrefix(strings.TrimSpace(s.Find("a").First().Text()), "Zadanie ")
 		info.Alias = strings.TrimSpace(s.Find("td").First().Text())
 		info.ID = strings.TrimPrefix(s.Find("div").First().AttrOr("id", ""), "limits_")
-		if curIns == Mimuw || curIns == Staszic {
-			info.Points = strings.TrimSpace(s.Find(".label").First().Text())
-		}
-		if curIns == Talent {
-			info.Points = strings.TrimSpace(s.Find(".badge").First().Text())
+		info.Points = strings.TrimSpace(s.Find(pointsSelector).First().Text())
+		if submissionsLeftColumn != -1 {
+			info.SubmissionsLeft = strings.TrimSpace(s.Find("td").Eq(submissionsLeftColumn).Text())
 		}
 
 		ret = append(ret, info)
@@ -109,7 +116,7 @@ func (c *SioClient) Statis(info Info) (problems []StatisInfo, perf util.Performa
 			return
 		}
 
-		problemsOnPage, err = findProblems(body, c.instanceClient)
+		problemsOnPage, err = findProblems(body, c.flavour.PointsSelector)
 		if err != nil {
 			return
 		}
@@ -2,6 +2,7 @@ package sio_client
 
 import (
 	"bytes"
+	"database/sql"
 	"errors"
 	"fmt"
 	"io"
@@ -10,8 +11,11 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/Arapak/sio-tool/account"
+	"github.com/Arapak/sio-tool/database_client"
 	"github.com/Arapak/sio-tool/util"
 
 	"github.com/PuerkitoBio/goquery"
@@ -64,7 +68,95 @@ func checkSubmitOption(body []byte) bool {
 		bytes.Contains(body, []byte("Niestety nie ma tu żadnych zadań, do których możesz przysłać rozwiązanie…"))
 }
 
-func (c *SioClient) Submit(info Info, sourcePath string) (err error) {
+// submissionsLeft returns the number of submissions left for the problem, as shown on the problem list.
+func (c *SioClient) submissionsLeft(info Info) int {
+	filter := Info{Contest: info.Contest, ProblemAlias: info.ProblemAlias}
+	if filter.ProblemAlias == "" {
+		filter.ProblemID = info.ProblemID
+	}
+	problems, _, err := c.Statis(filter)
+	if err != nil || len(problems) != 1 {
+		return database_client.UnknownLimit
+	}
+	left, err := strconv.Atoi(regexp.MustCompile(`^\d+`).FindString(problems[0].SubmissionsLeft))
+	if err != nil {
+		return database_client.UnknownLimit
+	}
+	return left
+}
+
+// SubmitOptions are the language of the submission and the options available to contest admins.
+type SubmitOptions struct {
+	LangID string
+	// Lang is the name of the template's language, e.g. "GNU G++17 7.3.0".
+	Lang string
+	// Kind is the value of the submission kind, e.g. "NORMAL" or "IGNORED".
+	Kind string
+	// User is the login of the user to submit as, or empty to submit as yourself.
+	User string
+}
+
+const ErrorCannotSubmitAsUser = "you can't submit as another user in this contest"
+
+// findKinds returns the values of the submission kind select of the submit form.
+func findKinds(body []byte) (kinds []string, names []string, err error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(body)))
+	if err != nil {
+		return
+	}
+	doc.Find(`select[name="kind"] option`).Each(func(_ int, s *goquery.Selection) {
+		if value, _ := s.Attr("value"); value != "" {
+			kinds = append(kinds, value)
+			names = append(names, strings.TrimSpace(s.Text()))
+		}
+	})
+	return
+}
+
+// canSubmitAsUser reports whether the submit form lets you choose the user (only for contest admins).
+func canSubmitAsUser(body []byte) bool {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(body)))
+	if err != nil {
+		return false
+	}
+	if doc.Find(`select[name="user"]`).Length() > 0 {
+		return true
+	}
+	input := doc.Find(`input[name="user"]`)
+	return input.Length() > 0 && input.AttrOr("type", "text") != "hidden"
+}
+
+// validateSubmitOptions checks the kind and the user against the submit form and
+// replaces the kind with the value expected by the form.
+func validateSubmitOptions(body []byte, options *SubmitOptions) error {
+	kinds, names, err := findKinds(body)
+	if err != nil {
+		return err
+	}
+	if options.Kind == "" {
+		options.Kind = "NORMAL"
+	}
+	if len(kinds) == 0 {
+		kinds, names = []string{"NORMAL"}, []string{"NORMAL"}
+	}
+	found := false
+	for i := range kinds {
+		if strings.EqualFold(options.Kind, kinds[i]) || strings.EqualFold(options.Kind, names[i]) {
+			options.Kind = kinds[i]
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("unknown submission kind %q, the allowed kinds are: %v", options.Kind, strings.Join(kinds, ", "))
+	}
+	if options.User != "" && !canSubmitAsUser(body) {
+		return errors.New(ErrorCannotSubmitAsUser)
+	}
+	return nil
+}
+
+func (c *SioClient) Submit(info Info, options SubmitOptions, sourcePath string, db *sql.DB) (err error) {
 	URL, err := info.SubmitURL(c.host)
 	if err != nil {
 		return
@@ -96,8 +188,39 @@ func (c *SioClient) Submit(info Info, sourcePath string) (err error) {
 		}
 	}
 
+	languages, err := findLanguages(submitPageBody)
+	if err != nil {
+		return
+	}
+	language, err := util.ChooseLanguage(languages, options.Lang, sourcePath)
+	if err != nil {
+		return
+	}
+
+	if err = validateSubmitOptions(submitPageBody, &options); err != nil {
+		return
+	}
+	user := c.Username
+	if options.User != "" {
+		user = options.User
+	}
+
 	color.Cyan("Submit " + info.Hint())
-	fmt.Printf("Current user: %v\n", c.Username)
+	fmt.Printf("Current user: %v\n", account.Label(c.path, c.Username))
+	if user != c.Username {
+		fmt.Printf("Submit as: %v\n", user)
+	}
+	if options.Kind != "NORMAL" {
+		fmt.Printf("Kind: %v\n", options.Kind)
+	}
+	if language != "" {
+		fmt.Printf("Language: %v\n", language)
+	}
+
+	check := database_client.Submission{Judge: c.name, ContestID: info.Contest, ShortName: info.ProblemAlias}
+	if err = database_client.CheckSubmission(db, check, sourcePath, c.submissionsLeft(info)); err != nil {
+		return
+	}
 
 	sourceFile, err := os.Open(sourcePath)
 	if err != nil {
@@ -135,7 +258,7 @@ func (c *SioClient) Submit(info Info, sourcePath string) (err error) {
 	if err != nil {
 		return
 	}
-	_, err = io.Copy(part, strings.NewReader(c.Username))
+	_, err = io.Copy(part, strings.NewReader(user))
 	if err != nil {
 		return
 	}
@@ -143,10 +266,20 @@ func (c *SioClient) Submit(info Info, sourcePath string) (err error) {
 	if err != nil {
 		return
 	}
-	_, err = io.Copy(part, strings.NewReader("NORMAL"))
+	_, err = io.Copy(part, strings.NewReader(options.Kind))
 	if err != nil {
 		return
 	}
+	if language != "" {
+		part, err = writer.CreateFormField("prog_lang")
+		if err != nil {
+			return
+		}
+		_, err = io.Copy(part, strings.NewReader(language))
+		if err != nil {
+			return
+		}
+	}
 	writer.Close()
 
 	req, err := http.NewRequest("POST", URL, body)
@@ -182,6 +315,11 @@ func (c *SioClient) Submit(info Info, sourcePath string) (err error) {
 
 	if isSubmissionsPage {
 		color.Green("Submitted")
+		if user != c.Username {
+			// The submission isn't on your submissions page, so it can't be watched.
+			color.Cyan(`Use "st admin submissions --user %v" to see its status`, user)
+			return c.save()
+		}
 
 		submissions, err := c.WatchSubmission(info, 1, true)
 		if err != nil {
@@ -190,6 +328,12 @@ func (c *SioClient) Submit(info Info, sourcePath string) (err error) {
 
 		info.SubmissionID = submissions[0].ParseID()
 		c.LastSubmission = &info
+		c.recordSubmission(db, info, submissions[0], sourcePath)
+		if submissions[0].CompilationError() {
+			if err = c.PrintCompilationLog(info, sourcePath); err != nil {
+				color.Red(err.Error())
+			}
+		}
 	} else {
 		fmt.Print("an error occurred: ")
 		err = getErrorsFromBody(responseBody)
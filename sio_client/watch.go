@@ -9,13 +9,13 @@ import (
 	"net/http"
 	"regexp"
 	"strings"
-	"time"
 
 	"github.com/Arapak/sio-tool/sio_submissions"
 	"github.com/Arapak/sio-tool/szkopul_client"
 	"github.com/Arapak/sio-tool/util"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/fatih/color"
 )
 
 func getSubmissionID(body string) (string, error) {
@@ -91,6 +91,7 @@ func parseSubmission(s *goquery.Selection) (ret sio_submissions.Submission, err
 		Status:    status,
 		Points:    points,
 		When:      when,
+		Kind:      kind,
 		End:       end,
 	}, nil
 }
@@ -120,7 +121,7 @@ func (c *SioClient) getSubmissions(URL string, n int) (submissions []sio_submiss
 	}
 
 	if len(submissions) < 1 {
-		return nil, errors.New("cannot find any submission")
+		return nil, errors.New(sio_submissions.ErrorNoSubmissions)
 	}
 
 	return
@@ -194,19 +195,30 @@ func (c *SioClient) WatchSubmission(info Info, n int, line bool) (submissions []
 
 	maxWidth := 0
 	first := true
+	poller := util.NewPoller()
+	previous := ""
+	var pending map[uint64]bool
 	revealstate := NotScored
 	for {
-		st := time.Now()
-		if c.instanceClient == Staszic {
+		if c.flavour.OldSubmissions {
 			submissions, err = c.getSubmissions(URL, n)
 		} else {
 			submissions, err = szkopul_client.GetSubmissions(c.client, URL, n)
 		}
 		if err != nil {
+			if poller.Backoff(err) {
+				if err = poller.Wait(); err != nil {
+					return
+				}
+				continue
+			}
 			return
 		}
 
 		sio_submissions.Display(submissions, first, &maxWidth, line)
+		if first {
+			pending = sio_submissions.Pending(submissions)
+		}
 		first = false
 
 		endCount := 0
@@ -216,22 +228,43 @@ func (c *SioClient) WatchSubmission(info Info, n int, line bool) (submissions []
 			}
 		}
 		if endCount == len(submissions) && (revealstate != NotScored || n != 1) {
+			if !line {
+				c.printCompilationLogs(info, submissions, pending)
+			}
 			return
 		}
 		if n == 1 && len(submissions) == 1 {
 			info.SubmissionID = submissions[0].ParseID()
 		}
 
-		if info.SubmissionID != "" {
+		// Once the score is revealed (or can't be revealed) there is no need to ask again.
+		if info.SubmissionID != "" && revealstate == NotScored {
 			revealstate, err = c.RevealSubmission(info)
 			if err != nil {
 				return
 			}
 		}
 
-		sub := time.Since(st)
-		if sub < time.Second {
-			time.Sleep(time.Second - sub)
+		if state := sio_submissions.Signature(submissions); state != previous {
+			previous = state
+			poller.Reset()
+		}
+		if err = poller.Wait(); err != nil {
+			return
+		}
+	}
+}
+
+// printCompilationLogs prints compiler output of the submissions which failed to compile while being watched.
+func (c *SioClient) printCompilationLogs(info Info, submissions []sio_submissions.Submission, pending map[uint64]bool) {
+	for _, submission := range submissions {
+		if !pending[submission.Id] || !submission.CompilationError() {
+			continue
+		}
+		info.SubmissionID = submission.ParseID()
+		color.Cyan("Submission #%v", info.SubmissionID)
+		if err := c.PrintCompilationLog(info, ""); err != nil {
+			color.Red(err.Error())
 		}
 	}
 }
@@ -0,0 +1,43 @@
+package sio_submissions
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const ErrorCompilationLogNotFound = "cannot find the compilation log"
+
+func (s *Submission) CompilationError() bool {
+	status := strings.ToLower(s.Status)
+	return strings.Contains(status, "kompilacji") || strings.Contains(status, "compilation")
+}
+
+// ParseCompilationLog finds the compiler output on an OIOIOI submission page.
+func ParseCompilationLog(body []byte) (string, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	pre := doc.Find(".submission-report pre, #content pre, main pre").First()
+	if pre.Length() == 0 {
+		pre = doc.Find("pre").First()
+	}
+	if pre.Length() == 0 {
+		return "", errors.New(ErrorCompilationLogNotFound)
+	}
+	return pre.Text(), nil
+}
+
+// Pending returns the IDs of the submissions which are still being judged.
+func Pending(submissions []Submission) map[uint64]bool {
+	pending := make(map[uint64]bool)
+	for _, s := range submissions {
+		if !s.End {
+			pending[s.Id] = true
+		}
+	}
+	return pending
+}
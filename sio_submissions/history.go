@@ -0,0 +1,50 @@
+package sio_submissions
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const ErrorNoSubmissions = "cannot find any submission"
+
+var colorTagReg = regexp.MustCompile(`\$\{c-\w+}`)
+
+// PlainStatus returns the status without color tags.
+func (s *Submission) PlainStatus() string {
+	return strings.TrimSpace(colorTagReg.ReplaceAllString(s.Status, ""))
+}
+
+// Signature changes whenever the status or the score of any of the submissions changes.
+func Signature(submissions []Submission) string {
+	var signature strings.Builder
+	for _, s := range submissions {
+		signature.WriteString(fmt.Sprint(s.Id, s.Status, s.Points, ";"))
+	}
+	return signature.String()
+}
+
+// CollectPages fetches consecutive pages of a submissions list until a page has no new submissions.
+func CollectPages(fetch func(page int) ([]Submission, error)) (submissions []Submission, err error) {
+	seen := make(map[uint64]bool)
+	for page := 1; ; page++ {
+		current, e := fetch(page)
+		if e != nil {
+			if page == 1 && e.Error() != ErrorNoSubmissions {
+				err = e
+			}
+			return
+		}
+		added := 0
+		for _, s := range current {
+			if !seen[s.Id] {
+				seen[s.Id] = true
+				submissions = append(submissions, s)
+				added++
+			}
+		}
+		if added == 0 {
+			return
+		}
+	}
+}
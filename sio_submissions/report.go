@@ -0,0 +1,204 @@
+package sio_submissions
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/fatih/color"
+	"github.com/k0kubun/go-ansi"
+	"github.com/olekukonko/tablewriter"
+)
+
+const ErrorReportNotFound = "no test report found for this submission"
+
+type TestResult struct {
+	Name      string `json:"name"`
+	Group     int    `json:"group"`
+	Status    string `json:"status"`
+	Verdict   string `json:"verdict"`
+	Time      string `json:"time"`
+	TimeLimit string `json:"time_limit"`
+}
+
+type GroupResult struct {
+	Points    string `json:"points"`
+	MaxPoints string `json:"max_points"`
+}
+
+type Report struct {
+	SubmissionID string        `json:"submission_id"`
+	Tests        []TestResult  `json:"tests"`
+	Groups       []GroupResult `json:"groups"`
+}
+
+var statusClassReg = regexp.MustCompile(`submission--(\w+)`)
+var timeReg = regexp.MustCompile(`^([\d.]+)\s*s?\s*/\s*([\d.]+)\s*s?$`)
+var pointsReg = regexp.MustCompile(`^([\d.]*)\s*(/\s*([\d.]+))?$`)
+
+func cellText(s *goquery.Selection) string {
+	return strings.Join(strings.Fields(s.Text()), " ")
+}
+
+// ParseReport parses the per-test tables of an OIOIOI submission page.
+func ParseReport(body []byte) (report Report, err error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	index := make(map[string]int)
+	doc.Find("table.table-report").Each(func(_ int, table *goquery.Selection) {
+		table.Find("tbody tr").Each(func(_ int, tr *goquery.Selection) {
+			cells := tr.Find("td")
+			if cells.Length() < 2 {
+				return
+			}
+			test := TestResult{Name: cellText(cells.Eq(0)), Verdict: cellText(cells.Eq(1)), Status: cellText(cells.Eq(1))}
+			if status := statusClassReg.FindStringSubmatch(cells.Eq(1).AttrOr("class", "")); status != nil {
+				test.Status = status[1]
+			}
+			if t := timeReg.FindStringSubmatch(cellText(cells.Eq(2))); t != nil {
+				test.Time = t[1]
+				test.TimeLimit = t[2]
+			}
+			// The points cell spans all rows of a group, so it is only present in the group's first row.
+			if cells.Length() > 3 {
+				if p := pointsReg.FindStringSubmatch(cellText(cells.Eq(3))); p != nil {
+					report.Groups = append(report.Groups, GroupResult{Points: p[1], MaxPoints: p[3]})
+				}
+			}
+			test.Group = len(report.Groups)
+			if i, ok := index[test.Name]; ok {
+				report.Tests[i] = test
+			} else {
+				index[test.Name] = len(report.Tests)
+				report.Tests = append(report.Tests, test)
+			}
+		})
+	})
+	if len(report.Tests) == 0 {
+		err = errors.New(ErrorReportNotFound)
+	}
+	return
+}
+
+func (r *Report) Points() (points, maxPoints float64) {
+	for _, group := range r.Groups {
+		p, _ := strconv.ParseFloat(group.Points, 64)
+		m, _ := strconv.ParseFloat(group.MaxPoints, 64)
+		points += p
+		maxPoints += m
+	}
+	return
+}
+
+func (t *TestResult) ParseStatus() string {
+	if t.Status == "OK" {
+		return color.New(color.FgGreen).Sprint(t.Verdict)
+	}
+	return color.New(color.FgRed).Sprint(t.Verdict)
+}
+
+func (t *TestResult) ParseTime() string {
+	if t.Time == "" {
+		return ""
+	}
+	return fmt.Sprintf("%vs/%vs", t.Time, t.TimeLimit)
+}
+
+func (r *Report) groupPoints(group int) string {
+	if group < 1 || group > len(r.Groups) {
+		return ""
+	}
+	g := r.Groups[group-1]
+	if g.MaxPoints == "" {
+		return g.Points
+	}
+	return fmt.Sprintf("%v/%v", g.Points, g.MaxPoints)
+}
+
+func printTable(header []string, rows [][]string) {
+	var buf bytes.Buffer
+	output := io.Writer(&buf)
+	table := tablewriter.NewWriter(output)
+	table.SetHeader(header)
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetAlignment(tablewriter.ALIGN_CENTER)
+	table.SetCenterSeparator("|")
+	table.SetAutoWrapText(false)
+	table.AppendBulk(rows)
+	table.Render()
+
+	scanner := bufio.NewScanner(io.Reader(&buf))
+	for scanner.Scan() {
+		_, _ = ansi.Println(scanner.Text())
+	}
+}
+
+func (r *Report) summary() string {
+	counts := make(map[string]int)
+	var statuses []string
+	maxTime := 0.0
+	for _, test := range r.Tests {
+		if counts[test.Status] == 0 {
+			statuses = append(statuses, test.Status)
+		}
+		counts[test.Status]++
+		if t, err := strconv.ParseFloat(test.Time, 64); err == nil && t > maxTime {
+			maxTime = t
+		}
+	}
+	points, maxPoints := r.Points()
+	summary := fmt.Sprintf("TESTS: %v MAX TIME: %0.3fs POINTS: %v/%v", color.New(color.FgBlue).Sprint(len(r.Tests)), maxTime, points, maxPoints)
+	for _, status := range statuses {
+		if status == "OK" {
+			summary += fmt.Sprintf(" OK: %v", color.New(color.FgGreen).Sprint(counts[status]))
+		} else {
+			summary += fmt.Sprintf(" %v: %v", status, color.New(color.FgRed).Sprint(counts[status]))
+		}
+	}
+	return summary
+}
+
+func (r *Report) Display() {
+	var rows [][]string
+	for i, test := range r.Tests {
+		points := ""
+		if i == 0 || r.Tests[i-1].Group != test.Group {
+			points = r.groupPoints(test.Group)
+		}
+		rows = append(rows, []string{test.Name, test.ParseStatus(), test.ParseTime(), points})
+	}
+	printTable([]string{"test", "result", "time", "points"}, rows)
+	_, _ = ansi.Println(r.summary())
+}
+
+// DisplayComparison prints the results of two submissions test by test, highlighting differences.
+func DisplayComparison(a, b Report) {
+	tests := make(map[string]TestResult)
+	for _, test := range b.Tests {
+		tests[test.Name] = test
+	}
+	var rows [][]string
+	for i, test := range a.Tests {
+		other := tests[test.Name]
+		name := test.Name
+		if test.Status != other.Status {
+			name = color.New(color.FgYellow).Sprint(name)
+		}
+		pointsA, pointsB := "", ""
+		if i == 0 || a.Tests[i-1].Group != test.Group {
+			pointsA, pointsB = a.groupPoints(test.Group), b.groupPoints(other.Group)
+		}
+		rows = append(rows, []string{name, test.ParseStatus(), test.ParseTime(), pointsA, other.ParseStatus(), other.ParseTime(), pointsB})
+	}
+	printTable([]string{"test", "#" + a.SubmissionID, "time", "points", "#" + b.SubmissionID, "time", "points"}, rows)
+	_, _ = ansi.Printf("#%v %v\n", a.SubmissionID, a.summary())
+	_, _ = ansi.Printf("#%v %v\n", b.SubmissionID, b.summary())
+}
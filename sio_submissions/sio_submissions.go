@@ -21,6 +21,7 @@ type Submission struct {
 	Status    string
 	Points    uint64
 	When      string
+	Kind      string
 	End       bool
 }
 
@@ -35,6 +36,24 @@ func (s *Submission) ParseStatus() string {
 	return status
 }
 
+// ParseKind returns the kind of the submission, or an empty string for normal submissions.
+func (s *Submission) ParseKind() string {
+	switch strings.ToLower(s.Kind) {
+	case "", "normal", "normalne":
+		return ""
+	}
+	return s.Kind
+}
+
+func hasKinds(submissions []Submission) bool {
+	for _, s := range submissions {
+		if s.ParseKind() != "" {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *Submission) ParseID() string {
 	return fmt.Sprintf("%v", s.Id)
 }
@@ -77,13 +96,20 @@ func updateLine(line string, maxWidth *int) string {
 }
 
 func (s *Submission) display(first bool, maxWidth *int) {
+	lines := 6
+	if s.ParseKind() != "" {
+		lines++
+	}
 	if !first {
-		ansi.CursorUp(6)
+		ansi.CursorUp(lines)
 	}
 	_, _ = ansi.Printf("      #: %v\n", s.ParseID())
 	_, _ = ansi.Printf("   when: %v\n", s.When)
 	_, _ = ansi.Printf("   prob: %v\n", s.Name)
 	_, _ = ansi.Printf("  alias: %v\n", s.ShortName)
+	if s.ParseKind() != "" {
+		_, _ = ansi.Printf("   kind: %v\n", s.ParseKind())
+	}
 	refreshLine(1, *maxWidth)
 	_, _ = ansi.Printf(updateLine(fmt.Sprintf(" status: %v\n", s.ParseStatus()), maxWidth))
 	_, _ = ansi.Printf(" points: %v\n", s.ParsePoints())
@@ -97,20 +123,29 @@ func Display(submissions []Submission, first bool, maxWidth *int, line bool) {
 	var buf bytes.Buffer
 	output := io.Writer(&buf)
 	table := tablewriter.NewWriter(output)
-	table.SetHeader([]string{"#", "when", "problem", "alias", "status", "points"})
+	kinds := hasKinds(submissions)
+	header := []string{"#", "when", "problem", "alias", "status", "points"}
+	if kinds {
+		header = append(header, "kind")
+	}
+	table.SetHeader(header)
 	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
 	table.SetAlignment(tablewriter.ALIGN_CENTER)
 	table.SetCenterSeparator("|")
 	table.SetAutoWrapText(false)
 	for _, sub := range submissions {
-		table.Append([]string{
+		record := []string{
 			sub.ParseID(),
 			sub.When,
 			sub.Name,
 			sub.ShortName,
 			sub.ParseStatus(),
 			sub.ParsePoints(),
-		})
+		}
+		if kinds {
+			record = append(record, sub.ParseKind())
+		}
+		table.Append(record)
 	}
 	table.Render()
 
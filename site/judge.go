@@ -0,0 +1,47 @@
+package site
+
+// Session is the part of a client that logs in and checks its session.
+type Session interface {
+	Login() error
+	Ping() error
+	CheckSession() error
+}
+
+// Judge implements the methods of Site that only pass the session of a client on.
+// The site of a client embeds it and adds the methods that use the Info of the judge.
+type Judge struct {
+	Session
+	// ID is the name of the judge, Name returns it.
+	ID string
+	// HostOf and UsernameOf read the host and the user of the client when they are asked for,
+	// as logging in or "st config" changes them.
+	HostOf     func() string
+	UsernameOf func() string
+	// ErrorNotLogged is the error the client returns when the session has expired.
+	ErrorNotLogged string
+	// Extensions are the accepted extensions, nil when every extension is accepted.
+	Extensions map[string]struct{}
+}
+
+func (j Judge) Name() string {
+	return j.ID
+}
+
+func (j Judge) Host() string {
+	return j.HostOf()
+}
+
+func (j Judge) Username() string {
+	return j.UsernameOf()
+}
+
+func (j Judge) NotLogged(err error) bool {
+	return err != nil && err.Error() == j.ErrorNotLogged
+}
+
+func (j Judge) AcceptedExtensions(info Info) map[string]struct{} {
+	if j.Extensions == nil {
+		return map[string]struct{}{}
+	}
+	return j.Extensions
+}
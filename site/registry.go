@@ -0,0 +1,42 @@
+package site
+
+import (
+	"fmt"
+	"sync"
+)
+
+var (
+	mu    sync.RWMutex
+	sites = map[string]Site{}
+	names []string
+)
+
+// Register makes a judge available by its name. Registering a name again replaces the judge.
+func Register(s Site) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := sites[s.Name()]; !ok {
+		names = append(names, s.Name())
+	}
+	sites[s.Name()] = s
+}
+
+// Get returns the judge registered under name.
+func Get(name string) (Site, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if s, ok := sites[name]; ok {
+		return s, nil
+	}
+	return nil, fmt.Errorf("unknown judge %v", name)
+}
+
+// All returns the registered judges in the order of registration.
+func All() (ret []Site) {
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, name := range names {
+		ret = append(ret, sites[name])
+	}
+	return
+}
@@ -0,0 +1,60 @@
+package site
+
+import (
+	"database/sql"
+
+	"github.com/Arapak/sio-tool/util"
+)
+
+const ErrorNotSupported = "this function is not supported by the judge"
+const ErrorWrongInfo = "the specifier doesn't belong to this judge"
+
+// Info specifies a problem, a contest or a submission on one judge.
+// Every judge uses its own Info, e.g. *codeforces_client.Info.
+type Info interface {
+	Hint() string
+}
+
+// SubmitOptions are the options of a submission. Kind and User are supported only by Sio.
+// LangID is the Codeforces ID of the template's language and Lang is its name.
+type SubmitOptions struct {
+	LangID string
+	Lang   string
+	Kind   string
+	User   string
+}
+
+// Problem is one row of a problem list. Columns follow the header returned by Statis
+// and State contains "accepted" or "rejected" when the judge shows it.
+type Problem struct {
+	Columns []string
+	State   string
+}
+
+// Site is a judge st can parse problems from and submit solutions to.
+type Site interface {
+	// Name is the name of the judge used in the configuration, e.g. "codeforces" or "sio-staszic".
+	Name() string
+	Host() string
+	// Username of the logged user, empty when nobody is logged.
+	Username() string
+	Login() error
+	Ping() error
+	// NotLogged reports whether err means that the session has expired and Login should be called.
+	NotLogged(err error) bool
+	// CheckSession asks the judge who is logged with the saved session, without logging in again.
+	// It returns an error for which NotLogged is true when the session has expired.
+	CheckSession() error
+	// AcceptedExtensions of the source files which can be submitted for info, empty when every extension is accepted.
+	AcceptedExtensions(info Info) map[string]struct{}
+	Parse(info Info, db *sql.DB) (paths []string, err error)
+	Submit(info Info, options SubmitOptions, sourcePath string, db *sql.DB) error
+	Watch(info Info, n int, line bool) error
+	Statis(info Info) (header []string, problems []Problem, perf util.Performance, err error)
+	OpenURL(info Info) (string, error)
+	StandingsURL(info Info) (string, error)
+	// SubmissionURL falls back to the last submission when info doesn't specify one.
+	SubmissionURL(info Info) (string, error)
+	// Race waits for the contest to start, fills in info and returns the pages to open.
+	Race(info Info) (urls []string, err error)
+}
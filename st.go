@@ -5,9 +5,14 @@ import (
 	"os"
 	"strings"
 
+	"github.com/Arapak/sio-tool/account"
+	"github.com/Arapak/sio-tool/atcoder_client"
 	"github.com/Arapak/sio-tool/cmd"
 	"github.com/Arapak/sio-tool/codeforces_client"
 	"github.com/Arapak/sio-tool/config"
+	"github.com/Arapak/sio-tool/credentials"
+	"github.com/Arapak/sio-tool/domjudge_client"
+	"github.com/Arapak/sio-tool/kattis_client"
 	"github.com/Arapak/sio-tool/sio_client"
 	"github.com/Arapak/sio-tool/szkopul_client"
 	"github.com/Arapak/sio-tool/util"
@@ -24,9 +29,12 @@ const buildTime = "$CI_BUILD_TIME"
 const configPath = "~/.st/config"
 const codeforcesSessionPath = "~/.st/codeforces_session"
 const szkopulSessionPath = "~/.st/szkopul_session"
-const sioStaszicSessionPath = "~/.st/sio_staszic_session"
-const sioMimuwSessionPath = "~/.st/sio_mimuw_session"
-const sioTalentSessionPath = "~/.st/sio_talent_session"
+const sioSessionPath = "~/.st/%v_session"
+const atcoderSessionPath = "~/.st/atcoder_session"
+const kattisSessionPath = "~/.st/kattis_session"
+const domjudgeSessionPath = "~/.st/domjudge_session"
+const vaultPath = "~/.st/vault"
+const accountsPath = "~/.st/accounts"
 
 func main() {
 	usage := `SIO Tool $%version%$ (st). https://github.com/Arapak/sio-tool
@@ -37,25 +45,41 @@ If you want to compete, the best command is "st race".
 
 Usage:
   st config
-  st submit [-f <file>] [<specifier>...]
-  st list [<specifier>...]
-  st parse [<specifier>...]
+  st submit [-f <file>] [--force] [--kind <kind>] [--as <as>] [--account <account>] [<specifier>...]
+  st list [--account <account>] [<specifier>...]
+  st parse [--account <account>] [<specifier>...]
   st gen [<alias>]
   st test [--oiejq] [--memory_limit <memory_limit>] [--time_limit <time_limit>] [<file>]
   st package_test [--oiejq] [--verbose] [--memory_limit <memory_limit>] [--time_limit <time_limit>] [<file>]
   st add_package <file>
-  st download_packages [<specifier>...]
-  st upload_package <file> [<specifier>...]
-  st watch [all] [<specifier>...]
-  st open [<specifier>...]
-  st stand [<specifier>...]
-  st sid [<specifier>...]
-  st race [<specifier>...]
-  st pull [ac] [<specifier>...]
+  st packages serve [--port <port>]
+  st packages fetch <url> [<specifier>...]
+  st download_packages [--round <round>] [--retries <retries>] [--account <account>] [<specifier>...]
+  st upload_package <file> [--account <account>] [<specifier>...]
+  st admin problems [--round <round>] [--account <account>] [<specifier>...]
+  st admin move --round <round> [--account <account>] [<specifier>...]
+  st admin rename --shortname <shortname> [--account <account>] [<specifier>...]
+  st admin submissions [--user <user>] [--status <status>] [--since <since>] [--until <until>] [--format <format>] [--output <output>] [--account <account>] [<specifier>...]
+  st admin sources [--user <user>] [--status <status>] [--since <since>] [--until <until>] [--output <output>] [--account <account>] [<specifier>...]
+  st admin rejudge [--user <user>] [--status <status>] [--since <since>] [--until <until>] [--account <account>] [<specifier>...]
+  st watch [all] [--account <account>] [<specifier>...]
+  st open [--account <account>] [<specifier>...]
+  st stand [--account <account>] [<specifier>...]
+  st ranking [--round <round>] [--format <format>] [--output <output>] [--account <account>] [<specifier>...]
+  st sid [--account <account>] [<specifier>...]
+  st report [--compare <compare>] [--account <account>] [<specifier>...]
+  st race [--account <account>] [<specifier>...]
+  st pull [ac] [--account <account>] [<specifier>...]
   st stress-test [--oiejq] [--memory_limit <memory_limit>] [--time_limit <time_limit>] <specifier> [-s <solve>] [-b <brute>] [-g <generator>]
   st db add [--source <source>] [-n <name>] [-p <path>] [-l <link>] [-c <contest>] [--shortname <shortname>] [--stage <stage>]
   st db find [--source <source>] [-n <name>] [-p <path>] [-l <link>] [-c <contest>] [--shortname <shortname>] [--stage <stage>]
   st db goto [--source <source>] [-n <name>] [-p <path>] [-l <link>] [-c <contest>] [--shortname <shortname>] [--stage <stage>]
+  st sync
+  st stats [--html <html>]
+  st watcher [--bell] [--desktop] [--hook <hook>]
+  st account list
+  st account (use | add | remove) <judge> <account>
+  st doctor
   st upgrade
 
 Options:
@@ -88,6 +112,7 @@ Options:
                        "https://codeforces.com/contest/180/problem/A",
                        "https://codeforces.com/group/Cw4JRyRGXR/contest/269760",
                        "https://szkopul.edu.pl/problemset/problem/kQ5ExYNkFhx3K2FvVuXAAbn4/site/?key=statement",
+                       "https://atcoder.jp/contests/abc350/tasks/abc350_a",
                        "1111A", "1111", "a", "Cw4JRyRGXR"
                        You can combine multiple specifiers to specify what you
                        want.
@@ -95,6 +120,29 @@ Options:
   ac                   The status of the submission is Accepted.
   -o, --oiejq          Use oiejq for running tests
   -v, --verbose        Print verdict of every test
+  --round <round>      Round of the problems to download or list, or the round to move a problem to
+  --retries <retries>  How many times to retry a failed download (default is 3)
+  --user <user>        Only submissions of the given user (login)
+  --status <status>    Only submissions whose status contains the given text, e.g. "OK"
+  --since <since>      Only submissions sent at or after the given time, e.g. "2023-03-01 10:00"
+  --until <until>      Only submissions sent at or before the given time, e.g. "2023-03-01"
+  --format <format>    Export format of "st admin submissions" and "st ranking": csv or json
+                       (default is a table)
+  --output <output>    File to export to, or the folder to download sources into
+  --compare <compare>  ID of another submission to compare the report with
+  --html <html>        Also save the report as an HTML page with charts
+  --force              Submit even if the code fails the tests run before submitting
+  --kind <kind>        Kind of the Sio submission, e.g. "IGNORED" (contest admins only,
+                       default is "NORMAL")
+  --as <as>            Login of the user to submit as on Sio (contest admins only)
+  --bell               Ring the terminal bell on every verdict (default if no other notification is chosen)
+  --desktop            Show a desktop notification (notify-send) on every verdict
+  --hook <hook>        Command to run on every verdict, with the submission in the ST_JUDGE,
+                       ST_SUBMISSION, ST_CONTEST, ST_PROBLEM, ST_STATUS and ST_POINTS variables
+  --account <account>  Account to use on the judge instead of the one chosen with "st account use"
+                       (also set for a folder and its subfolders by a ".st-account" file)
+  --port <port>        Port on which "st packages serve" listens (default is 8080)
+  <url>                Address of a teammate's packages server, e.g. "http://192.168.0.10:8080"
   -m <memory_limit>, --memory_limit <memory_limit>, <memory_limit>
              Set oiejq's memory limit in MiB (default is 1024 (1 GiB))
   -t <time_limit>, --time_limit <time_limit>, <time_limit>  
@@ -109,6 +157,10 @@ Examples:
   st submit -f a.cpp 100 a
   st submit contest 100 a
   st submit gym 100001 a
+  st submit --force    Submit without running the tests configured in "st config".
+  st submit --kind ignored --as jkowalski
+                       Submit to Sio as user "jkowalski", without the submission counting
+                       towards the results (contest admins only).
   st list              List all problems' stats of a contest.
   st list 1119
   st parse 100         Fetch all problems' samples from contest 100 into
@@ -130,6 +182,30 @@ Examples:
   st add_package ~/tests
                        Add package (set of tests) for a task you are currently in 
   st test_package      Test your solution on a package added before
+  st download_packages
+                       Download packages of all problems in the current contest (as an admin) into
+                       the current path. Partially downloaded packages are resumed and packages which
+                       didn't change since the last download are skipped.
+  st download_packages abc
+                       Download only the package of problem "abc".
+  st admin problems    List problem instances of the current contest (as an admin) with their round,
+                       short name and submissions limit.
+  st admin move --round "Runda 2" abc
+                       Move problem "abc" to the round "Runda 2".
+  st admin rename --shortname xyz abc
+                       Change the short name of problem "abc" to "xyz".
+  st admin rejudge abc Rejudge all submissions for problem "abc".
+  st admin submissions --status OK --since 2023-03-01 abc
+                       List accepted submissions for problem "abc" sent since March 1st.
+  st admin submissions --format csv --output results.csv
+                       Export all submissions of the current contest to a CSV file.
+  st admin sources --output sources
+                       Download sources of all submissions into "sources/<user>/<problem>".
+  st admin rejudge --status "Wrong answer" abc
+                       Rejudge only the submissions of problem "abc" with a wrong answer.
+  st packages serve    Share all your packages with teammates over HTTP (the index is at "/index.json")
+  st packages fetch http://192.168.0.10:8080
+                       Download the packages a teammate shares for the task you are currently in
   st watch             Watch the first 10 submissions for the current contest.
   st watch all         Watch all submissions for the current contest.
   st open 1136a        Use your default web browser to open the page for the contest.
@@ -137,9 +213,18 @@ Examples:
   st open gym 100136   Use the default web browser to open the page of gym.
                        100136.
   st stand             Use the default web browser to open the standing page.
+                       On DOMjudge, show the scoreboard of the contest.
+  st ranking           Print the ranking of the current Sio contest with your row highlighted and
+                       the rank changes since the last time you ran it.
+  st ranking --round "Runda 1" --format csv --output ranking.csv
+                       Export the ranking of the round "Runda 1" to a CSV file.
   st sid 52531875      Use the default web browser to open the submission.
                        52531875's page.
   st sid               Open the last submission's page.
+  st report            Print the per-test results (verdict, time, points) of the last submission on
+                       Sio or Szkopul. Reports are saved in "~/.st/reports".
+  st report 12345 --compare 12340
+                       Compare the results of submission 12345 with submission 12340 test by test.
   st race 1136         If the contest 1136 has not started yet, it will
                        countdown. When the countdown ends, it will open all
                        problems' pages and parse samples.
@@ -152,6 +237,21 @@ Examples:
   st pull              Pull the latest codes for the current problem into the current
                        path.
   st stress-test abc   Stresstest a program with your solve, brute force solution, and test generator.
+  st sync              Save your submission history from Codeforces, Szkopul and every Sio instance you
+                       are logged in to in the database (in a Sio contest folder, only that contest).
+  st stats --html report.html
+                       Print solved, partially solved and attempted tasks per source, contest and stage,
+                       your streaks and OI coverage, and save them as an HTML report.
+  st watcher --desktop Watch the pending submissions on every judge you are logged in to and
+                       notify about their verdicts and revealed scores.
+  st account add codeforces team
+                       Log in to another account on Codeforces, named "team".
+  st account use codeforces team
+                       Use the account "team" on Codeforces from now on.
+  st account list      List the accounts on every judge with their users, the used ones are marked.
+  st doctor            Check that every judge answers and that you are still logged in to it.
+  st submit --account team
+                       Submit with the account "team" this time.
   st db add            Add a new task to the database with problems you solved (problems parsed by sio-tool are automatically added).
   st db find -n "square"
 					   Find all problems in the database that contain the string "square" (ignoring capitalization).
@@ -164,13 +264,24 @@ Files:
   st will save some data in some files:
 
   "~/.st/config"        Configuration file, including templates, etc.
-  "~/.st/codeforces_session"    Codeforces session file, including cookies, handle, password, etc.
-  "~/.st/szkopul_session"       Szkopul session file, including username and password
-  "~/.st/sio_session"           Sio session file, including username and password
+  "~/.st/codeforces_session"    Codeforces session file, including cookies, handle, etc.
+  "~/.st/szkopul_session"       Szkopul session file, including cookies and username
+  "~/.st/atcoder_session"       AtCoder session file, including cookies and username
+  "~/.st/kattis_session"        Kattis session file, including cookies (the username and the token
+                                are read from "~/.kattisrc")
+  "~/.st/domjudge_session"      DOMjudge session file, including the username of your team
+  "~/.st/<name>_session"        Session file of every Sio instance (with "-" in the name replaced by "_",
+                                e.g. "~/.st/sio_staszic_session"), including cookies and username
+  "~/.st/accounts/<account>/"   Session files of the other accounts (see "st account"), a Kattis account
+                                reads "kattisrc" from this folder when there is one
+  "~/.st/vault"                 Passwords encrypted with your passphrase, used when the system
+                                has no keyring (or when chosen in "st config")
 
   "~" is the home directory of the current user on your system.
 
-  Don't share the session files with anyone, your password is encrypted, but if someone knows you are using this program, he can easily decrypt it.
+  The passwords are kept in the keyring of your system or in the vault, not in the session files.
+  The session files are readable only by you, don't share them with anyone, as the cookies let
+  anyone use your accounts.
 
 Template:
   You can insert some placeholders into your template code. When generating a code
@@ -211,15 +322,29 @@ Script in template:
 	cfgPath, _ := homedir.Expand(configPath)
 	codeforcesClnPath, _ := homedir.Expand(codeforcesSessionPath)
 	szkopulClnPath, _ := homedir.Expand(szkopulSessionPath)
-	sioStaszicClnPath, _ := homedir.Expand(sioStaszicSessionPath)
-	sioMimuwClnPath, _ := homedir.Expand(sioMimuwSessionPath)
-	sioTalentClnPath, _ := homedir.Expand(sioTalentSessionPath)
+	atcoderClnPath, _ := homedir.Expand(atcoderSessionPath)
+	kattisClnPath, _ := homedir.Expand(kattisSessionPath)
+	domjudgeClnPath, _ := homedir.Expand(domjudgeSessionPath)
 	config.Init(cfgPath)
-	codeforces_client.Init(codeforcesClnPath, config.Instance.CodeforcesHost, config.Instance.Proxy)
-	szkopul_client.Init(szkopulClnPath, config.Instance.SzkopulHost, config.Instance.Proxy)
-	sio_client.Init(sioStaszicClnPath, config.Instance.SioStaszicHost, config.Instance.Proxy, sio_client.Staszic)
-	sio_client.Init(sioMimuwClnPath, config.Instance.SioMimuwHost, config.Instance.Proxy, sio_client.Mimuw)
-	sio_client.Init(sioTalentClnPath, config.Instance.SioTalentHost, config.Instance.Proxy, sio_client.Talent)
+	account.Dir, _ = homedir.Expand(accountsPath)
+	if name, ok := opts["--account"].(string); ok {
+		account.Override = name
+	} else if wd, err := os.Getwd(); err == nil {
+		account.Override = account.FromFolder(wd)
+	}
+	cfg := config.Instance
+	vaultClnPath, _ := homedir.Expand(vaultPath)
+	credentials.Init(cfg.CredentialStore, vaultClnPath)
+	codeforces_client.Init(cfg.SessionPath("codeforces", codeforcesClnPath), cfg.CodeforcesHost, cfg.Proxy)
+	szkopul_client.Init(cfg.SessionPath("szkopul", szkopulClnPath), cfg.SzkopulHost, cfg.Proxy)
+	atcoder_client.Init(cfg.SessionPath("atcoder", atcoderClnPath), cfg.AtcoderHost, cfg.Proxy)
+	kattisClnPath = cfg.SessionPath("kattis", kattisClnPath)
+	kattis_client.Init(kattisClnPath, cfg.KattisRCPath(kattisClnPath), cfg.Proxy)
+	domjudge_client.Init(cfg.SessionPath("domjudge", domjudgeClnPath), cfg.DomjudgeHost, cfg.Proxy)
+	for _, instance := range cfg.SioInstances {
+		sioClnPath, _ := homedir.Expand(fmt.Sprintf(sioSessionPath, strings.ReplaceAll(instance.Name, "-", "_")))
+		sio_client.Init(cfg.SessionPath(instance.Name, sioClnPath), instance.Host, cfg.Proxy, instance.Name, instance.Flavour)
+	}
 
 	err := cmd.Eval(opts)
 	if err != nil {
@@ -0,0 +1,119 @@
+package stats
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const barWidth = 400
+
+type bar struct {
+	Label   string
+	Solved  int
+	Partial int
+	Total   int
+}
+
+func (b bar) Width(count int) int {
+	if b.Total == 0 {
+		return 0
+	}
+	return count * barWidth / b.Total
+}
+
+func bars(rows []Row) (ret []bar) {
+	for _, r := range rows {
+		label := r.Source
+		if r.Contest != "" {
+			label = fmt.Sprintf("%v %v %v", r.Source, r.Contest, r.Stage)
+		}
+		ret = append(ret, bar{Label: label, Solved: r.Solved, Partial: r.Partial, Total: r.Tasks})
+	}
+	return
+}
+
+func editionBars(editions []Edition) (ret []bar) {
+	for _, e := range editions {
+		ret = append(ret, bar{Label: e.Contest, Solved: e.Solved, Partial: e.Partial, Total: e.Problems})
+	}
+	return
+}
+
+// FormatDuration prints the duration rounded to minutes, with days when it is long enough.
+func FormatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if days := d / (24 * time.Hour); days > 0 {
+		return fmt.Sprintf("%vd %v", int(days), d-days*24*time.Hour)
+	}
+	return d.String()
+}
+
+var page = template.Must(template.New("stats").Funcs(template.FuncMap{
+	"duration": FormatDuration,
+	"add":      func(a, b int) int { return a + b },
+	"mul":      func(a, b int) int { return a * b },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>st stats</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+td, th { border: 1px solid #ccc; padding: 4px 8px; text-align: center; }
+text { font-size: 12px; }
+</style>
+</head>
+<body>
+<h1>Progress report</h1>
+<p>Generated {{.Report.Generated.Format "2006-01-02 15:04"}}.
+Current streak: {{.Report.CurrentStreak}} days, longest streak: {{.Report.LongestStreak}} days.
+{{if .Report.Solves}}Median time to full score: {{duration .Report.MedianSolveTime}}.{{end}}</p>
+{{define "chart"}}
+<svg width="600" height="{{mul (len .) 22}}">
+{{range $i, $b := .}}
+<text x="0" y="{{add (mul $i 22) 15}}">{{$b.Label}}</text>
+<rect x="150" y="{{mul $i 22}}" width="400" height="18" fill="#eee"/>
+<rect x="150" y="{{mul $i 22}}" width="{{$b.Width $b.Solved}}" height="18" fill="#4caf50"/>
+<rect x="{{add 150 ($b.Width $b.Solved)}}" y="{{mul $i 22}}" width="{{$b.Width $b.Partial}}" height="18" fill="#03a9f4"/>
+<text x="555" y="{{add (mul $i 22) 15}}">{{$b.Solved}}/{{$b.Total}}</text>
+{{end}}
+</svg>
+{{end}}
+<h2>Sources</h2>
+{{template "chart" .Sources}}
+<h2>Contests</h2>
+<table>
+<tr><th>source</th><th>contest</th><th>stage</th><th>tasks</th><th>solved</th><th>partial</th><th>attempted</th></tr>
+{{range .Report.Contests}}<tr><td>{{.Source}}</td><td>{{.Contest}}</td><td>{{.Stage}}</td><td>{{.Tasks}}</td><td>{{.Solved}}</td><td>{{.Partial}}</td><td>{{.Attempted}}</td></tr>
+{{end}}</table>
+{{if .OI}}<h2>OI coverage</h2>
+{{template "chart" .OI}}{{end}}
+{{if .Report.Solves}}<h2>Solved tasks</h2>
+<table>
+<tr><th>name</th><th>source</th><th>solved</th><th>time</th></tr>
+{{range .Report.Solves}}<tr><td>{{.Name}}</td><td>{{.Source}}</td><td>{{.Solved.Format "2006-01-02"}}</td><td>{{duration .Duration}}</td></tr>
+{{end}}</table>{{end}}
+</body>
+</html>
+`))
+
+// WriteHTML saves the report as a static HTML page with bar charts.
+func (r *Report) WriteHTML(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return page.Execute(file, struct {
+		Report  *Report
+		Sources []bar
+		OI      []bar
+	}{r, bars(r.Sources), editionBars(r.OI)})
+}
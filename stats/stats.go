@@ -0,0 +1,199 @@
+// Package stats aggregates the tasks and submissions from the local database into
+// a progress report, which can be printed in the terminal or saved as an HTML page.
+package stats
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/Arapak/sio-tool/database_client"
+	"github.com/Arapak/sio-tool/szkopul_client"
+)
+
+const fullScore = 100
+
+var timeLayouts = []string{database_client.TimeLayout, "2006-01-02 15:04", "2006-01-02"}
+
+func parseTime(value string) (time.Time, bool) {
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+type Row struct {
+	Source    string
+	Contest   string
+	Stage     string
+	Tasks     int
+	Solved    int
+	Partial   int
+	Attempted int
+}
+
+func (r *Row) add(t database_client.TaskSummary) {
+	r.Tasks++
+	if t.BestPoints >= fullScore {
+		r.Solved++
+	} else if t.BestPoints > 0 {
+		r.Partial++
+	} else if t.Submissions > 0 {
+		r.Attempted++
+	}
+}
+
+type Solve struct {
+	Name     string
+	Source   string
+	Started  time.Time
+	Solved   time.Time
+	Duration time.Duration
+}
+
+type Edition struct {
+	Contest   string
+	Problems  int
+	Solved    int
+	Partial   int
+	Points    int
+	MaxPoints int
+}
+
+type Report struct {
+	Generated     time.Time
+	Sources       []Row
+	Contests      []Row
+	CurrentStreak int
+	LongestStreak int
+	Solves        []Solve
+	OI            []Edition
+}
+
+func group(tasks []database_client.TaskSummary, key func(t database_client.TaskSummary) Row) (rows []Row) {
+	index := make(map[Row]int)
+	for _, t := range tasks {
+		k := key(t)
+		i, ok := index[k]
+		if !ok {
+			i = len(rows)
+			index[k] = i
+			rows = append(rows, k)
+		}
+		rows[i].add(t)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Source != rows[j].Source {
+			return rows[i].Source < rows[j].Source
+		} else if rows[i].Contest != rows[j].Contest {
+			return rows[i].Contest < rows[j].Contest
+		}
+		return rows[i].Stage < rows[j].Stage
+	})
+	return
+}
+
+// Streaks returns the number of consecutive days with a submission ending today (or yesterday)
+// and the longest such run. The days have to be sorted.
+func Streaks(days []string, now time.Time) (current, longest int) {
+	var previous time.Time
+	run := 0
+	for _, day := range days {
+		t, ok := parseTime(day)
+		if !ok {
+			continue
+		}
+		if run > 0 && t.Sub(previous) <= 36*time.Hour {
+			run++
+		} else {
+			run = 1
+		}
+		previous = t
+		if run > longest {
+			longest = run
+		}
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if run > 0 && today.Sub(previous) <= 36*time.Hour {
+		current = run
+	}
+	return
+}
+
+func solves(tasks []database_client.TaskSummary) (ret []Solve) {
+	for _, t := range tasks {
+		solved, ok := parseTime(t.FirstFullScore)
+		if !ok {
+			continue
+		}
+		started, ok := parseTime(t.CreatedAt)
+		if !ok {
+			if started, ok = parseTime(t.FirstSubmitted); !ok {
+				continue
+			}
+		}
+		if solved.Before(started) {
+			started = solved
+		}
+		ret = append(ret, Solve{Name: t.Name, Source: t.Source, Started: started, Solved: solved, Duration: solved.Sub(started)})
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Solved.After(ret[j].Solved) })
+	return
+}
+
+// MedianSolveTime returns the median time from the first parse (or submission) to the first full score.
+func (r *Report) MedianSolveTime() time.Duration {
+	if len(r.Solves) == 0 {
+		return 0
+	}
+	durations := make([]time.Duration, len(r.Solves))
+	for i, s := range r.Solves {
+		durations[i] = s.Duration
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	return durations[len(durations)/2]
+}
+
+// Coverage summarizes the scores of OI problems per edition from the Szkopul problem list.
+func Coverage(problems []szkopul_client.StatisInfo) (editions []Edition) {
+	index := make(map[string]int)
+	for _, p := range problems {
+		i, ok := index[p.Contest]
+		if !ok {
+			i = len(editions)
+			index[p.Contest] = i
+			editions = append(editions, Edition{Contest: p.Contest})
+		}
+		e := &editions[i]
+		e.Problems++
+		e.MaxPoints += fullScore
+		if points, err := strconv.Atoi(p.Points); err == nil {
+			e.Points += points
+			if points >= fullScore {
+				e.Solved++
+			} else if points > 0 {
+				e.Partial++
+			}
+		}
+	}
+	return
+}
+
+func NewReport(tasks []database_client.TaskSummary, days []string, oi []szkopul_client.StatisInfo) Report {
+	now := time.Now()
+	report := Report{
+		Generated: now,
+		Sources: group(tasks, func(t database_client.TaskSummary) Row {
+			return Row{Source: t.Source}
+		}),
+		Contests: group(tasks, func(t database_client.TaskSummary) Row {
+			return Row{Source: t.Source, Contest: t.ContestID, Stage: t.ContestStageID}
+		}),
+		Solves: solves(tasks),
+		OI:     Coverage(oi),
+	}
+	report.CurrentStreak, report.LongestStreak = Streaks(days, now)
+	return report
+}
@@ -0,0 +1,110 @@
+package stats
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Arapak/sio-tool/database_client"
+	_ "modernc.org/sqlite"
+)
+
+// fixture creates a database with the tasks and the points of their submissions.
+func fixture(t *testing.T, tasks map[database_client.Task][]int) *sql.DB {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "tasks.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	id := 0
+	for task, points := range tasks {
+		task.Name = task.ShortName
+		task.Path = filepath.Join(task.Source, task.ContestID, task.ContestStageID, task.ShortName)
+		if err = database_client.AddTask(db, task); err != nil {
+			t.Fatal(err)
+		}
+		taskID, err := database_client.FindTaskID(db, task)
+		if err != nil || taskID == 0 {
+			t.Fatalf("Expect to find %+v, but found %v (%v).", task, taskID, err)
+		}
+		for _, p := range points {
+			id++
+			submission := database_client.Submission{TaskID: taskID, Judge: task.Source, SubmissionID: fmt.Sprint(id), ShortName: task.ShortName, When: fmt.Sprintf("2024-04-%02d 12:00:00", id), Points: p}
+			if err = database_client.AddSubmission(db, submission); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return db
+}
+
+func TestReportRows(t *testing.T) {
+	db := fixture(t, map[database_client.Task][]int{
+		{Source: "cf", ContestID: "1500", ShortName: "A"}:                       {0, 100},
+		{Source: "cf", ContestID: "1500", ShortName: "B"}:                       {40},
+		{Source: "cf", ContestID: "1501", ShortName: "A"}:                       {0},
+		{Source: "OI", ContestID: "XX", ContestStageID: "1", ShortName: "dom"}:  nil,
+		{Source: "OI", ContestID: "XX", ContestStageID: "2", ShortName: "ply"}:  {100},
+		{Source: "OI", ContestID: "XXI", ContestStageID: "1", ShortName: "dom"}: {100, 0},
+	})
+	tasks, err := database_client.TaskSummaries(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	days, err := database_client.SubmissionDays(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(days) != 7 {
+		t.Errorf("Expect 7 days with submissions, but found %v.", days)
+	}
+	report := NewReport(tasks, days, nil)
+
+	tests := []struct {
+		name string
+		rows []Row
+		want []Row
+	}{
+		{"judges", report.Sources, []Row{
+			{Source: "OI", Tasks: 3, Solved: 2},
+			{Source: "cf", Tasks: 3, Solved: 1, Partial: 1, Attempted: 1},
+		}},
+		{"contests", report.Contests, []Row{
+			{Source: "OI", Contest: "XX", Stage: "1", Tasks: 1},
+			{Source: "OI", Contest: "XX", Stage: "2", Tasks: 1, Solved: 1},
+			{Source: "OI", Contest: "XXI", Stage: "1", Tasks: 1, Solved: 1},
+			{Source: "cf", Contest: "1500", Tasks: 2, Solved: 1, Partial: 1},
+			{Source: "cf", Contest: "1501", Tasks: 1, Attempted: 1},
+		}},
+	}
+	for _, test := range tests {
+		if !reflect.DeepEqual(test.rows, test.want) {
+			t.Errorf("%v: expect %+v, but found %+v.", test.name, test.want, test.rows)
+		}
+	}
+	if len(report.Solves) != 3 {
+		t.Errorf("Expect 3 solved tasks, but found %+v.", report.Solves)
+	}
+}
+
+func TestStreaks(t *testing.T) {
+	now := time.Date(2024, 4, 10, 18, 0, 0, 0, time.Local)
+	tests := []struct {
+		days             []string
+		current, longest int
+	}{
+		{nil, 0, 0},
+		{[]string{"2024-04-10"}, 1, 1},
+		{[]string{"2024-04-01", "2024-04-02", "2024-04-03", "2024-04-09", "2024-04-10"}, 2, 3},
+		{[]string{"2024-04-07", "2024-04-08", "2024-04-09"}, 3, 3},
+		{[]string{"2024-04-05", "2024-04-06", "2024-04-08"}, 0, 2},
+	}
+	for _, test := range tests {
+		if current, longest := Streaks(test.days, now); current != test.current || longest != test.longest {
+			t.Errorf("%v: expect %v %v, but found %v %v.", test.days, test.current, test.longest, current, longest)
+		}
+	}
+}
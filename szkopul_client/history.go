@@ -0,0 +1,112 @@
+package szkopul_client
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Arapak/sio-tool/database_client"
+	"github.com/Arapak/sio-tool/sio_submissions"
+	"github.com/Arapak/sio-tool/util"
+	"github.com/fatih/color"
+)
+
+const judgeName = "szkopul"
+
+func toDatabaseSubmission(contest string, s sio_submissions.Submission) database_client.Submission {
+	points := int(s.Points)
+	if s.Points == sio_submissions.Inf {
+		points = database_client.NoPoints
+	}
+	return database_client.Submission{
+		Judge:        judgeName,
+		SubmissionID: s.ParseID(),
+		ContestID:    contest,
+		ShortName:    s.ShortName,
+		When:         s.When,
+		Status:       s.PlainStatus(),
+		Points:       points,
+	}
+}
+
+// recordSubmission saves a submission made with st, together with the hash of its source, in the database.
+func (c *SzkopulClient) recordSubmission(db *sql.DB, info Info, s sio_submissions.Submission, sourcePath string) {
+	if db == nil {
+		return
+	}
+	submission := toDatabaseSubmission(info.ContestID, s)
+	if submission.ShortName == "" {
+		submission.ShortName = info.ProblemAlias
+	}
+	submission.FilePath = sourcePath
+	if source, err := os.ReadFile(sourcePath); err == nil {
+		submission.SourceHash = database_client.SourceHash(source)
+	}
+	submission.TaskID, _ = database_client.FindTaskID(db, info.ToTask())
+	if err := database_client.AddSubmission(db, submission); err != nil {
+		color.Red(err.Error())
+	}
+}
+
+// archiveTasks returns the tasks of the OI archive by their alias.
+func (c *SzkopulClient) archiveTasks() (map[string][]StatisInfo, error) {
+	URL, err := (&Info{Archive: "OI"}).ProblemSetURL(c.host)
+	if err != nil {
+		return nil, err
+	}
+	body, err := util.GetBody(c.client, URL)
+	if err != nil {
+		return nil, err
+	}
+	problems, err := findProblems(body)
+	if err != nil {
+		return nil, err
+	}
+	tasks := make(map[string][]StatisInfo)
+	for _, problem := range problems {
+		alias := strings.ToLower(problem.Alias)
+		tasks[alias] = append(tasks[alias], problem)
+	}
+	return tasks, nil
+}
+
+// archiveTask returns the only task of the archive with the alias and the name of the submission.
+// Szkopul lists submissions without their contest and stage, and aliases repeat between editions.
+func archiveTask(tasks map[string][]StatisInfo, s sio_submissions.Submission) (task StatisInfo, ok bool) {
+	found := 0
+	for _, t := range tasks[strings.ToLower(s.ShortName)] {
+		if s.Name == "" || strings.EqualFold(t.Name, s.Name) {
+			task = t
+			found++
+		}
+	}
+	return task, found == 1
+}
+
+// Sync saves the whole submission history of the current user in the database.
+func (c *SzkopulClient) Sync(db *sql.DB) (synced int, err error) {
+	URL := (&Info{}).MySubmissionURL(c.host)
+	submissions, err := sio_submissions.CollectPages(func(page int) ([]sio_submissions.Submission, error) {
+		return GetSubmissions(c.client, fmt.Sprintf("%v?page=%v", URL, page), -1)
+	})
+	if err != nil {
+		return
+	}
+	tasks, err := c.archiveTasks()
+	if err != nil {
+		return
+	}
+	for _, s := range submissions {
+		submission := toDatabaseSubmission("", s)
+		if task, ok := archiveTask(tasks, s); ok {
+			submission.ContestID = task.Contest
+			submission.TaskID, _ = database_client.FindTaskID(db, database_client.Task{Source: "OI", ContestID: task.Contest, ContestStageID: task.Stage, ShortName: s.ShortName})
+		}
+		if err = database_client.AddSubmission(db, submission); err != nil {
+			return
+		}
+		synced++
+	}
+	return
+}
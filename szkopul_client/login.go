@@ -4,11 +4,11 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/md5"
-	"crypto/rand"
 	"encoding/hex"
 	"errors"
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/Arapak/sio-tool/cookiejar"
+	"github.com/Arapak/sio-tool/credentials"
 	"github.com/Arapak/sio-tool/util"
 	"io"
 	"net/http"
@@ -47,6 +47,16 @@ func (c *SzkopulClient) GetCsrf(URL string) (csrf string, err error) {
 	return findCsrf(body)
 }
 
+// CheckSession checks that the saved session is still logged in.
+func (c *SzkopulClient) CheckSession() (err error) {
+	body, err := util.GetBody(c.client, c.host)
+	if err != nil {
+		return
+	}
+	_, err = findUsername(body)
+	return
+}
+
 func (c *SzkopulClient) Login() (err error) {
 	color.Cyan("Login...\n")
 
@@ -106,24 +116,7 @@ func createHash(key string) []byte {
 	return hasher.Sum(nil)
 }
 
-func encrypt(handle, password string) (ret string, err error) {
-	block, err := aes.NewCipher(createHash("glhf" + handle + "233"))
-	if err != nil {
-		return
-	}
-	gcm, err := cipher.NewGCM(block)
-	if err != nil {
-		return
-	}
-	nonce := make([]byte, gcm.NonceSize())
-	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
-		return
-	}
-	text := gcm.Seal(nonce, nonce, []byte(password), nil)
-	ret = hex.EncodeToString(text)
-	return
-}
-
+// decrypt reads a password saved by older versions of st, see credentials.Migrate.
 func decrypt(handle, password string) (ret string, err error) {
 	data, err := hex.DecodeString(password)
 	if err != nil {
@@ -149,10 +142,10 @@ func decrypt(handle, password string) (ret string, err error) {
 }
 
 func (c *SzkopulClient) DecryptPassword() (string, error) {
-	if len(c.Password) == 0 || len(c.Username) == 0 {
-		return "", errors.New("you have to configure your username and password by `st config`")
+	if len(c.Username) == 0 {
+		return "", errors.New(credentials.ErrorNotFound)
 	}
-	return decrypt(c.Username, c.Password)
+	return credentials.Get("szkopul", c.Username)
 }
 
 func (c *SzkopulClient) ConfigLogin() (err error) {
@@ -170,8 +163,7 @@ func (c *SzkopulClient) ConfigLogin() (err error) {
 	}
 
 	c.Username = username
-	c.Password, err = encrypt(username, password)
-	if err != nil {
+	if err = credentials.Set("szkopul", username, password); err != nil {
 		return
 	}
 	return c.Login()
@@ -2,6 +2,7 @@ package szkopul_client
 
 import (
 	"bytes"
+	"database/sql"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -11,12 +12,15 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/Arapak/sio-tool/account"
+	"github.com/Arapak/sio-tool/database_client"
+
 	"github.com/fatih/color"
 )
 
 const SubmitIDRegStr = `\d+`
 
-func (c *SzkopulClient) Submit(info Info, sourcePath string) (err error) {
+func (c *SzkopulClient) Submit(info Info, sourcePath string, db *sql.DB) (err error) {
 	color.Cyan("Submit " + info.Hint())
 
 	URL, err := info.APISubmitURL(c.host)
@@ -28,7 +32,12 @@ func (c *SzkopulClient) Submit(info Info, sourcePath string) (err error) {
 		return
 	}
 
-	fmt.Printf("Current user: %v\n", c.Username)
+	fmt.Printf("Current user: %v\n", account.Label(c.path, c.Username))
+
+	check := database_client.Submission{Judge: judgeName, ContestID: info.ContestID, ShortName: info.ProblemAlias}
+	if err = database_client.CheckSubmission(db, check, sourcePath, database_client.UnknownLimit); err != nil {
+		return
+	}
 
 	csrf, err := c.GetCsrf(refererURL)
 	if err != nil {
@@ -94,6 +103,12 @@ func (c *SzkopulClient) Submit(info Info, sourcePath string) (err error) {
 
 		info.SubmissionID = submissions[0].ParseID()
 		c.LastSubmission = &info
+		c.recordSubmission(db, info, submissions[0], sourcePath)
+		if submissions[0].CompilationError() {
+			if err = c.PrintCompilationLog(info, sourcePath); err != nil {
+				color.Red(err.Error())
+			}
+		}
 	} else {
 		fmt.Print("an error occurred: ")
 		color.Red(string(responseBody))
@@ -6,18 +6,21 @@ import (
 	"net/http"
 	"net/url"
 	"os"
-	"path/filepath"
 
 	"github.com/Arapak/sio-tool/cookiejar"
+	"github.com/Arapak/sio-tool/credentials"
+	"github.com/Arapak/sio-tool/site"
+	"github.com/Arapak/sio-tool/util"
 
 	"github.com/fatih/color"
 )
 
 type SzkopulClient struct {
-	Jar            *cookiejar.Jar `json:"cookies"`
-	Username       string         `json:"handle"`
-	Password       string         `json:"password"`
-	LastSubmission *Info          `json:"last_submission"`
+	Jar      *cookiejar.Jar `json:"cookies"`
+	Username string         `json:"handle"`
+	// Password is the password encrypted by older versions of st, it is moved to the credential store.
+	Password       string `json:"password,omitempty"`
+	LastSubmission *Info  `json:"last_submission"`
 	host           string
 	path           string
 	client         *http.Client
@@ -32,6 +35,7 @@ func Init(path, host, proxy string) {
 		color.Red(err.Error())
 		color.Green("Create a new session in %v", path)
 	}
+	credentials.Migrate("szkopul", c.Username, c.Password, decrypt, func() { c.Password = "" })
 	Proxy := http.ProxyFromEnvironment
 	if len(proxy) > 0 {
 		proxyURL, err := url.Parse(proxy)
@@ -42,11 +46,12 @@ func Init(path, host, proxy string) {
 			Proxy = http.ProxyURL(proxyURL)
 		}
 	}
-	c.client = &http.Client{Jar: c.Jar, Transport: &http.Transport{Proxy: Proxy}}
+	c.client = &http.Client{Jar: c.Jar, Transport: util.NewTransport(Proxy)}
 	if err := c.save(); err != nil {
 		color.Red(err.Error())
 	}
 	Instance = c
+	site.Register(c.Site())
 }
 
 func (c *SzkopulClient) load() (err error) {
@@ -82,10 +87,7 @@ func (c *SzkopulClient) load() (err error) {
 func (c *SzkopulClient) save() (err error) {
 	data, err := json.MarshalIndent(c, "", "  ")
 	if err == nil {
-		err = os.MkdirAll(filepath.Dir(c.path), os.ModePerm)
-		if err == nil {
-			err = os.WriteFile(c.path, data, 0644)
-		}
+		err = credentials.WriteFile(c.path, data)
 	}
 	if err != nil {
 		color.Red("Cannot save session to %v\n%v", c.path, err.Error())
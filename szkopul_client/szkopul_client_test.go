@@ -0,0 +1,156 @@
+package szkopul_client
+
+import (
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/Arapak/sio-tool/credentials"
+	"github.com/Arapak/sio-tool/replay/replaytest"
+	"github.com/Arapak/sio-tool/sio_submissions"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/mitchellh/go-homedir"
+)
+
+// host is the site the fixtures are recorded from, ST_RECORD_FIXTURES=1 records them again
+// with the session of "st config". The expectations are read from the recorded pages.
+const host = "https://szkopul.edu.pl"
+
+func init() {
+	replaytest.Session = func() (*http.Client, string, error) {
+		vault, _ := homedir.Expand("~/.st/vault")
+		credentials.Init(credentials.Auto, vault)
+		path, _ := homedir.Expand("~/.st/szkopul_session")
+		Init(path, host, "")
+		if err := Instance.CheckSession(); err != nil {
+			if err = Instance.Login(); err != nil {
+				return nil, "", err
+			}
+		}
+		return Instance.client, Instance.Username, nil
+	}
+}
+
+// problem matches "Name (alias)", the way the problems and the submissions are shown.
+var problem = regexp.MustCompile(`^(.*) \((\w+)\)$`)
+
+func TestFindStrings(t *testing.T) {
+	login := replaytest.AnonymousFixture(t, host+"/login/")
+	replaytest.TestStrings(t, []replaytest.StringTest{
+		{Name: "username", Page: replaytest.Fixture(t, host+"/submissions/"), Find: findUsername, Want: replaytest.User(t)},
+		{Name: "not logged", Page: login, Find: findUsername},
+		{Name: "csrf", Page: login, Find: findCsrf, Want: replaytest.Select(t, login, `input[name="csrfmiddlewaretoken"]`, "value")},
+	})
+	if _, err := findUsername(login); err == nil || err.Error() != ErrorNotLogged {
+		t.Errorf("Expect %v, but found %v.", ErrorNotLogged, err)
+	}
+}
+
+// archive returns the problems of the archive as its page lists them.
+func archive(t *testing.T, body []byte) (problems []StatisInfo) {
+	stage := regexp.MustCompile(`^problemgroups-\d+-(\d+)$`)
+	id := regexp.MustCompile(`/problem/(\w+)/`)
+	replaytest.Document(t, body).Find("#problemgroups table").Each(func(_ int, table *goquery.Selection) {
+		group := table.ParentsFiltered(".panel-collapse").First()
+		heading := group.Parent().Find(".panel-heading").First()
+		table.Find("tr").Each(func(_ int, tr *goquery.Selection) {
+			link := tr.Find("a").First()
+			names := problem.FindStringSubmatch(strings.TrimSpace(link.Text()))
+			found := id.FindStringSubmatch(link.AttrOr("href", ""))
+			if names == nil || found == nil {
+				t.Fatalf("Cannot read the problem %q.", link.Text())
+			}
+			problems = append(problems, StatisInfo{
+				ID:      found[1],
+				Name:    names[1],
+				Alias:   names[2],
+				Stage:   stage.FindStringSubmatch(group.AttrOr("id", ""))[1],
+				Contest: strings.Fields(heading.Text())[0],
+				Points:  strings.TrimSpace(tr.Find("td").Last().Text()),
+			})
+		})
+	})
+	return
+}
+
+func TestStatis(t *testing.T) {
+	c := &SzkopulClient{host: host, client: replaytest.FixtureClient(t)}
+	all := archive(t, replaytest.Fixture(t, host+"/task_archive/oi/"))
+	if len(all) == 0 {
+		t.Fatal("Expect the problems of the archive.")
+	}
+	first, last := all[0], all[len(all)-1]
+	tests := []struct {
+		info  Info
+		match func(StatisInfo) bool
+	}{
+		{Info{Archive: "oi", StageID: first.Stage}, func(p StatisInfo) bool { return p.Stage == first.Stage }},
+		{Info{Archive: "oi", ContestID: last.Contest, ProblemAlias: last.Alias}, func(p StatisInfo) bool {
+			return p.Contest == last.Contest && p.Alias == last.Alias
+		}},
+	}
+	for _, test := range tests {
+		var want []StatisInfo
+		for _, p := range all {
+			if test.match(p) {
+				want = append(want, p)
+			}
+		}
+		problems, _, err := c.Statis(test.info)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(problems) != len(want) {
+			t.Fatalf("%+v: expect %v, but found %v.", test.info, want, problems)
+		}
+		for i := range want {
+			if problems[i] != want[i] {
+				t.Errorf("%+v: expect %+v, but found %+v.", test.info, want[i], problems[i])
+			}
+		}
+	}
+}
+
+func TestGetSubmissions(t *testing.T) {
+	URL := host + "/submissions/"
+	submissions, err := GetSubmissions(replaytest.FixtureClient(t), URL, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := replaytest.Document(t, replaytest.Fixture(t, URL)).Find(`tr[id^="report"]`)
+	if len(submissions) == 0 || len(submissions) != rows.Length() {
+		t.Fatalf("Expect %v submissions, but found %v.", rows.Length(), len(submissions))
+	}
+	for i, s := range submissions {
+		row := rows.Eq(i)
+		link := row.Find(`a[href*="/s/"]`).First()
+		names := problem.FindStringSubmatch(strings.TrimSpace(row.Find(`td[id$="-problem-instance"]`).Text()))
+		status := strings.ToLower(strings.TrimSpace(row.Find(`td[id$="-status"]`).Text()))
+		switch {
+		case names == nil:
+			t.Errorf("Cannot find the problem of submission %v.", s.Id)
+		case s.Id != sio_submissions.ToInt(path.Base(link.AttrOr("href", ""))),
+			s.When != strings.TrimSpace(link.Text()),
+			s.Name != names[1], s.ShortName != names[2],
+			s.Points != sio_submissions.ToInt(strings.TrimSpace(row.Find(`td[id$="-score"]`).Text())),
+			s.Kind != strings.TrimSpace(row.Find(`td[id$="-kind"]`).Text()),
+			!strings.HasSuffix(s.Status, status),
+			s.End == (status == "oczekuje"):
+			t.Errorf("Submission %v doesn't match its row: %+v.", i+1, s)
+		}
+	}
+}
+
+func TestCheckSession(t *testing.T) {
+	c := &SzkopulClient{host: host, client: replaytest.FixtureClient(t)}
+	if err := c.CheckSession(); err != nil {
+		t.Errorf("Expect a valid session, but found %v.", err)
+	}
+	c.client = replaytest.AnonymousClient()
+	if err := c.CheckSession(); !c.Site().NotLogged(err) {
+		t.Errorf("Expect an expired session, but found %v.", err)
+	}
+}
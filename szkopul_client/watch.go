@@ -6,12 +6,12 @@ import (
 	"net/http"
 	"regexp"
 	"strings"
-	"time"
 
 	"github.com/Arapak/sio-tool/sio_submissions"
 	"github.com/Arapak/sio-tool/util"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/fatih/color"
 )
 
 func getSubmissionID(body []byte) (string, error) {
@@ -27,7 +27,7 @@ func findSubmission(body []byte, n int) ([][]byte, error) {
 	reg := regexp.MustCompile(`<tr id="report\d+row">[\s\S]+?</tr>`)
 	tmp := reg.FindAll(body, n)
 	if tmp == nil {
-		return nil, errors.New("cannot find any submission")
+		return nil, errors.New(sio_submissions.ErrorNoSubmissions)
 	}
 	return tmp, nil
 }
@@ -57,6 +57,7 @@ func parseSubmission(body []byte) (ret sio_submissions.Submission, err error) {
 	combinedName := get(fmt.Sprintf("td#submission%v-problem-instance", id))
 	name, shortName := getProblemNames(combinedName)
 	points := sio_submissions.ToInt(get(fmt.Sprintf("td#submission%v-score", id)))
+	kind := get(fmt.Sprintf("td#submission%v-kind", id))
 	status := strings.ToLower(get(fmt.Sprintf("td#submission%v-status", id)))
 	end := true
 	if status == "oczekuje" || status == "pending" {
@@ -82,6 +83,7 @@ func parseSubmission(body []byte) (ret sio_submissions.Submission, err error) {
 		Status:    status,
 		Points:    points,
 		When:      when,
+		Kind:      kind,
 		End:       end,
 	}, nil
 }
@@ -123,12 +125,17 @@ func GetSubmissions(client *http.Client, URL string, n int) (submissions []sio_s
 	}
 
 	if len(submissions) < 1 {
-		return nil, errors.New("cannot find any submission")
+		return nil, errors.New(sio_submissions.ErrorNoSubmissions)
 	}
 
 	return
 }
 
+// RecentSubmissions returns the latest submissions of the current user.
+func (c *SzkopulClient) RecentSubmissions() ([]sio_submissions.Submission, error) {
+	return GetSubmissions(c.client, (&Info{}).MySubmissionURL(c.host), -1)
+}
+
 func (c *SzkopulClient) WatchSubmission(info Info, n int, line bool) (submissions []sio_submissions.Submission, err error) {
 	URL := info.MySubmissionURL(c.host)
 	if err != nil {
@@ -137,13 +144,24 @@ func (c *SzkopulClient) WatchSubmission(info Info, n int, line bool) (submission
 
 	maxWidth := 0
 	first := true
+	poller := util.NewPoller()
+	previous := ""
+	var pending map[uint64]bool
 	for {
-		st := time.Now()
 		submissions, err = GetSubmissions(c.client, URL, n)
 		if err != nil {
+			if poller.Backoff(err) {
+				if err = poller.Wait(); err != nil {
+					return
+				}
+				continue
+			}
 			return
 		}
 		sio_submissions.Display(submissions, first, &maxWidth, line)
+		if first {
+			pending = sio_submissions.Pending(submissions)
+		}
 		first = false
 		endCount := 0
 		for _, submission := range submissions {
@@ -152,11 +170,31 @@ func (c *SzkopulClient) WatchSubmission(info Info, n int, line bool) (submission
 			}
 		}
 		if endCount == len(submissions) {
+			if !line {
+				c.printCompilationLogs(info, submissions, pending)
+			}
+			return
+		}
+		if state := sio_submissions.Signature(submissions); state != previous {
+			previous = state
+			poller.Reset()
+		}
+		if err = poller.Wait(); err != nil {
 			return
 		}
-		sub := time.Since(st)
-		if sub < time.Second {
-			time.Sleep(time.Second - sub)
+	}
+}
+
+// printCompilationLogs prints compiler output of the submissions which failed to compile while being watched.
+func (c *SzkopulClient) printCompilationLogs(info Info, submissions []sio_submissions.Submission, pending map[uint64]bool) {
+	for _, submission := range submissions {
+		if !pending[submission.Id] || !submission.CompilationError() {
+			continue
+		}
+		info.SubmissionID = submission.ParseID()
+		color.Cyan("Submission #%v", info.SubmissionID)
+		if err := c.PrintCompilationLog(info, ""); err != nil {
+			color.Red(err.Error())
 		}
 	}
 }
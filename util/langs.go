@@ -0,0 +1,91 @@
+package util
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Language is an option of the language select of a submit form.
+type Language struct {
+	Value string
+	Name  string
+}
+
+var extensionLanguages = map[string]string{
+	"cpp":  "C++",
+	"cc":   "C++",
+	"cxx":  "C++",
+	"c":    "C",
+	"pas":  "Pascal",
+	"py":   "Python",
+	"java": "Java",
+	"rs":   "Rust",
+}
+
+var langFamilies = []struct {
+	keyword string
+	family  string
+}{
+	{"++", "C++"},
+	{"GCC C", "C"},
+	{"Pascal", "Pascal"},
+	{"Delphi", "Pascal"},
+	{"Python", "Python"},
+	{"PyPy", "Python"},
+	{"Java ", "Java"},
+	{"Rust", "Rust"},
+}
+
+// LanguageFamily returns the name of the language for the name of a template's language
+// (e.g. "GNU G++17 7.3.0"), or for the extension of the source file if the name is unknown.
+func LanguageFamily(lang, sourcePath string) string {
+	for _, family := range langFamilies {
+		if strings.Contains(lang, family.keyword) {
+			return family.family
+		}
+	}
+	return extensionLanguages[strings.TrimPrefix(filepath.Ext(sourcePath), ".")]
+}
+
+// LanguageExtensions returns the extensions of the source files of the languages of a submit form.
+func LanguageExtensions(languages []Language) map[string]struct{} {
+	extensions := map[string]struct{}{}
+	for extension, family := range extensionLanguages {
+		for _, language := range languages {
+			if matchesFamily(language, family) {
+				extensions[extension] = struct{}{}
+				break
+			}
+		}
+	}
+	return extensions
+}
+
+// matchesFamily tells if the value or the name of the option is the family as a word, which can be
+// preceded by a compiler and followed by a version (e.g. "GNU C++17", "Python3" or "C++ 20 (gcc 12.2)").
+func matchesFamily(language Language, family string) bool {
+	re := regexp.MustCompile(`(?i)(^|\s)` + regexp.QuoteMeta(family) + `(\d|\s|$)`)
+	return re.MatchString(language.Value) || re.MatchString(language.Name)
+}
+
+// ChooseLanguage returns the value of the language option of a submit form matching the template's language.
+// It returns an empty value when the form has no language select, so that the server guesses
+// the language from the extension.
+func ChooseLanguage(languages []Language, lang, sourcePath string) (string, error) {
+	if len(languages) == 0 {
+		return "", nil
+	}
+	family := LanguageFamily(lang, sourcePath)
+	for _, language := range languages {
+		if matchesFamily(language, family) {
+			return language.Value, nil
+		}
+	}
+	var names []string
+	for _, language := range languages {
+		names = append(names, language.Name)
+	}
+	return "", fmt.Errorf("cannot find language %q, the available languages are: %v", family, strings.Join(names, ", "))
+}
@@ -0,0 +1,59 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLanguageExtensions(t *testing.T) {
+	tests := []struct {
+		languages  []Language
+		extensions []string
+	}{
+		{[]Language{{"C++", "C++"}, {"Python", "Python"}}, []string{"cc", "cpp", "cxx", "py"}},
+		{[]Language{{"c", "C"}, {"pascal", "Pascal"}, {"java", "Java 17"}}, []string{"c", "java", "pas"}},
+		{[]Language{{"54", "GNU C++17"}, {"31", "Python3"}}, []string{"cc", "cpp", "cxx", "py"}},
+		{[]Language{{"5001", "C++17 (g++ 11)"}, {"43", "GNU C11"}, {"4", "Free Pascal 3.2"}}, []string{"c", "cc", "cpp", "cxx", "pas"}},
+		{[]Language{{"js", "JavaScript"}, {"cs", "C# 10"}, {"objc", "Objective-C"}}, nil},
+		{[]Language{{"cobol", "Cobol"}}, nil},
+		{nil, nil},
+	}
+	for _, test := range tests {
+		want := map[string]struct{}{}
+		for _, extension := range test.extensions {
+			want[extension] = struct{}{}
+		}
+		if got := LanguageExtensions(test.languages); !reflect.DeepEqual(got, want) {
+			t.Errorf("%v: expect %v, but found %v.", test.languages, want, got)
+		}
+	}
+}
+
+func TestChooseLanguage(t *testing.T) {
+	languages := []Language{{"C", "C"}, {"C++", "C++"}, {"Python", "Python"}}
+	tests := []struct {
+		lang, path, value string
+		ok                bool
+	}{
+		{"GNU G++17 7.3.0", "a.cpp", "C++", true},
+		{"GNU GCC C11 5.1.0", "a.c", "C", true},
+		{"PyPy 3.6.9 (7.3.0)", "a.py", "Python", true},
+		{"", "a.cc", "C++", true},
+		{"Rust 1.64.0 (2021)", "a.rs", "", false},
+	}
+	for _, test := range tests {
+		value, err := ChooseLanguage(languages, test.lang, test.path)
+		if value != test.value || (err == nil) != test.ok {
+			t.Errorf("%v %v: expect %q, but found %q (%v).", test.lang, test.path, test.value, value, err)
+		}
+	}
+	codeforces := []Language{{"43", "GNU C11"}, {"54", "GNU C++17"}, {"31", "Python3"}, {"5001", "C++17 (g++ 11)"}}
+	for lang, want := range map[string]string{"GNU GCC C11 5.1.0": "43", "GNU G++17 7.3.0": "54", "Python 3.8.10": "31"} {
+		if value, err := ChooseLanguage(codeforces, lang, ""); value != want || err != nil {
+			t.Errorf("%v: expect %q, but found %q (%v).", lang, want, value, err)
+		}
+	}
+	if value, err := ChooseLanguage(nil, "Rust 1.64.0 (2021)", "a.rs"); value != "" || err != nil {
+		t.Errorf("Expect no language for a form without a language select, but found %q (%v).", value, err)
+	}
+}
@@ -0,0 +1,131 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/k0kubun/go-ansi"
+)
+
+const ErrorPollTimeout = "timed out waiting for the judge, check the submission later with \"st watch\""
+
+// PollOptions configure how often st asks the judges about pending submissions.
+type PollOptions struct {
+	// Interval is the time between the first requests.
+	Interval time.Duration
+	// MaxInterval limits the interval, which grows by Multiplier after every request without any change.
+	MaxInterval time.Duration
+	Multiplier  float64
+	// Jitter is the fraction of the interval by which every wait is randomly shortened or lengthened.
+	Jitter float64
+	// Timeout is the time after which polling stops, or 0 to poll until there are no pending submissions.
+	Timeout time.Duration
+}
+
+// Poll are the options used by all pollers, set from the configuration.
+var Poll = PollOptions{Interval: time.Second, MaxInterval: 30 * time.Second, Multiplier: 1.5, Jitter: 0.2}
+
+// RateLimitError is returned for responses with status 429 (or 503 with Retry-After),
+// which ask to slow down.
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("too many requests, the server asked to wait %v", e.RetryAfter)
+}
+
+// rateLimitError returns a RateLimitError if the response asks to slow down.
+func rateLimitError(resp *http.Response) error {
+	retryAfter := resp.Header.Get("Retry-After")
+	if resp.StatusCode != http.StatusTooManyRequests && (resp.StatusCode != http.StatusServiceUnavailable || retryAfter == "") {
+		return nil
+	}
+	err := &RateLimitError{}
+	if seconds, e := strconv.Atoi(retryAfter); e == nil {
+		err.RetryAfter = time.Duration(seconds) * time.Second
+	} else if date, e := http.ParseTime(retryAfter); e == nil {
+		err.RetryAfter = time.Until(date)
+	}
+	return err
+}
+
+// Poller spaces out repeated requests with exponential backoff.
+type Poller struct {
+	Options  PollOptions
+	interval time.Duration
+	minWait  time.Duration
+	start    time.Time
+	last     time.Time
+}
+
+func NewPoller() *Poller {
+	now := time.Now()
+	return &Poller{Options: Poll, interval: Poll.Interval, start: now, last: now}
+}
+
+// Reset goes back to the initial interval, e.g. when something has changed since the last request.
+func (p *Poller) Reset() {
+	p.interval = p.Options.Interval
+}
+
+// Wait sleeps until the next request should be made (counting from the end of the previous wait)
+// and increases the interval. It fails when the timeout has passed.
+func (p *Poller) Wait() error {
+	wait := p.interval
+	if p.Options.Jitter > 0 {
+		wait += time.Duration((rand.Float64()*2 - 1) * p.Options.Jitter * float64(wait))
+	}
+	if wait < p.minWait {
+		wait = p.minWait
+	}
+	p.minWait = 0
+	if p.Options.Timeout > 0 && time.Since(p.start)+wait > p.Options.Timeout {
+		return errors.New(ErrorPollTimeout)
+	}
+	if sub := time.Since(p.last); sub < wait {
+		time.Sleep(wait - sub)
+	}
+	p.last = time.Now()
+	p.interval = time.Duration(float64(p.interval) * p.Options.Multiplier)
+	if p.Options.MaxInterval > 0 && p.interval > p.Options.MaxInterval {
+		p.interval = p.Options.MaxInterval
+	}
+	return nil
+}
+
+// Backoff handles an error of a request. For a RateLimitError it makes the next wait at least as long
+// as the server asked for and returns true, so that the request can be retried after Wait.
+func (p *Poller) Backoff(err error) bool {
+	var rateLimit *RateLimitError
+	if !errors.As(err, &rateLimit) {
+		return false
+	}
+	p.interval = p.Options.MaxInterval
+	p.minWait = rateLimit.RetryAfter
+	return true
+}
+
+// clock formats a number of seconds as hh:mm:ss.
+func clock(seconds int64) string {
+	h := seconds / 60 / 60
+	m := seconds/60 - h*60
+	s := seconds - h*60*60 - m*60
+	return fmt.Sprintf("%02d:%02d:%02d", h, m, s)
+}
+
+// Countdown prints the time left until the given number of seconds has passed.
+func Countdown(seconds int64) {
+	end := time.Now().Add(time.Duration(seconds) * time.Second)
+	for left := seconds; left > 0; left-- {
+		fmt.Println(clock(left))
+		ansi.CursorUp(1)
+		// Sleep until the next full second before the end, so that the countdown doesn't drift.
+		time.Sleep(time.Until(end.Add(-time.Duration(left-1) * time.Second)))
+	}
+	time.Sleep(900 * time.Millisecond)
+}
@@ -0,0 +1,104 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestPollerInterval(t *testing.T) {
+	p := &Poller{Options: PollOptions{Interval: time.Millisecond, MaxInterval: 4 * time.Millisecond, Multiplier: 2}}
+	p.Reset()
+	for i, want := range []time.Duration{2, 4, 4, 4} {
+		if err := p.Wait(); err != nil {
+			t.Fatal(err)
+		}
+		if p.interval != want*time.Millisecond {
+			t.Errorf("Wait %v: expect the interval %v, but found %v.", i+1, want*time.Millisecond, p.interval)
+		}
+	}
+	p.Reset()
+	if p.interval != time.Millisecond {
+		t.Errorf("Expect the interval %v after Reset, but found %v.", time.Millisecond, p.interval)
+	}
+}
+
+func TestPollerTimeout(t *testing.T) {
+	now := time.Now()
+	p := &Poller{Options: PollOptions{Interval: time.Millisecond, Multiplier: 1, Timeout: time.Second}, start: now.Add(-time.Second), last: now}
+	p.Reset()
+	if err := p.Wait(); err == nil || err.Error() != ErrorPollTimeout {
+		t.Errorf("Expect %v, but found %v.", ErrorPollTimeout, err)
+	}
+}
+
+func TestPollerBackoff(t *testing.T) {
+	p := &Poller{Options: PollOptions{Interval: time.Millisecond, MaxInterval: 5 * time.Millisecond, Multiplier: 2}, last: time.Now()}
+	p.Reset()
+	if p.Backoff(errors.New("404 Not Found")) {
+		t.Errorf("Expect no backoff for an error which isn't a RateLimitError.")
+	}
+	if p.interval != time.Millisecond {
+		t.Errorf("Expect the interval %v, but found %v.", time.Millisecond, p.interval)
+	}
+
+	err := fmt.Errorf("submissions: %w", &RateLimitError{RetryAfter: 30 * time.Millisecond})
+	if !p.Backoff(err) {
+		t.Fatalf("Expect a backoff for %v.", err)
+	}
+	if p.interval != p.Options.MaxInterval {
+		t.Errorf("Expect the interval %v, but found %v.", p.Options.MaxInterval, p.interval)
+	}
+	start := time.Now()
+	if err = p.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if waited := time.Since(start); waited < 25*time.Millisecond {
+		t.Errorf("Expect to wait as long as the server asked, but waited %v.", waited)
+	}
+	if p.minWait != 0 {
+		t.Errorf("Expect the next wait to use the interval again, but found %v.", p.minWait)
+	}
+}
+
+func TestRateLimitError(t *testing.T) {
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	tests := []struct {
+		status     int
+		retryAfter string
+		limited    bool
+		min, max   time.Duration
+	}{
+		{http.StatusTooManyRequests, "3", true, 3 * time.Second, 3 * time.Second},
+		{http.StatusTooManyRequests, "", true, 0, 0},
+		{http.StatusServiceUnavailable, "120", true, 2 * time.Minute, 2 * time.Minute},
+		{http.StatusServiceUnavailable, date, true, 58 * time.Second, time.Minute},
+		{http.StatusServiceUnavailable, "", false, 0, 0},
+		{http.StatusOK, "3", false, 0, 0},
+	}
+	for _, test := range tests {
+		resp := &http.Response{StatusCode: test.status, Header: http.Header{}}
+		if test.retryAfter != "" {
+			resp.Header.Set("Retry-After", test.retryAfter)
+		}
+		err := rateLimitError(resp)
+		var rateLimit *RateLimitError
+		if errors.As(err, &rateLimit) != test.limited {
+			t.Errorf("%v %q: expect a rate limit %v, but found %v.", test.status, test.retryAfter, test.limited, err)
+			continue
+		}
+		if test.limited && (rateLimit.RetryAfter < test.min || rateLimit.RetryAfter > test.max) {
+			t.Errorf("%v %q: expect to wait %v, but found %v.", test.status, test.retryAfter, test.min, rateLimit.RetryAfter)
+		}
+	}
+}
+
+func TestClock(t *testing.T) {
+	for seconds, want := range map[int64]string{0: "00:00:00", 59: "00:00:59", 61: "00:01:01", 3600: "01:00:00", 90061: "25:01:01"} {
+		if got := clock(seconds); got != want {
+			t.Errorf("%v: expect %v, but found %v.", seconds, want, got)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Arapak/sio-tool/replay"
+)
+
+// ErrorChallenge and ErrorMaintenance are returned (wrapped by the client) for the pages which
+// are not worth retrying at once, test them with errors.Is.
+var ErrorChallenge = errors.New("the site answered with a browser check (e.g. Cloudflare) instead of the page, open it in a browser or try again later")
+var ErrorMaintenance = errors.New("the site is down for maintenance, try again later")
+
+// HTTPOptions configure the requests of all clients.
+type HTTPOptions struct {
+	// Timeout limits every attempt of a request until the headers of the response arrive,
+	// or 0 for no limit. Reading the body isn't limited, so slow downloads can finish.
+	Timeout time.Duration
+	// Retries is the number of times a GET request is repeated after a network error or a 5xx response.
+	Retries int
+	// UserAgent is sent with every request that doesn't set its own.
+	UserAgent string
+	// DebugLog is the file the requests and the responses are appended to, or empty for no logging.
+	DebugLog string
+}
+
+const DefaultUserAgent = "sio-tool (+https://github.com/Arapak/sio-tool)"
+
+// HTTP are the options used by all transports, set from the configuration.
+var HTTP = HTTPOptions{Timeout: 30 * time.Second, Retries: 2, UserAgent: DefaultUserAgent}
+
+// retryWait is the wait before the first retry, it doubles with every next one.
+var retryWait = 500 * time.Millisecond
+
+// challengeMarkers and maintenanceMarkers are looked for in the 403 and 5xx pages.
+var challengeMarkers = []string{"challenge-platform", "cf-browser-verification", "<title>Just a moment...</title>", "Checking your browser"}
+var maintenanceMarkers = []string{"maintenance", "przerwa techniczna", "temporarily unavailable"}
+
+// Transport sends the requests of a client with Base, retrying them and checking
+// that the responses are not challenge or maintenance pages.
+type Transport struct {
+	Base http.RoundTripper
+}
+
+// NewTransport returns the transport every client uses, with the given proxy.
+// It records or replays the responses when ST_RECORD or ST_REPLAY is set.
+func NewTransport(proxy func(*http.Request) (*url.URL, error)) http.RoundTripper {
+	return &Transport{Base: replay.Wrap(&http.Transport{Proxy: proxy})}
+}
+
+// idempotent requests are safe to send again, a submission must not be sent twice.
+func idempotent(req *http.Request) bool {
+	return req.Method == "GET" || req.Method == "HEAD" || req.Method == "OPTIONS"
+}
+
+// retryStatus tells if a response is worth retrying. A 503 with Retry-After asks
+// to slow down and is left to the caller (see RateLimitError).
+func retryStatus(resp *http.Response) bool {
+	return resp.StatusCode >= 500 && resp.Header.Get("Retry-After") == ""
+}
+
+type noRetriesKey struct{}
+
+// WithoutRetries marks the request to be sent only once by Transport,
+// for the callers which retry it themselves.
+func WithoutRetries(req *http.Request) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), noRetriesKey{}, true))
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if HTTP.UserAgent != "" && req.Header.Get("User-Agent") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("User-Agent", HTTP.UserAgent)
+	}
+	retries := 0
+	if idempotent(req) && req.Context().Value(noRetriesKey{}) == nil {
+		retries = HTTP.Retries
+	}
+	wait := retryWait
+	for attempt := 0; ; attempt++ {
+		start := time.Now()
+		resp, err := t.send(req)
+		logExchange(req, resp, err, attempt, time.Since(start))
+		if attempt == retries || req.Context().Err() != nil || (err == nil && !retryStatus(resp)) {
+			if err != nil {
+				return nil, err
+			}
+			return resp, checkPage(resp)
+		}
+		if err == nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		time.Sleep(wait)
+		wait *= 2
+	}
+}
+
+// send makes one attempt of the request. HTTP.Timeout limits connecting, sending the request
+// and waiting for the headers of the response, the timer is stopped once they arrive.
+func (t *Transport) send(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if HTTP.Timeout <= 0 {
+		return base.RoundTrip(req)
+	}
+	ctx, cancel := context.WithCancel(req.Context())
+	timer := time.AfterFunc(HTTP.Timeout, cancel)
+	resp, err := base.RoundTrip(req.WithContext(ctx))
+	if !timer.Stop() && err != nil {
+		err = fmt.Errorf("%v %v: no response in %v", req.Method, req.URL, HTTP.Timeout)
+	}
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelBody releases the context of a request when its response is closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// checkPage returns an error for challenge and maintenance pages, which are
+// answered with 403 or 5xx. The body of the other responses is left as it was.
+func checkPage(resp *http.Response) error {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode < 500 {
+		return nil
+	}
+	challenge := resp.Header.Get("Cf-Mitigated") == "challenge"
+	head, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err != nil {
+		return err
+	}
+	resp.Body = readCloser{io.MultiReader(bytes.NewReader(head), resp.Body), resp.Body}
+	page := string(head)
+	for _, marker := range challengeMarkers {
+		challenge = challenge || strings.Contains(page, marker)
+	}
+	if challenge {
+		resp.Body.Close()
+		return ErrorChallenge
+	}
+	if resp.StatusCode >= 500 {
+		page = strings.ToLower(page)
+		for _, marker := range maintenanceMarkers {
+			if strings.Contains(page, marker) {
+				resp.Body.Close()
+				return ErrorMaintenance
+			}
+		}
+	}
+	return nil
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// checkStatus returns an error for the responses which are not worth parsing.
+func checkStatus(resp *http.Response) error {
+	if err := rateLimitError(resp); err != nil {
+		return err
+	}
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("%v answered with %v", resp.Request.URL.Host, resp.Status)
+	}
+	return nil
+}
+
+// hiddenHeaders are left out of the debug log.
+var hiddenHeaders = map[string]bool{"Cookie": true, "Set-Cookie": true, "Authorization": true}
+
+var logMutex sync.Mutex
+
+// logExchange appends a request and the status and headers of its response to HTTP.DebugLog.
+// No bodies are logged, as the requests can hold passwords and the pages CSRF tokens.
+func logExchange(req *http.Request, resp *http.Response, err error, attempt int, took time.Duration) {
+	if HTTP.DebugLog == "" {
+		return
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "=== %v %v %v", time.Now().Format(time.RFC3339), req.Method, req.URL)
+	if attempt > 0 {
+		fmt.Fprintf(&buf, " (retry %v)", attempt)
+	}
+	buf.WriteString("\n")
+	writeHeaders(&buf, "> ", req.Header)
+	if err != nil {
+		fmt.Fprintf(&buf, "! %v (%v)\n\n", err, took.Round(time.Millisecond))
+	} else {
+		fmt.Fprintf(&buf, "< %v (%v)\n", resp.Status, took.Round(time.Millisecond))
+		writeHeaders(&buf, "< ", resp.Header)
+		buf.WriteString("\n")
+	}
+
+	logMutex.Lock()
+	defer logMutex.Unlock()
+	f, err := os.OpenFile(HTTP.DebugLog, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	f.Write(buf.Bytes())
+}
+
+func writeHeaders(buf *bytes.Buffer, prefix string, header http.Header) {
+	keys := make([]string, 0, len(header))
+	for key := range header {
+		if !hiddenHeaders[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, value := range header[key] {
+			fmt.Fprintf(buf, "%v%v: %v\n", prefix, key, value)
+		}
+	}
+}
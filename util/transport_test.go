@@ -0,0 +1,112 @@
+package util
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTransport(t *testing.T) {
+	retryWait = time.Millisecond
+	HTTP = HTTPOptions{Timeout: 100 * time.Millisecond, Retries: 2, UserAgent: "st-test", DebugLog: filepath.Join(t.TempDir(), "debug.log")}
+	defer func() { HTTP = HTTPOptions{Timeout: 30 * time.Second, Retries: 2, UserAgent: DefaultUserAgent} }()
+
+	var mutex sync.Mutex
+	calls := map[string]int{}
+	count := func(path string) int {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return calls[path]
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		calls[r.URL.Path]++
+		mutex.Unlock()
+		if r.UserAgent() != "st-test" {
+			t.Errorf("Expect the configured user-agent, but found %q.", r.UserAgent())
+		}
+		switch r.URL.Path {
+		case "/flaky":
+			if count(r.URL.Path) < 3 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			io.WriteString(w, "ok")
+		case "/down":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "/slow":
+			time.Sleep(300 * time.Millisecond)
+		case "/download":
+			// the body takes longer than the timeout, but arrives
+			w.(http.Flusher).Flush()
+			for i := 0; i < 3; i++ {
+				time.Sleep(50 * time.Millisecond)
+				io.WriteString(w, "part ")
+				w.(http.Flusher).Flush()
+			}
+		case "/challenge":
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusForbidden)
+			io.WriteString(w, "<html><head><title>Just a moment...</title></head></html>")
+		case "/maintenance":
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			io.WriteString(w, "<h1>Site under maintenance</h1>")
+		case "/forbidden":
+			w.WriteHeader(http.StatusForbidden)
+			io.WriteString(w, "no access")
+		}
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: &Transport{}}
+
+	body, err := GetBody(client, server.URL+"/flaky")
+	if err != nil || string(body) != "ok" || count("/flaky") != 3 {
+		t.Errorf("Expect ok after 2 retries, but found %q (%v) after %v calls.", body, err, count("/flaky"))
+	}
+	if _, err = GetBody(client, server.URL+"/down"); err == nil || !strings.Contains(err.Error(), "500") {
+		t.Errorf("Expect an error for the status 500, but found %v.", err)
+	}
+	if _, err = PostBody(client, server.URL+"/down", url.Values{"password": {"secret"}}); err == nil || count("/down") != 4 {
+		t.Errorf("Expect no retries of a POST, but found %v calls (%v).", count("/down"), err)
+	}
+	req, _ := http.NewRequest("GET", server.URL+"/down", nil)
+	if resp, err := client.Do(WithoutRetries(req)); err != nil || resp.StatusCode != http.StatusInternalServerError || count("/down") != 5 {
+		t.Errorf("Expect no retries of a request sent without them, but found %v calls (%v).", count("/down"), err)
+	} else {
+		resp.Body.Close()
+	}
+	if _, err = GetBody(client, server.URL+"/slow"); err == nil || count("/slow") != 3 {
+		t.Errorf("Expect a timeout after 3 attempts, but found %v calls (%v).", count("/slow"), err)
+	}
+	if body, err = GetBody(client, server.URL+"/download"); err != nil || string(body) != "part part part " {
+		t.Errorf("Expect the whole slow body, but found %q (%v).", body, err)
+	}
+	for path, want := range map[string]error{"/challenge": ErrorChallenge, "/maintenance": ErrorMaintenance} {
+		if _, err = GetBody(client, server.URL+path); !errors.Is(err, want) {
+			t.Errorf("%v: expect %v, but found %v.", path, want, err)
+		}
+	}
+	if body, err = GetBody(client, server.URL+"/forbidden"); err != nil || string(body) != "no access" {
+		t.Errorf("Expect the page of a plain 403, but found %q (%v).", body, err)
+	}
+
+	log, err := os.ReadFile(HTTP.DebugLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(log), "GET "+server.URL+"/flaky (retry 2)") || !strings.Contains(string(log), "< 403 Forbidden") {
+		t.Errorf("Expect the requests and the responses in the debug log, but found:\n%s", log)
+	}
+	if strings.Contains(string(log), "secret") || strings.Contains(string(log), "Just a moment...") {
+		t.Errorf("Expect no bodies in the debug log, but found:\n%s", log)
+	}
+}
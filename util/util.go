@@ -1,17 +1,21 @@
 package util
 
 import (
+	"archive/zip"
 	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"math/rand"
 	"net/http"
 	"net/url"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
 	"unicode"
 
@@ -35,6 +39,9 @@ func GetBody(client *http.Client, URL string) ([]byte, error) {
 		return nil, err
 	}
 	defer resp.Body.Close()
+	if err = checkStatus(resp); err != nil {
+		return nil, err
+	}
 	return io.ReadAll(resp.Body)
 }
 
@@ -44,31 +51,10 @@ func PostBody(client *http.Client, URL string, data url.Values) ([]byte, error)
 		return nil, err
 	}
 	defer resp.Body.Close()
-	return io.ReadAll(resp.Body)
-}
-
-func DebugSave(data interface{}) {
-	f, err := os.OpenFile("./tmp/body", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		log.Fatal(err)
-	}
-	if dataBytes, ok := data.([]byte); ok {
-		// Write the slice of bytes to the file
-		if _, err := f.Write(dataBytes); err != nil {
-			log.Fatal(err)
-		}
-	} else {
-		// Convert the value of data to a string
-		dataString := fmt.Sprintf("%v\n\n", data)
-
-		// Write the string to the file
-		if _, err := f.Write([]byte(dataString)); err != nil {
-			log.Fatal(err)
-		}
-	}
-	if err := f.Close(); err != nil {
-		log.Fatal(err)
+	if err = checkStatus(resp); err != nil {
+		return nil, err
 	}
+	return io.ReadAll(resp.Body)
 }
 
 func DebugJSON(data interface{}) {
@@ -217,3 +203,67 @@ func FileExists(path string) bool {
 	}
 	return true
 }
+
+// MapSourcePaths replaces "file.ext:line" references to the judge's copy of a source file
+// in a compiler log with the path of the local file.
+func MapSourcePaths(compilerLog, sourcePath string) string {
+	ext := strings.TrimPrefix(filepath.Ext(sourcePath), ".")
+	if sourcePath == "" || ext == "" {
+		return compilerLog
+	}
+	reg := regexp.MustCompile(`[^\s:'"()]+\.` + regexp.QuoteMeta(ext) + `:(\d+)`)
+	return reg.ReplaceAllString(compilerLog, sourcePath+":$1")
+}
+
+func PrintCompilationLog(compilerLog, sourcePath string) {
+	color.Red("Compilation log:")
+	fmt.Println(strings.TrimRight(MapSourcePaths(compilerLog, sourcePath), "\n"))
+}
+
+// SamplesFromZip returns the samples of the zip file of a problem, the input of every "X.in" file
+// with the answer from "X.ans", ordered by X.
+func SamplesFromZip(data []byte) (input [][]byte, output [][]byte, err error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return
+	}
+	files := map[string]*zip.File{}
+	var names []string
+	for _, file := range archive.File {
+		files[file.Name] = file
+		if strings.HasSuffix(file.Name, ".in") {
+			names = append(names, strings.TrimSuffix(file.Name, ".in"))
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if len(names[i]) != len(names[j]) {
+			return len(names[i]) < len(names[j])
+		}
+		return names[i] < names[j]
+	})
+	read := func(file *zip.File) ([]byte, error) {
+		r, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return io.ReadAll(r)
+	}
+	for _, name := range names {
+		answer, ok := files[name+".ans"]
+		if !ok {
+			continue
+		}
+		in, err := read(files[name+".in"])
+		if err != nil {
+			return nil, nil, err
+		}
+		out, err := read(answer)
+		if err != nil {
+			return nil, nil, err
+		}
+		input = append(input, AddNewLine(in))
+		output = append(output, AddNewLine(out))
+	}
+	return
+}
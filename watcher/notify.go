@@ -0,0 +1,58 @@
+package watcher
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/Arapak/sio-tool/util"
+
+	"github.com/fatih/color"
+)
+
+type Notifier struct {
+	Bell    bool
+	Desktop bool
+	// Hook is a command run for every verdict, with the submission in the ST_* environment variables.
+	Hook string
+}
+
+func (s *Submission) message() string {
+	message := s.Status
+	if s.Points != "" {
+		message += fmt.Sprintf(" (%v points)", s.Points)
+	}
+	return message
+}
+
+func (n *Notifier) Notify(s Submission) {
+	if n.Bell {
+		fmt.Print("\a")
+	}
+	title := fmt.Sprintf("%v #%v %v", s.Judge, s.ID, s.Problem)
+	color.Cyan(title)
+	fmt.Printf("  %v\n", s.message())
+
+	if n.Desktop {
+		if err := exec.Command("notify-send", "st: "+strings.TrimSpace(title), s.message()).Run(); err != nil {
+			color.Red("notify-send: %v", err.Error())
+		}
+	}
+	if cmds := util.SplitCmd(n.Hook); len(cmds) > 0 {
+		cmd := exec.Command(cmds[0], cmds[1:]...)
+		cmd.Env = append(os.Environ(),
+			"ST_JUDGE="+s.Judge,
+			"ST_SUBMISSION="+s.ID,
+			"ST_CONTEST="+s.Contest,
+			"ST_PROBLEM="+s.Problem,
+			"ST_STATUS="+s.Status,
+			"ST_POINTS="+s.Points,
+		)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			color.Red("hook: %v", err.Error())
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// Package watcher tracks pending submissions on all judges and notifies about their verdicts.
+package watcher
+
+import (
+	"encoding/json"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Arapak/sio-tool/credentials"
+	"github.com/mitchellh/go-homedir"
+)
+
+const statePath = "~/.st/watcher.json"
+
+// forgetAfter is the time after which a submission that never got a final verdict stops being tracked.
+const forgetAfter = 24 * time.Hour
+
+type Submission struct {
+	Judge   string    `json:"judge"`
+	ID      string    `json:"id"`
+	Contest string    `json:"contest"`
+	Problem string    `json:"problem"`
+	Status  string    `json:"status"`
+	Points  string    `json:"points"`
+	Waiting bool      `json:"waiting"`
+	End     bool      `json:"-"`
+	Added   time.Time `json:"added"`
+}
+
+func (s *Submission) key() string {
+	return s.Judge + "/" + s.ID
+}
+
+// State is saved after every check, so that a restarted watcher keeps tracking the same submissions.
+type State struct {
+	// Contests are the contests to check on the judges which have no list of all submissions.
+	Contests map[string][]string   `json:"contests"`
+	Pending  map[string]Submission `json:"pending"`
+	// Latest is the ID of the newest submission seen on each judge, so that a submission
+	// judged between two checks is noticed even though it was never seen pending.
+	Latest map[string]uint64 `json:"latest"`
+}
+
+func path() (string, error) {
+	return homedir.Expand(statePath)
+}
+
+func Load() (state State, err error) {
+	state = State{Contests: map[string][]string{}, Pending: map[string]Submission{}, Latest: map[string]uint64{}}
+	path, err := path()
+	if err != nil {
+		return
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	if err = json.Unmarshal(data, &state); err != nil {
+		return
+	}
+	if state.Contests == nil {
+		state.Contests = map[string][]string{}
+	}
+	if state.Pending == nil {
+		state.Pending = map[string]Submission{}
+	}
+	if state.Latest == nil {
+		state.Latest = map[string]uint64{}
+	}
+	return
+}
+
+func (state *State) Save() error {
+	path, err := path()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return credentials.WriteFile(path, data)
+}
+
+// AddContest makes the watcher check the contest on the judge.
+func AddContest(judge, contest string) error {
+	state, err := Load()
+	if err != nil {
+		return err
+	}
+	for _, c := range state.Contests[judge] {
+		if c == contest {
+			return nil
+		}
+	}
+	state.Contests[judge] = append(state.Contests[judge], contest)
+	return state.Save()
+}
+
+// update compares the fetched submissions with the tracked ones and returns those with a new verdict or score.
+func (state *State) update(submissions []Submission, now time.Time) (changed []Submission) {
+	latest := make(map[string]uint64)
+	for _, s := range submissions {
+		id, _ := strconv.ParseUint(s.ID, 10, 64)
+		last, known := state.Latest[s.Judge]
+		if id > latest[s.Judge] {
+			latest[s.Judge] = id
+		}
+		previous, tracked := state.Pending[s.key()]
+		if !tracked {
+			if s.End && (!known || id <= last) {
+				continue
+			}
+			s.Added = now
+		} else {
+			s.Added = previous.Added
+		}
+		if !s.Waiting && (!tracked || previous.Waiting || previous.Status != s.Status || previous.Points != s.Points) {
+			changed = append(changed, s)
+		}
+		if s.End {
+			delete(state.Pending, s.key())
+		} else {
+			state.Pending[s.key()] = s
+		}
+	}
+	for judge, id := range latest {
+		if id > state.Latest[judge] {
+			state.Latest[judge] = id
+		}
+	}
+	for key, s := range state.Pending {
+		if now.Sub(s.Added) > forgetAfter {
+			delete(state.Pending, key)
+		}
+	}
+	return
+}
@@ -0,0 +1,57 @@
+package watcher
+
+import (
+	"time"
+
+	"github.com/Arapak/sio-tool/util"
+
+	"github.com/fatih/color"
+)
+
+// Source fetches the latest submissions from one judge. The state holds the contests to check.
+type Source struct {
+	Judge string
+	Fetch func(state *State) ([]Submission, error)
+}
+
+// check fetches the submissions from every source and reports whether any verdict has arrived.
+func check(sources []Source, notifier *Notifier, poller *util.Poller) (changed bool, err error) {
+	state, err := Load()
+	if err != nil {
+		return
+	}
+	now := time.Now()
+	for _, source := range sources {
+		submissions, err := source.Fetch(&state)
+		if err != nil {
+			if !poller.Backoff(err) {
+				color.Red("%v: %v", source.Judge, err.Error())
+			}
+			continue
+		}
+		for _, s := range state.update(submissions, now) {
+			notifier.Notify(s)
+			changed = true
+		}
+	}
+	return changed, state.Save()
+}
+
+// Run checks the sources until it is stopped and notifies about every new verdict or revealed score.
+func Run(sources []Source, notifier *Notifier) error {
+	color.Green("Watching submissions on %v judges, press Ctrl+C to stop", len(sources))
+	poller := util.NewPoller()
+	poller.Options.Timeout = 0
+	for {
+		changed, err := check(sources, notifier, poller)
+		if err != nil {
+			return err
+		}
+		if changed {
+			poller.Reset()
+		}
+		if err = poller.Wait(); err != nil {
+			return err
+		}
+	}
+}